@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
@@ -9,12 +10,22 @@ import (
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/config"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/activity"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/admin"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/announcement"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/assignment"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/attachment"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/auth"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/category"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/certificate"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/coursearchive"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/discussion"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/learningpath"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/note"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/notification"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/payment"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/progress"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/quiz"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/review"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/session"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
@@ -62,13 +73,39 @@ func main() {
 	db := database.GetDB()
 	if err := db.AutoMigrate(
 		&auth.User{},
+		&category.Category{},
 		&course.Course{},
+		&course.Section{},
 		&course.Lesson{},
+		&course.LessonRevision{},
+		&course.LessonAttachment{},
 		&course.Enrollment{},
+		&course.WaitlistEntry{},
+		&course.CoursePrerequisite{},
+		&course.CourseCollaborator{},
+		&course.CourseStatusEvent{},
+		&course.CourseSlugHistory{},
+		&course.Tag{},
+		&course.CourseTag{},
 		&payment.PaymentTransaction{},
 		&progress.LessonProgress{},
+		&quiz.Quiz{},
+		&quiz.Question{},
+		&quiz.QuestionOption{},
+		&quiz.Attempt{},
+		&quiz.AttemptAnswer{},
+		&learningpath.LearningPath{},
+		&learningpath.PathCourse{},
+		&assignment.Submission{},
 		&review.CourseReview{},
 		&activity.ActivityLog{},
+		&notification.Notification{},
+		&announcement.Announcement{},
+		&announcement.AnnouncementRead{},
+		&discussion.Comment{},
+		&discussion.CommentUpvote{},
+		&note.Note{},
+		&note.Bookmark{},
 		&withdrawal.InstructorEarning{},
 		&withdrawal.WithdrawalRequest{},
 		&withdrawal.InstructorBankAccount{},
@@ -77,6 +114,16 @@ func main() {
 	}
 	logger.Info("Database migrations completed")
 
+	// Seed the default course categories on a fresh database
+	if err := category.NewRepository(db).SeedDefaults(context.Background()); err != nil {
+		logger.Fatal("Failed to seed course categories", zap.Error(err))
+	}
+
+	// Courses published before the review workflow keep showing in the catalog
+	if err := course.NewRepository(db).BackfillCourseStatus(context.Background()); err != nil {
+		logger.Fatal("Failed to backfill course status", zap.Error(err))
+	}
+
 	// Set Gin mode based on environment
 	if cfg.Server.AppEnv == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -157,21 +204,35 @@ func main() {
 		// Register user routes
 		user.RegisterRoutes(v1, userHandler, authMiddleware)
 
-		// Register course routes
-		course.RegisterRoutes(v1, db, authMiddleware)
+		// Initialize notification module (in-app notifications sent by other modules)
+		notificationService := notification.NewService(notification.NewRepository(db))
+		notification.RegisterRoutes(v1, notificationService, authMiddleware)
+
+		// Register course routes (review decisions notify the instructor)
+		course.RegisterRoutes(v1, db, authMiddleware, notificationService)
+
+		// Register category routes
+		category.RegisterRoutes(v1, db, authMiddleware)
 
 		// Register session routes
 		session.RegisterRoutes(v1, db, authMiddleware)
 
 		// Initialize progress module
 		courseRepo := course.NewRepository(db)
+		courseService := course.NewService(courseRepo, notificationService)
 		progressRepo := progress.NewRepository(db)
-		progressService := progress.NewService(progressRepo, courseRepo)
+		progressService := progress.NewService(progressRepo, courseRepo, courseService)
 		progressHandler := progress.NewHandler(progressService)
 
 		// Register progress routes
 		progress.RegisterRoutes(v1, progressHandler, authMiddleware)
 
+		// Register quiz routes
+		quiz.RegisterRoutes(v1, db, authMiddleware, notificationService)
+
+		// Register learning path routes
+		learningpath.RegisterRoutes(v1, db, authMiddleware, notificationService)
+
 		// Initialize certificate module
 		certificateRepo := certificate.NewCertificateRepository(db)
 	certificateService := certificate.NewCertificateServiceFull(certificateRepo, progressService, userRepo, courseRepo)
@@ -185,6 +246,24 @@ func main() {
 		// Register upload routes
 		upload.RegisterRoutes(v1, uploadHandler, authMiddleware)
 
+		// Register assignment routes (submissions are stored via the upload service)
+		assignment.RegisterRoutes(v1, db, authMiddleware, notificationService, uploadService)
+
+		// Register course archive routes (imported assets are stored via the upload service)
+		coursearchive.RegisterRoutes(v1, db, authMiddleware, uploadService)
+
+		// Register lesson attachment routes (files are stored via the upload service)
+		attachment.RegisterRoutes(v1, db, authMiddleware, notificationService, uploadService)
+
+		// Register course announcement routes
+		announcement.RegisterRoutes(v1, db, authMiddleware)
+
+		// Register lesson discussion routes (reply and accepted-answer notifications)
+		discussion.RegisterRoutes(v1, db, authMiddleware, notificationService)
+
+		// Register private lesson note and bookmark routes
+		note.RegisterRoutes(v1, db, authMiddleware, notificationService)
+
 		// Admin or Instructor middleware (for shared resources)
 		// This allows both admin and instructor to access certain endpoints
 		// Actual data filtering is done in service layer based on user role
@@ -211,7 +290,7 @@ func main() {
 			IsProduction: cfg.Midtrans.IsProduction,
 			BaseURL:      cfg.Midtrans.BaseURL,
 		}
-		paymentService := payment.NewPaymentService(paymentRepo, courseRepo, authRepo, paymentConfig)
+		paymentService := payment.NewPaymentService(paymentRepo, courseRepo, courseService, authRepo, paymentConfig)
 		paymentHandler := payment.NewPaymentHandler(paymentService)
 
 		// Register payment routes
@@ -0,0 +1,35 @@
+// Command backfillratings recomputes the denormalized average_rating and review_count of
+// every course from course_reviews. Run it once after adding the columns, or to repair
+// the aggregates after editing reviews by hand; the API keeps them in sync otherwise.
+//
+//	go run ./cmd/backfillratings
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/config"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/review"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/database"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("❌ Failed to load config: %v", err)
+	}
+	if err := logger.InitLogger(cfg.Server.AppEnv); err != nil {
+		log.Fatalf("❌ Failed to initialize logger: %v", err)
+	}
+	if err := database.ConnectDB(cfg); err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+
+	updated, err := review.NewRepository(database.GetDB()).RecalculateAllCourseRatings()
+	if err != nil {
+		log.Fatalf("❌ Backfill failed: %v", err)
+	}
+	fmt.Printf("✅ Course ratings recalculated (%d courses changed)\n", updated)
+}
@@ -0,0 +1,119 @@
+// Command coursearchive exports a course to a zip archive or imports one, using the
+// same code as the API endpoints (GET /courses/:id/export, POST /courses/import).
+//
+//	go run ./cmd/coursearchive export -course 12 -out course.zip
+//	go run ./cmd/coursearchive import -in course.zip -instructor 7
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/config"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/coursearchive"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/database"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/firebase"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	fmt.Fprintln(os.Stderr, "  coursearchive export -course ID -out FILE.zip")
+	fmt.Fprintln(os.Stderr, "  coursearchive import -in FILE.zip -instructor USER_ID")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	exportCmd := flag.NewFlagSet("export", flag.ExitOnError)
+	courseID := exportCmd.Uint("course", 0, "ID of the course to export")
+	out := exportCmd.String("out", "", "archive file to write (default: <slug>.zip)")
+
+	importCmd := flag.NewFlagSet("import", flag.ExitOnError)
+	in := importCmd.String("in", "", "archive file to import")
+	instructorID := importCmd.Uint("instructor", 0, "user ID that will own the imported course")
+
+	switch os.Args[1] {
+	case "export":
+		exportCmd.Parse(os.Args[2:])
+		if *courseID == 0 {
+			usage()
+		}
+	case "import":
+		importCmd.Parse(os.Args[2:])
+		if *in == "" || *instructorID == 0 {
+			usage()
+		}
+	default:
+		usage()
+	}
+
+	service := newService()
+	ctx := context.Background()
+
+	// The CLI acts as an admin: it has direct database access anyway
+	switch os.Args[1] {
+	case "export":
+		archive, err := service.ExportCourse(ctx, 0, "admin", *courseID)
+		if err != nil {
+			log.Fatalf("❌ Export failed: %v", err)
+		}
+		path := *out
+		if path == "" {
+			path = archive.Filename
+		}
+		if err := os.WriteFile(path, archive.Data, 0o644); err != nil {
+			log.Fatalf("❌ Failed to write archive: %v", err)
+		}
+		fmt.Printf("✅ Course %d exported to %s (%d bytes)\n", *courseID, path, len(archive.Data))
+
+	case "import":
+		data, err := os.ReadFile(*in)
+		if err != nil {
+			log.Fatalf("❌ Failed to read archive: %v", err)
+		}
+		result, err := service.ImportCourse(ctx, *instructorID, "admin", data)
+		if err != nil {
+			if manifestErr, ok := err.(*coursearchive.ManifestError); ok {
+				for _, problem := range manifestErr.Problems {
+					fmt.Fprintln(os.Stderr, "  -", problem)
+				}
+			}
+			log.Fatalf("❌ Import failed: %v", err)
+		}
+		report, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(report))
+		if len(result.LessonErrors) > 0 {
+			os.Exit(1)
+		}
+	}
+}
+
+// newService connects to the database and storage like the API server does
+func newService() coursearchive.Service {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("❌ Failed to load config: %v", err)
+	}
+	if err := logger.InitLogger(cfg.Server.AppEnv); err != nil {
+		log.Fatalf("❌ Failed to initialize logger: %v", err)
+	}
+	if err := database.ConnectDB(cfg); err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	if err := firebase.InitializeFirebase(); err != nil {
+		log.Fatalf("❌ Failed to initialize Firebase: %v", err)
+	}
+
+	db := database.GetDB()
+	return coursearchive.NewService(coursearchive.NewRepository(db), course.NewRepository(db), upload.NewService())
+}
@@ -36,7 +36,7 @@ func (s *service) GetDashboardStats(ctx context.Context, userID uint, userRole s
 	}
 
 	// 2. Count published courses
-	publishedQuery := s.db.Table("courses").Where("is_published = ? AND deleted_at IS NULL", true)
+	publishedQuery := s.db.Table("courses").Where("status = ? AND deleted_at IS NULL", "published")
 	if userRole == "instructor" {
 		publishedQuery = publishedQuery.Where("instructor_id = ?", userID)
 	}
@@ -0,0 +1,52 @@
+package announcement
+
+import "time"
+
+// CreateAnnouncementRequest represents an announcement posted by the course team
+type CreateAnnouncementRequest struct {
+	Title    string `json:"title" binding:"required,min=3,max=200"`
+	Body     string `json:"body" binding:"required"` // MDX
+	IsPinned bool   `json:"is_pinned"`
+}
+
+// UpdateAnnouncementRequest represents announcement update payload
+type UpdateAnnouncementRequest struct {
+	Title    *string `json:"title" binding:"omitempty,min=3,max=200"`
+	Body     *string `json:"body" binding:"omitempty,min=1"`
+	IsPinned *bool   `json:"is_pinned"`
+}
+
+// AnnouncementListQuery represents query parameters for listing announcements
+type AnnouncementListQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+// AnnouncementResponse is an announcement as seen by the requesting user
+type AnnouncementResponse struct {
+	ID         uint      `json:"id"`
+	CourseID   uint      `json:"course_id"`
+	AuthorID   uint      `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	IsPinned   bool      `json:"is_pinned"`
+	IsRead     bool      `json:"is_read"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// AnnouncementListResponse represents a page of announcements, pinned first then newest
+type AnnouncementListResponse struct {
+	Announcements []*AnnouncementResponse `json:"announcements"`
+	UnreadCount   int                     `json:"unread_count"`
+	Pagination    PaginationMeta          `json:"pagination"`
+}
+
+// PaginationMeta represents pagination information
+type PaginationMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
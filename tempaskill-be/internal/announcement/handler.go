@@ -0,0 +1,213 @@
+package announcement
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	switch err {
+	case ErrCourseNotFound, ErrAnnouncementNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrNotEnrolled, ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUser returns the authenticated user's ID and role from the JWT middleware
+func getUser(c *gin.Context) (uint, string, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return userID.(uint), role, true
+}
+
+// parseIDs parses the course ID and, when present, the announcement ID from the path
+func parseIDs(c *gin.Context) (uint, uint, bool) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return 0, 0, false
+	}
+	if c.Param("announcementId") == "" {
+		return uint(courseID), 0, true
+	}
+	announcementID, err := strconv.ParseUint(c.Param("announcementId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid announcement ID"})
+		return 0, 0, false
+	}
+	return uint(courseID), uint(announcementID), true
+}
+
+// ListAnnouncements handles GET /courses/:id/announcements
+func (h *Handler) ListAnnouncements(c *gin.Context) {
+	courseID, _, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var query AnnouncementListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListAnnouncements(c.Request.Context(), userID, userRole, courseID, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// CreateAnnouncement handles POST /courses/:id/announcements
+func (h *Handler) CreateAnnouncement(c *gin.Context) {
+	courseID, _, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	announcement, err := h.service.CreateAnnouncement(c.Request.Context(), userID, userRole, courseID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Announcement posted successfully",
+		"data":    announcement,
+	})
+}
+
+// UpdateAnnouncement handles PATCH /courses/:id/announcements/:announcementId
+func (h *Handler) UpdateAnnouncement(c *gin.Context) {
+	courseID, announcementID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateAnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	announcement, err := h.service.UpdateAnnouncement(c.Request.Context(), userID, userRole, courseID, announcementID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Announcement updated successfully",
+		"data":    announcement,
+	})
+}
+
+// DeleteAnnouncement handles DELETE /courses/:id/announcements/:announcementId
+func (h *Handler) DeleteAnnouncement(c *gin.Context) {
+	courseID, announcementID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAnnouncement(c.Request.Context(), userID, userRole, courseID, announcementID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Announcement deleted successfully",
+	})
+}
+
+// MarkRead handles POST /courses/:id/announcements/:announcementId/read
+func (h *Handler) MarkRead(c *gin.Context) {
+	courseID, announcementID, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.MarkRead(c.Request.Context(), userID, userRole, courseID, announcementID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Announcement marked as read",
+	})
+}
+
+// MarkAllRead handles POST /courses/:id/announcements/read-all
+func (h *Handler) MarkAllRead(c *gin.Context) {
+	courseID, _, ok := parseIDs(c)
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.MarkAllRead(c.Request.Context(), userID, userRole, courseID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "All announcements marked as read",
+	})
+}
@@ -0,0 +1,34 @@
+package announcement
+
+import "time"
+
+// Announcement is a message from the course team to the course's enrolled students
+// (e.g. new content or a schedule change)
+type Announcement struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CourseID  uint      `gorm:"not null;index:idx_course_announcements_course_pinned" json:"course_id"`
+	AuthorID  uint      `gorm:"not null" json:"author_id"`
+	Title     string    `gorm:"type:varchar(200);not null" json:"title"`
+	Body      string    `gorm:"type:longtext;not null" json:"body"` // MDX, like lesson content
+	IsPinned  bool      `gorm:"not null;default:false;index:idx_course_announcements_course_pinned" json:"is_pinned"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AnnouncementRead records that a user has read an announcement
+type AnnouncementRead struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	AnnouncementID uint      `gorm:"not null;uniqueIndex:idx_announcement_reads_pair" json:"announcement_id"`
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_announcement_reads_pair;index" json:"user_id"`
+	ReadAt         time.Time `json:"read_at"`
+}
+
+// TableName specifies the table name for Announcement model
+func (Announcement) TableName() string {
+	return "course_announcements"
+}
+
+// TableName specifies the table name for AnnouncementRead model
+func (AnnouncementRead) TableName() string {
+	return "announcement_reads"
+}
@@ -0,0 +1,118 @@
+package announcement
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateAnnouncement(ctx context.Context, announcement *Announcement) error
+	FindAnnouncementByID(ctx context.Context, id uint) (*Announcement, error)
+	FindAnnouncements(ctx context.Context, courseID, userID uint, limit, offset int) ([]*AnnouncementResponse, int, error)
+	UpdateAnnouncement(ctx context.Context, announcement *Announcement) error
+	DeleteAnnouncement(ctx context.Context, id uint) error
+	CountUnread(ctx context.Context, courseID, userID uint) (int, error)
+	MarkRead(ctx context.Context, announcementID, userID uint) error
+	MarkAllRead(ctx context.Context, courseID, userID uint) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateAnnouncement(ctx context.Context, announcement *Announcement) error {
+	if err := r.db.WithContext(ctx).Create(announcement).Error; err != nil {
+		logger.Error("Failed to create announcement",
+			zap.Error(err),
+			zap.Uint("course_id", announcement.CourseID),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) FindAnnouncementByID(ctx context.Context, id uint) (*Announcement, error) {
+	var announcement Announcement
+	if err := r.db.WithContext(ctx).First(&announcement, id).Error; err != nil {
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// FindAnnouncements returns a page of a course's announcements, pinned first then newest,
+// with the author's name and whether the user has read each one
+func (r *repository) FindAnnouncements(ctx context.Context, courseID, userID uint, limit, offset int) ([]*AnnouncementResponse, int, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&Announcement{}).
+		Where("course_id = ?", courseID).
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var announcements []*AnnouncementResponse
+	if err := r.db.WithContext(ctx).Table("course_announcements AS a").
+		Select("a.*, COALESCE(users.name, '') AS author_name, ar.id IS NOT NULL AS is_read").
+		Joins("LEFT JOIN users ON users.id = a.author_id").
+		Joins("LEFT JOIN announcement_reads AS ar ON ar.announcement_id = a.id AND ar.user_id = ?", userID).
+		Where("a.course_id = ?", courseID).
+		Order("a.is_pinned DESC, a.created_at DESC, a.id DESC").
+		Limit(limit).Offset(offset).
+		Scan(&announcements).Error; err != nil {
+		logger.Error("Database error finding announcements",
+			zap.Error(err),
+			zap.Uint("course_id", courseID),
+		)
+		return nil, 0, err
+	}
+	return announcements, int(total), nil
+}
+
+func (r *repository) UpdateAnnouncement(ctx context.Context, announcement *Announcement) error {
+	return r.db.WithContext(ctx).Model(announcement).
+		Select("title", "body", "is_pinned").
+		Updates(announcement).Error
+}
+
+// DeleteAnnouncement deletes an announcement with its read receipts
+func (r *repository) DeleteAnnouncement(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("announcement_id = ?", id).Delete(&AnnouncementRead{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&Announcement{}, id).Error
+	})
+}
+
+// CountUnread counts the course's announcements the user has not read
+func (r *repository) CountUnread(ctx context.Context, courseID, userID uint) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table("course_announcements AS a").
+		Joins("LEFT JOIN announcement_reads AS ar ON ar.announcement_id = a.id AND ar.user_id = ?", userID).
+		Where("a.course_id = ? AND ar.id IS NULL", courseID).
+		Count(&count).Error
+	return int(count), err
+}
+
+// MarkRead records a read receipt; reading again keeps the first one
+func (r *repository) MarkRead(ctx context.Context, announcementID, userID uint) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&AnnouncementRead{AnnouncementID: announcementID, UserID: userID, ReadAt: time.Now()}).Error
+}
+
+// MarkAllRead records read receipts for all of the course's announcements
+func (r *repository) MarkAllRead(ctx context.Context, courseID, userID uint) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT IGNORE INTO announcement_reads (announcement_id, user_id, read_at)
+		SELECT id, ?, ? FROM course_announcements WHERE course_id = ?`,
+		userID, time.Now(), courseID,
+	).Error
+}
@@ -0,0 +1,30 @@
+package announcement
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware) {
+	// Initialize layers
+	repo := NewRepository(db)
+	service := NewService(repo, course.NewRepository(db))
+	handler := NewHandler(service)
+
+	// All announcement routes require authentication
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// Reading (enrolled students and the course team)
+		protected.GET("/courses/:id/announcements", handler.ListAnnouncements)              // Pinned first, with read state and unread count
+		protected.POST("/courses/:id/announcements/read-all", handler.MarkAllRead)          // Mark every announcement read
+		protected.POST("/courses/:id/announcements/:announcementId/read", handler.MarkRead) // Mark one announcement read
+
+		// Posting (instructor, co-instructors/TAs or admin - authorization checked in service layer)
+		protected.POST("/courses/:id/announcements", handler.CreateAnnouncement)                   // Post announcement
+		protected.PATCH("/courses/:id/announcements/:announcementId", handler.UpdateAnnouncement)  // Edit, pin or unpin
+		protected.DELETE("/courses/:id/announcements/:announcementId", handler.DeleteAnnouncement) // Delete announcement
+	}
+}
@@ -0,0 +1,223 @@
+package announcement
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+)
+
+var (
+	ErrCourseNotFound       = errors.New("course not found")
+	ErrAnnouncementNotFound = errors.New("announcement not found")
+	ErrNotEnrolled          = errors.New("announcements are only visible to enrolled students and the course team")
+	ErrUnauthorized         = errors.New("unauthorized access")
+)
+
+type Service interface {
+	// Course team (instructor, co-instructors/TAs or admin)
+	CreateAnnouncement(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateAnnouncementRequest) (*AnnouncementResponse, error)
+	UpdateAnnouncement(ctx context.Context, userID uint, userRole string, courseID, announcementID uint, req *UpdateAnnouncementRequest) (*AnnouncementResponse, error)
+	DeleteAnnouncement(ctx context.Context, userID uint, userRole string, courseID, announcementID uint) error
+
+	// Enrolled students and the course team
+	ListAnnouncements(ctx context.Context, userID uint, userRole string, courseID uint, query *AnnouncementListQuery) (*AnnouncementListResponse, error)
+	MarkRead(ctx context.Context, userID uint, userRole string, courseID, announcementID uint) error
+	MarkAllRead(ctx context.Context, userID uint, userRole string, courseID uint) error
+}
+
+type service struct {
+	repo       Repository
+	courseRepo course.Repository
+}
+
+func NewService(repo Repository, courseRepo course.Repository) Service {
+	return &service{
+		repo:       repo,
+		courseRepo: courseRepo,
+	}
+}
+
+// Helper: Check whether the user may post to the course (instructor, co-instructors/TAs or admin)
+func (s *service) isCourseManager(ctx context.Context, userID uint, userRole string, c *course.Course) bool {
+	if userRole == "admin" || c.InstructorID == userID {
+		return true
+	}
+	collaborator, err := s.courseRepo.FindCollaborator(ctx, c.ID, userID)
+	return err == nil && course.RoleAllows(collaborator.Role, course.PermissionTeach)
+}
+
+// Helper: Load a course the user may post announcements to
+func (s *service) findManagedCourse(ctx context.Context, userID uint, userRole string, courseID uint) (*course.Course, error) {
+	c, err := s.courseRepo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+	if !s.isCourseManager(ctx, userID, userRole, c) {
+		return nil, ErrUnauthorized
+	}
+	return c, nil
+}
+
+// Helper: Check the user may read the course's announcements (active enrollment or course team)
+func (s *service) checkAudience(ctx context.Context, userID uint, userRole string, courseID uint) error {
+	c, err := s.courseRepo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return ErrCourseNotFound
+	}
+	if s.isCourseManager(ctx, userID, userRole, c) {
+		return nil
+	}
+	enrolled, err := s.courseRepo.IsUserEnrolled(ctx, userID, courseID)
+	if err != nil {
+		return err
+	}
+	if !enrolled {
+		return ErrNotEnrolled
+	}
+	return nil
+}
+
+// Helper: Load an announcement of the course
+func (s *service) findAnnouncement(ctx context.Context, courseID, announcementID uint) (*Announcement, error) {
+	announcement, err := s.repo.FindAnnouncementByID(ctx, announcementID)
+	if err != nil || announcement.CourseID != courseID {
+		return nil, ErrAnnouncementNotFound
+	}
+	return announcement, nil
+}
+
+// Helper: Convert an announcement for its author's view
+func (s *service) toResponse(ctx context.Context, announcement *Announcement) *AnnouncementResponse {
+	resp := &AnnouncementResponse{
+		ID:        announcement.ID,
+		CourseID:  announcement.CourseID,
+		AuthorID:  announcement.AuthorID,
+		Title:     announcement.Title,
+		Body:      announcement.Body,
+		IsPinned:  announcement.IsPinned,
+		IsRead:    true,
+		CreatedAt: announcement.CreatedAt,
+		UpdatedAt: announcement.UpdatedAt,
+	}
+	if author, err := s.courseRepo.FindUserByID(ctx, announcement.AuthorID); err == nil {
+		resp.AuthorName = author.Name
+	}
+	return resp
+}
+
+// Course team
+
+func (s *service) CreateAnnouncement(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateAnnouncementRequest) (*AnnouncementResponse, error) {
+	if _, err := s.findManagedCourse(ctx, userID, userRole, courseID); err != nil {
+		return nil, err
+	}
+
+	announcement := &Announcement{
+		CourseID: courseID,
+		AuthorID: userID,
+		Title:    req.Title,
+		Body:     req.Body,
+		IsPinned: req.IsPinned,
+	}
+	if err := s.repo.CreateAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	// The author has read their own announcement
+	if err := s.repo.MarkRead(ctx, announcement.ID, userID); err != nil {
+		return nil, err
+	}
+	return s.toResponse(ctx, announcement), nil
+}
+
+func (s *service) UpdateAnnouncement(ctx context.Context, userID uint, userRole string, courseID, announcementID uint, req *UpdateAnnouncementRequest) (*AnnouncementResponse, error) {
+	if _, err := s.findManagedCourse(ctx, userID, userRole, courseID); err != nil {
+		return nil, err
+	}
+	announcement, err := s.findAnnouncement(ctx, courseID, announcementID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		announcement.Title = *req.Title
+	}
+	if req.Body != nil {
+		announcement.Body = *req.Body
+	}
+	if req.IsPinned != nil {
+		announcement.IsPinned = *req.IsPinned
+	}
+	if err := s.repo.UpdateAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return s.toResponse(ctx, announcement), nil
+}
+
+func (s *service) DeleteAnnouncement(ctx context.Context, userID uint, userRole string, courseID, announcementID uint) error {
+	if _, err := s.findManagedCourse(ctx, userID, userRole, courseID); err != nil {
+		return err
+	}
+	if _, err := s.findAnnouncement(ctx, courseID, announcementID); err != nil {
+		return err
+	}
+	return s.repo.DeleteAnnouncement(ctx, announcementID)
+}
+
+// Enrolled students and the course team
+
+func (s *service) ListAnnouncements(ctx context.Context, userID uint, userRole string, courseID uint, query *AnnouncementListQuery) (*AnnouncementListResponse, error) {
+	if err := s.checkAudience(ctx, userID, userRole, courseID); err != nil {
+		return nil, err
+	}
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 {
+		limit = 20
+	}
+
+	announcements, total, err := s.repo.FindAnnouncements(ctx, courseID, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.repo.CountUnread(ctx, courseID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if announcements == nil {
+		announcements = []*AnnouncementResponse{}
+	}
+
+	return &AnnouncementListResponse{
+		Announcements: announcements,
+		UnreadCount:   unread,
+		Pagination: PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+func (s *service) MarkRead(ctx context.Context, userID uint, userRole string, courseID, announcementID uint) error {
+	if err := s.checkAudience(ctx, userID, userRole, courseID); err != nil {
+		return err
+	}
+	if _, err := s.findAnnouncement(ctx, courseID, announcementID); err != nil {
+		return err
+	}
+	return s.repo.MarkRead(ctx, announcementID, userID)
+}
+
+func (s *service) MarkAllRead(ctx context.Context, userID uint, userRole string, courseID uint) error {
+	if err := s.checkAudience(ctx, userID, userRole, courseID); err != nil {
+		return err
+	}
+	return s.repo.MarkAllRead(ctx, courseID, userID)
+}
@@ -0,0 +1,202 @@
+package announcement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// The mocks below embed the interface and only implement what the announcement service calls
+
+type mockRepository struct {
+	Repository
+	mock.Mock
+}
+
+func (m *mockRepository) CreateAnnouncement(ctx context.Context, announcement *Announcement) error {
+	announcement.ID = 40
+	return m.Called(ctx, announcement).Error(0)
+}
+
+func (m *mockRepository) FindAnnouncementByID(ctx context.Context, id uint) (*Announcement, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Announcement), args.Error(1)
+}
+
+func (m *mockRepository) FindAnnouncements(ctx context.Context, courseID, userID uint, limit, offset int) ([]*AnnouncementResponse, int, error) {
+	args := m.Called(ctx, courseID, userID, limit, offset)
+	return args.Get(0).([]*AnnouncementResponse), args.Int(1), args.Error(2)
+}
+
+func (m *mockRepository) CountUnread(ctx context.Context, courseID, userID uint) (int, error) {
+	args := m.Called(ctx, courseID, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockRepository) MarkRead(ctx context.Context, announcementID, userID uint) error {
+	return m.Called(ctx, announcementID, userID).Error(0)
+}
+
+func (m *mockRepository) MarkAllRead(ctx context.Context, courseID, userID uint) error {
+	return m.Called(ctx, courseID, userID).Error(0)
+}
+
+type mockCourseRepository struct {
+	course.Repository
+	mock.Mock
+}
+
+func (m *mockCourseRepository) FindCourseByID(ctx context.Context, id uint) (*course.Course, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.Course), args.Error(1)
+}
+
+func (m *mockCourseRepository) FindCollaborator(ctx context.Context, courseID, userID uint) (*course.CourseCollaborator, error) {
+	args := m.Called(ctx, courseID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.CourseCollaborator), args.Error(1)
+}
+
+func (m *mockCourseRepository) IsUserEnrolled(ctx context.Context, userID, courseID uint) (bool, error) {
+	args := m.Called(ctx, userID, courseID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockCourseRepository) FindUserByID(ctx context.Context, id uint) (*course.User, error) {
+	return &course.User{ID: id, Name: "Instructor"}, nil
+}
+
+const (
+	testStudentID    = uint(7)
+	testInstructorID = uint(2)
+	testTAID         = uint(5)
+	testCourseID     = uint(3)
+)
+
+var errNotFound = errors.New("record not found")
+
+func newTestService() (Service, *mockRepository, *mockCourseRepository) {
+	repo := new(mockRepository)
+	courseRepo := new(mockCourseRepository)
+	courseRepo.On("FindCourseByID", mock.Anything, testCourseID).Return(&course.Course{ID: testCourseID, InstructorID: testInstructorID}, nil)
+	courseRepo.On("FindCollaborator", mock.Anything, testCourseID, testTAID).
+		Return(&course.CourseCollaborator{CourseID: testCourseID, UserID: testTAID, Role: course.CollaboratorRoleTA}, nil)
+	courseRepo.On("FindCollaborator", mock.Anything, testCourseID, mock.Anything).Return(nil, errNotFound)
+	return NewService(repo, courseRepo), repo, courseRepo
+}
+
+// TestCheckAudience tests who may read a course's announcements
+func TestCheckAudience(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      uint
+		userRole    string
+		enrolled    bool
+		expectError error
+	}{
+		{"Enrolled student", testStudentID, "student", true, nil},
+		{"Expired or missing enrollment", testStudentID, "student", false, ErrNotEnrolled},
+		{"Course instructor", testInstructorID, "instructor", false, nil},
+		{"Teaching assistant", testTAID, "instructor", false, nil},
+		{"Admin", 99, "admin", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, courseRepo := newTestService()
+			courseRepo.On("IsUserEnrolled", mock.Anything, tt.userID, testCourseID).Return(tt.enrolled, nil)
+			repo.On("MarkAllRead", mock.Anything, testCourseID, tt.userID).Return(nil)
+
+			err := service.MarkAllRead(context.Background(), tt.userID, tt.userRole, testCourseID)
+
+			assert.Equal(t, tt.expectError, err)
+			if tt.expectError != nil {
+				repo.AssertNotCalled(t, "MarkAllRead", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+
+	// Unknown course
+	service, _, courseRepo := newTestService()
+	courseRepo.On("FindCourseByID", mock.Anything, uint(404)).Return(nil, errNotFound)
+	_, err := service.ListAnnouncements(context.Background(), testStudentID, "student", 404, &AnnouncementListQuery{})
+	assert.Equal(t, ErrCourseNotFound, err)
+}
+
+// TestCreateAnnouncement tests that only the course team posts and that the author has read the post
+func TestCreateAnnouncement(t *testing.T) {
+	service, repo, _ := newTestService()
+	repo.On("CreateAnnouncement", mock.Anything, mock.Anything).Return(nil)
+	repo.On("MarkRead", mock.Anything, uint(40), testInstructorID).Return(nil)
+
+	resp, err := service.CreateAnnouncement(context.Background(), testInstructorID, "instructor", testCourseID, &CreateAnnouncementRequest{Title: "Live session moved"})
+	require.NoError(t, err)
+	assert.True(t, resp.IsRead)
+	repo.AssertExpectations(t)
+
+	// Enrolled students cannot post
+	service, repo, _ = newTestService()
+	_, err = service.CreateAnnouncement(context.Background(), testStudentID, "student", testCourseID, &CreateAnnouncementRequest{Title: "Hi"})
+	assert.Equal(t, ErrUnauthorized, err)
+	repo.AssertNotCalled(t, "CreateAnnouncement", mock.Anything, mock.Anything)
+}
+
+// TestMarkRead tests read tracking: only the audience marks reads, and only on the course's announcements
+func TestMarkRead(t *testing.T) {
+	tests := []struct {
+		name         string
+		enrolled     bool
+		announcement *Announcement
+		expectError  error
+	}{
+		{"Marks the announcement read", true, &Announcement{ID: 40, CourseID: testCourseID}, nil},
+		{"Announcement of another course", true, &Announcement{ID: 40, CourseID: 8}, ErrAnnouncementNotFound},
+		{"Enrollment expired", false, &Announcement{ID: 40, CourseID: testCourseID}, ErrNotEnrolled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, courseRepo := newTestService()
+			courseRepo.On("IsUserEnrolled", mock.Anything, testStudentID, testCourseID).Return(tt.enrolled, nil)
+			repo.On("FindAnnouncementByID", mock.Anything, uint(40)).Return(tt.announcement, nil)
+			repo.On("MarkRead", mock.Anything, uint(40), testStudentID).Return(nil)
+
+			err := service.MarkRead(context.Background(), testStudentID, "student", testCourseID, 40)
+
+			assert.Equal(t, tt.expectError, err)
+			if tt.expectError == nil {
+				repo.AssertCalled(t, "MarkRead", mock.Anything, uint(40), testStudentID)
+			} else {
+				repo.AssertNotCalled(t, "MarkRead", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// TestListAnnouncements tests the unread count and pagination of the student's list
+func TestListAnnouncements(t *testing.T) {
+	service, repo, courseRepo := newTestService()
+	courseRepo.On("IsUserEnrolled", mock.Anything, testStudentID, testCourseID).Return(true, nil)
+	repo.On("FindAnnouncements", mock.Anything, testCourseID, testStudentID, 20, 20).
+		Return([]*AnnouncementResponse{{ID: 12, IsRead: false}}, 21, nil)
+	repo.On("CountUnread", mock.Anything, testCourseID, testStudentID).Return(3, nil)
+
+	resp, err := service.ListAnnouncements(context.Background(), testStudentID, "student", testCourseID, &AnnouncementListQuery{Page: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 3, resp.UnreadCount)
+	assert.Len(t, resp.Announcements, 1)
+	assert.Equal(t, 2, resp.Pagination.TotalPages)
+}
@@ -0,0 +1,25 @@
+package assignment
+
+import "time"
+
+// SubmitRequest represents a submission payload (multipart form or JSON).
+// The optional file is read from the "file" form field.
+type SubmitRequest struct {
+	LinkURL string `form:"link_url" json:"link_url" binding:"omitempty,url,max=500"`
+	Note    string `form:"note" json:"note" binding:"omitempty,max=2000"`
+}
+
+// GradeRequest represents an instructor's grading of a submission
+type GradeRequest struct {
+	Status   string `json:"status" binding:"required,oneof=accepted needs_revision"`
+	Score    *int   `json:"score" binding:"omitempty,min=0"`
+	Feedback string `json:"feedback" binding:"omitempty,max=5000"`
+}
+
+// SubmissionResponse is the API representation of a submission
+type SubmissionResponse struct {
+	Submission
+	MaxScore    int        `json:"max_score"`              // 0 = ungraded scale
+	DownloadURL string     `json:"download_url,omitempty"` // Short-lived, detail view only
+	URLExpires  *time.Time `json:"download_url_expires_at,omitempty"`
+}
@@ -0,0 +1,173 @@
+package assignment
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	if errors.Is(err, ErrUploadFailed) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch err {
+	case ErrLessonNotFound, ErrSubmissionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrUnauthorized, ErrNotEnrolled, ErrLessonLocked:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case ErrAlreadyAccepted, ErrNotGradable:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case ErrNotAssignment, ErrSubmissionEmpty, ErrScoreTooHigh:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUser returns the authenticated user's ID and role from the JWT middleware
+func getUser(c *gin.Context) (uint, string, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return userID.(uint), role, true
+}
+
+// parseID parses a numeric path parameter
+func parseID(c *gin.Context, param string, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// Submit handles POST /lessons/:id/submissions
+// Accepts multipart/form-data with an optional "file" plus "link_url" and "note" fields
+func (h *Handler) Submit(c *gin.Context) {
+	lessonID, ok := parseID(c, "id", "lesson")
+	if !ok {
+		return
+	}
+
+	var req SubmitRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) && !errors.Is(err, http.ErrNotMultipart) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file: " + err.Error()})
+		return
+	}
+
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	submission, err := h.service.Submit(c.Request.Context(), userID, lessonID, file, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Assignment submitted successfully",
+		"data":    submission,
+	})
+}
+
+// ListMySubmissions handles GET /lessons/:id/submissions/me
+func (h *Handler) ListMySubmissions(c *gin.Context) {
+	lessonID, ok := parseID(c, "id", "lesson")
+	if !ok {
+		return
+	}
+
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	submissions, err := h.service.ListMySubmissions(c.Request.Context(), userID, lessonID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": submissions,
+	})
+}
+
+// GetSubmission handles GET /assignments/submissions/:id
+func (h *Handler) GetSubmission(c *gin.Context) {
+	submissionID, ok := parseID(c, "id", "submission")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	submission, err := h.service.GetSubmission(c.Request.Context(), userID, userRole, submissionID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": submission,
+	})
+}
+
+// GradeSubmission handles POST /assignments/submissions/:id/grade
+func (h *Handler) GradeSubmission(c *gin.Context) {
+	submissionID, ok := parseID(c, "id", "submission")
+	if !ok {
+		return
+	}
+
+	var req GradeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	submission, err := h.service.GradeSubmission(c.Request.Context(), userID, userRole, submissionID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Submission graded successfully",
+		"data":    submission,
+	})
+}
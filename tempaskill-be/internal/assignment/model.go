@@ -0,0 +1,40 @@
+package assignment
+
+import (
+	"time"
+)
+
+// Submission statuses
+const (
+	StatusSubmitted     = "submitted"      // Waiting for grading
+	StatusNeedsRevision = "needs_revision" // Graded, student may resubmit
+	StatusAccepted      = "accepted"       // Graded and accepted, lesson completed
+	StatusSuperseded    = "superseded"     // Replaced by a newer submission before grading
+)
+
+// Submission is a student's hand-in for an assignment lesson (a file and/or a link)
+type Submission struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	LessonID    uint       `gorm:"not null;index:idx_assignment_submissions_lesson_user" json:"lesson_id"`
+	UserID      uint       `gorm:"not null;index:idx_assignment_submissions_lesson_user" json:"user_id"`
+	CourseID    uint       `gorm:"not null;index" json:"course_id"`
+	Attempt     int        `gorm:"not null;default:1" json:"attempt"` // 1, 2, 3... per student and lesson
+	Status      string     `gorm:"type:varchar(20);not null;default:'submitted';index" json:"status"`
+	FilePath    string     `gorm:"type:varchar(500)" json:"-"` // Private storage path, served via signed URL
+	FileName    string     `gorm:"type:varchar(255)" json:"file_name,omitempty"`
+	FileSize    int64      `gorm:"default:0" json:"file_size,omitempty"`
+	MimeType    string     `gorm:"type:varchar(100)" json:"mime_type,omitempty"`
+	LinkURL     string     `gorm:"type:varchar(500)" json:"link_url,omitempty"`
+	Note        string     `gorm:"type:text" json:"note,omitempty"`
+	Score       *int       `json:"score"`
+	Feedback    string     `gorm:"type:text" json:"feedback,omitempty"`
+	GradedBy    *uint      `json:"graded_by,omitempty"`
+	GradedAt    *time.Time `json:"graded_at,omitempty"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for Submission model
+func (Submission) TableName() string {
+	return "assignment_submissions"
+}
@@ -0,0 +1,110 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateSubmission(ctx context.Context, submission *Submission) error
+	FindSubmissionByID(ctx context.Context, id uint) (*Submission, error)
+	FindLatestSubmission(ctx context.Context, userID, lessonID uint) (*Submission, error)
+	FindSubmissionsByUser(ctx context.Context, userID, lessonID uint) ([]*Submission, error)
+	SaveGrade(ctx context.Context, submission *Submission) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// CreateSubmission stores a new submission and supersedes any ungraded one
+// of the same student for the lesson, so the grading queue only shows the latest.
+func (r *repository) CreateSubmission(ctx context.Context, submission *Submission) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Submission{}).
+			Where("user_id = ? AND lesson_id = ? AND status = ?", submission.UserID, submission.LessonID, StatusSubmitted).
+			Update("status", StatusSuperseded).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(submission).Error; err != nil {
+			logger.Error("Failed to create assignment submission",
+				zap.Error(err),
+				zap.Uint("lesson_id", submission.LessonID),
+				zap.Uint("user_id", submission.UserID),
+			)
+			return err
+		}
+		return nil
+	})
+}
+
+func (r *repository) FindSubmissionByID(ctx context.Context, id uint) (*Submission, error) {
+	var submission Submission
+	if err := r.db.WithContext(ctx).First(&submission, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding assignment submission",
+				zap.Error(err),
+				zap.Uint("submission_id", id),
+			)
+		}
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// FindLatestSubmission returns the student's most recent submission for a lesson
+func (r *repository) FindLatestSubmission(ctx context.Context, userID, lessonID uint) (*Submission, error) {
+	var submission Submission
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND lesson_id = ?", userID, lessonID).
+		Order("attempt DESC").
+		First(&submission).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+func (r *repository) FindSubmissionsByUser(ctx context.Context, userID, lessonID uint) ([]*Submission, error) {
+	var submissions []*Submission
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND lesson_id = ?", userID, lessonID).
+		Order("attempt DESC").
+		Find(&submissions).Error; err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// SaveGrade stores the grading result. Only the pending submission can be graded,
+// which protects against grading a superseded or already graded hand-in concurrently.
+func (r *repository) SaveGrade(ctx context.Context, submission *Submission) error {
+	result := r.db.WithContext(ctx).Model(&Submission{}).
+		Where("id = ? AND status = ?", submission.ID, StatusSubmitted).
+		Updates(map[string]interface{}{
+			"status":    submission.Status,
+			"score":     submission.Score,
+			"feedback":  submission.Feedback,
+			"graded_by": submission.GradedBy,
+			"graded_at": submission.GradedAt,
+		})
+	if result.Error != nil {
+		logger.Error("Failed to save assignment grade",
+			zap.Error(result.Error),
+			zap.Uint("submission_id", submission.ID),
+		)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotGradable
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package assignment
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/progress"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, notifier course.Notifier, uploadService upload.Service) {
+	// Initialize layers
+	repo := NewRepository(db)
+	courseRepo := course.NewRepository(db)
+	service := NewService(repo, courseRepo, course.NewService(courseRepo, notifier), progress.NewRepository(db), uploadService)
+	handler := NewHandler(service)
+
+	// All assignment routes require authentication
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// Student submissions (enrolled students)
+		protected.POST("/lessons/:id/submissions", handler.Submit)              // Submit file and/or link
+		protected.GET("/lessons/:id/submissions/me", handler.ListMySubmissions) // My submission history
+
+		// Submission detail (owner, course instructor or admin)
+		protected.GET("/assignments/submissions/:id", handler.GetSubmission)
+
+		// Grading (course instructor or admin - authorization checked in service layer)
+		protected.POST("/assignments/submissions/:id/grade", handler.GradeSubmission)
+	}
+}
@@ -0,0 +1,287 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/progress"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrLessonNotFound     = errors.New("lesson not found")
+	ErrSubmissionNotFound = errors.New("submission not found")
+	ErrNotAssignment      = errors.New("lesson is not an assignment")
+	ErrNotEnrolled        = errors.New("not enrolled in this course")
+	ErrUnauthorized       = errors.New("unauthorized access")
+	ErrSubmissionEmpty    = errors.New("a file or a link is required")
+	ErrAlreadyAccepted    = errors.New("assignment has already been accepted")
+	ErrNotGradable        = errors.New("only pending submissions can be graded")
+	ErrScoreTooHigh       = errors.New("score exceeds the assignment's maximum score")
+	ErrUploadFailed       = errors.New("file upload failed")
+	ErrLessonLocked       = errors.New("lesson is not released yet")
+)
+
+// downloadURLTTL is how long a signed submission download link stays valid
+const downloadURLTTL = 15 * time.Minute
+
+// uploadFolder is the storage folder for submitted files
+const uploadFolder = "submissions"
+
+type Service interface {
+	// Student side
+	Submit(ctx context.Context, userID uint, lessonID uint, file *multipart.FileHeader, req *SubmitRequest) (*SubmissionResponse, error)
+	ListMySubmissions(ctx context.Context, userID uint, lessonID uint) ([]*SubmissionResponse, error)
+
+	// Shared: the owner or the course instructor/admin
+	GetSubmission(ctx context.Context, userID uint, userRole string, submissionID uint) (*SubmissionResponse, error)
+
+	// Grading (course instructor or admin)
+	GradeSubmission(ctx context.Context, userID uint, userRole string, submissionID uint, req *GradeRequest) (*SubmissionResponse, error)
+}
+
+type service struct {
+	repo          Repository
+	courseRepo    course.Repository
+	courseService course.Service
+	progressRepo  progress.Repository
+	uploadService upload.Service
+}
+
+func NewService(repo Repository, courseRepo course.Repository, courseService course.Service, progressRepo progress.Repository, uploadService upload.Service) Service {
+	return &service{
+		repo:          repo,
+		courseRepo:    courseRepo,
+		courseService: courseService,
+		progressRepo:  progressRepo,
+		uploadService: uploadService,
+	}
+}
+
+// Helper: Load an assignment lesson
+func (s *service) findAssignmentLesson(ctx context.Context, lessonID uint) (*course.Lesson, error) {
+	lesson, err := s.courseRepo.FindLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+	if lesson.Type != course.LessonTypeAssignment {
+		return nil, ErrNotAssignment
+	}
+	return lesson, nil
+}
+
+// Helper: Check whether the user may grade the course (instructor, co-instructors/TAs or admin)
+func (s *service) isCourseManager(ctx context.Context, userID uint, userRole string, courseID uint) bool {
+	if userRole == "admin" {
+		return true
+	}
+	c, err := s.courseRepo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return false
+	}
+	if c.InstructorID == userID {
+		return true
+	}
+	collaborator, err := s.courseRepo.FindCollaborator(ctx, courseID, userID)
+	return err == nil && course.RoleAllows(collaborator.Role, course.PermissionTeach)
+}
+
+// Helper: Verify the student is enrolled and the lesson is released to them (drip release).
+// Access follows course.Service.GetLesson.
+func (s *service) checkStudentAccess(ctx context.Context, userID uint, lessonID uint) error {
+	access, err := s.courseService.CheckLessonAccess(ctx, userID, lessonID)
+	if err != nil {
+		switch err {
+		case course.ErrLessonNotFound, course.ErrCourseNotFound:
+			return ErrLessonNotFound
+		case course.ErrUnauthorized, course.ErrNotEnrolled:
+			return ErrNotEnrolled
+		}
+		return err
+	}
+	if !access.IsEnrolled {
+		return ErrNotEnrolled
+	}
+	if !access.IsParticipant() {
+		return ErrLessonLocked
+	}
+	return nil
+}
+
+// Student side
+
+func (s *service) Submit(ctx context.Context, userID uint, lessonID uint, file *multipart.FileHeader, req *SubmitRequest) (*SubmissionResponse, error) {
+	if file == nil && req.LinkURL == "" {
+		return nil, ErrSubmissionEmpty
+	}
+
+	lesson, err := s.findAssignmentLesson(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkStudentAccess(ctx, userID, lessonID); err != nil {
+		return nil, err
+	}
+
+	attempt := 1
+	if latest, err := s.repo.FindLatestSubmission(ctx, userID, lessonID); err == nil {
+		if latest.Status == StatusAccepted {
+			return nil, ErrAlreadyAccepted
+		}
+		attempt = latest.Attempt + 1
+	}
+
+	submission := &Submission{
+		LessonID:    lessonID,
+		UserID:      userID,
+		CourseID:    lesson.CourseID,
+		Attempt:     attempt,
+		Status:      StatusSubmitted,
+		LinkURL:     req.LinkURL,
+		Note:        req.Note,
+		SubmittedAt: time.Now(),
+	}
+
+	if file != nil {
+		uploaded, err := s.uploadService.UploadFile(ctx, file, uploadFolder)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUploadFailed, err)
+		}
+		submission.FilePath = uploaded.Path
+		submission.FileName = uploaded.Filename
+		submission.FileSize = uploaded.Size
+		submission.MimeType = uploaded.MimeType
+	}
+
+	if err := s.repo.CreateSubmission(ctx, submission); err != nil {
+		// Don't leave an orphaned file in storage
+		if submission.FilePath != "" {
+			if deleteErr := s.uploadService.DeleteFile(ctx, submission.FilePath); deleteErr != nil {
+				logger.Warn("Failed to delete upload of unsaved submission",
+					zap.Error(deleteErr),
+					zap.String("path", submission.FilePath),
+				)
+			}
+		}
+		return nil, err
+	}
+
+	return toSubmissionResponse(submission, lesson), nil
+}
+
+func (s *service) ListMySubmissions(ctx context.Context, userID uint, lessonID uint) ([]*SubmissionResponse, error) {
+	lesson, err := s.findAssignmentLesson(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := s.repo.FindSubmissionsByUser(ctx, userID, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*SubmissionResponse, 0, len(submissions))
+	for _, submission := range submissions {
+		responses = append(responses, toSubmissionResponse(submission, lesson))
+	}
+	return responses, nil
+}
+
+// Shared
+
+func (s *service) GetSubmission(ctx context.Context, userID uint, userRole string, submissionID uint) (*SubmissionResponse, error) {
+	submission, err := s.repo.FindSubmissionByID(ctx, submissionID)
+	if err != nil {
+		return nil, ErrSubmissionNotFound
+	}
+	if submission.UserID != userID && !s.isCourseManager(ctx, userID, userRole, submission.CourseID) {
+		return nil, ErrUnauthorized
+	}
+
+	lesson, err := s.courseRepo.FindLessonByID(ctx, submission.LessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+
+	resp := toSubmissionResponse(submission, lesson)
+	if submission.FilePath != "" {
+		url, err := s.uploadService.SignedURL(ctx, submission.FilePath, downloadURLTTL)
+		if err != nil {
+			// The submission is still useful without the link; the client can retry
+			logger.Warn("Failed to sign submission download URL",
+				zap.Error(err),
+				zap.Uint("submission_id", submission.ID),
+			)
+		} else {
+			expires := time.Now().Add(downloadURLTTL)
+			resp.DownloadURL = url
+			resp.URLExpires = &expires
+		}
+	}
+	return resp, nil
+}
+
+// Grading
+
+func (s *service) GradeSubmission(ctx context.Context, userID uint, userRole string, submissionID uint, req *GradeRequest) (*SubmissionResponse, error) {
+	submission, err := s.repo.FindSubmissionByID(ctx, submissionID)
+	if err != nil {
+		return nil, ErrSubmissionNotFound
+	}
+	if !s.isCourseManager(ctx, userID, userRole, submission.CourseID) {
+		return nil, ErrUnauthorized
+	}
+	if submission.Status != StatusSubmitted {
+		return nil, ErrNotGradable
+	}
+
+	lesson, err := s.courseRepo.FindLessonByID(ctx, submission.LessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+	if err := validateScore(req.Score, lesson.AssignmentMaxScore); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	submission.Status = req.Status
+	submission.Score = req.Score
+	submission.Feedback = req.Feedback
+	submission.GradedBy = &userID
+	submission.GradedAt = &now
+
+	if err := s.repo.SaveGrade(ctx, submission); err != nil {
+		return nil, err
+	}
+
+	// An accepted assignment completes the lesson for the student
+	if submission.Status == StatusAccepted {
+		if _, err := s.progressRepo.MarkLessonComplete(ctx, submission.UserID, submission.LessonID, submission.CourseID); err != nil {
+			return nil, err
+		}
+	}
+
+	return toSubmissionResponse(submission, lesson), nil
+}
+
+// validateScore checks the score against the assignment's maximum (0 = no maximum)
+func validateScore(score *int, maxScore int) error {
+	if score != nil && maxScore > 0 && *score > maxScore {
+		return ErrScoreTooHigh
+	}
+	return nil
+}
+
+func toSubmissionResponse(submission *Submission, lesson *course.Lesson) *SubmissionResponse {
+	return &SubmissionResponse{
+		Submission: *submission,
+		MaxScore:   lesson.AssignmentMaxScore,
+	}
+}
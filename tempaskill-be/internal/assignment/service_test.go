@@ -0,0 +1,262 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"testing"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/progress"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRepository is a mock implementation of Repository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CreateSubmission(ctx context.Context, submission *Submission) error {
+	return m.Called(ctx, submission).Error(0)
+}
+
+func (m *MockRepository) FindSubmissionByID(ctx context.Context, id uint) (*Submission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Submission), args.Error(1)
+}
+
+func (m *MockRepository) FindLatestSubmission(ctx context.Context, userID, lessonID uint) (*Submission, error) {
+	args := m.Called(ctx, userID, lessonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Submission), args.Error(1)
+}
+
+func (m *MockRepository) FindSubmissionsByUser(ctx context.Context, userID, lessonID uint) ([]*Submission, error) {
+	args := m.Called(ctx, userID, lessonID)
+	return args.Get(0).([]*Submission), args.Error(1)
+}
+
+func (m *MockRepository) SaveGrade(ctx context.Context, submission *Submission) error {
+	return m.Called(ctx, submission).Error(0)
+}
+
+// The mocks below embed the interface and only implement what the assignment service calls
+
+type mockCourseRepository struct {
+	course.Repository
+	mock.Mock
+}
+
+func (m *mockCourseRepository) FindLessonByID(ctx context.Context, id uint) (*course.Lesson, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.Lesson), args.Error(1)
+}
+
+func (m *mockCourseRepository) FindCourseByID(ctx context.Context, id uint) (*course.Course, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.Course), args.Error(1)
+}
+
+type mockCourseService struct {
+	course.Service
+	mock.Mock
+}
+
+func (m *mockCourseService) CheckLessonAccess(ctx context.Context, userID uint, lessonID uint) (*course.LessonAccess, error) {
+	args := m.Called(ctx, userID, lessonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.LessonAccess), args.Error(1)
+}
+
+type mockProgressRepository struct {
+	progress.Repository
+	mock.Mock
+}
+
+func (m *mockProgressRepository) MarkLessonComplete(ctx context.Context, userID, lessonID, courseID uint) (*progress.LessonProgress, error) {
+	args := m.Called(ctx, userID, lessonID, courseID)
+	return &progress.LessonProgress{}, args.Error(0)
+}
+
+type mockUploadService struct {
+	upload.Service
+	mock.Mock
+}
+
+func (m *mockUploadService) UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (*upload.UploadedFile, error) {
+	args := m.Called(ctx, file, folder)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*upload.UploadedFile), args.Error(1)
+}
+
+func (m *mockUploadService) DeleteFile(ctx context.Context, path string) error {
+	return m.Called(ctx, path).Error(0)
+}
+
+const (
+	testStudentID    = uint(7)
+	testInstructorID = uint(2)
+	testLessonID     = uint(30)
+	testCourseID     = uint(3)
+)
+
+type testMocks struct {
+	repo          *MockRepository
+	courseRepo    *mockCourseRepository
+	courseService *mockCourseService
+	progressRepo  *mockProgressRepository
+	uploadService *mockUploadService
+}
+
+func newTestService() (Service, *testMocks) {
+	m := &testMocks{
+		repo:          new(MockRepository),
+		courseRepo:    new(mockCourseRepository),
+		courseService: new(mockCourseService),
+		progressRepo:  new(mockProgressRepository),
+		uploadService: new(mockUploadService),
+	}
+	lesson := &course.Lesson{ID: testLessonID, CourseID: testCourseID, Type: course.LessonTypeAssignment, AssignmentMaxScore: 100}
+	m.courseRepo.On("FindLessonByID", mock.Anything, testLessonID).Return(lesson, nil)
+	m.courseRepo.On("FindCourseByID", mock.Anything, testCourseID).Return(&course.Course{ID: testCourseID, InstructorID: testInstructorID}, nil)
+	m.courseService.On("CheckLessonAccess", mock.Anything, testStudentID, testLessonID).
+		Return(&course.LessonAccess{Lesson: lesson, IsEnrolled: true, Available: true}, nil)
+	return NewService(m.repo, m.courseRepo, m.courseService, m.progressRepo, m.uploadService), m
+}
+
+// TestValidateScore tests the score bound against the assignment maximum
+func TestValidateScore(t *testing.T) {
+	score := func(v int) *int { return &v }
+
+	assert.NoError(t, validateScore(nil, 100))
+	assert.NoError(t, validateScore(score(100), 100))
+	assert.NoError(t, validateScore(score(250), 0)) // No maximum configured
+	assert.Equal(t, ErrScoreTooHigh, validateScore(score(101), 100))
+}
+
+// TestSubmitResubmission tests that a new hand-in replaces a pending one (the repository
+// supersedes it) and that an accepted assignment cannot be submitted again
+func TestSubmitResubmission(t *testing.T) {
+	tests := []struct {
+		name          string
+		latest        *Submission
+		expectAttempt int
+		expectError   error
+	}{
+		{"First submission", nil, 1, nil},
+		{"Supersedes the pending submission", &Submission{Attempt: 1, Status: StatusSubmitted}, 2, nil},
+		{"Resubmits after a revision request", &Submission{Attempt: 2, Status: StatusNeedsRevision}, 3, nil},
+		{"Blocked once accepted", &Submission{Attempt: 2, Status: StatusAccepted}, 0, ErrAlreadyAccepted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService()
+			if tt.latest != nil {
+				m.repo.On("FindLatestSubmission", mock.Anything, testStudentID, testLessonID).Return(tt.latest, nil)
+			} else {
+				m.repo.On("FindLatestSubmission", mock.Anything, testStudentID, testLessonID).Return(nil, errors.New("record not found"))
+			}
+			if tt.expectError == nil {
+				m.repo.On("CreateSubmission", mock.Anything, mock.MatchedBy(func(s *Submission) bool {
+					return s.Attempt == tt.expectAttempt && s.Status == StatusSubmitted
+				})).Return(nil)
+			}
+
+			resp, err := service.Submit(context.Background(), testStudentID, testLessonID, nil, &SubmitRequest{LinkURL: "https://github.com/student/repo"})
+
+			if tt.expectError != nil {
+				assert.Equal(t, tt.expectError, err)
+				m.repo.AssertNotCalled(t, "CreateSubmission", mock.Anything, mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectAttempt, resp.Attempt)
+			}
+			m.repo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestSubmitLockedLesson tests that drip-fed assignments cannot be submitted before release
+func TestSubmitLockedLesson(t *testing.T) {
+	service, m := newTestService()
+	m.courseService.ExpectedCalls = nil
+	m.courseService.On("CheckLessonAccess", mock.Anything, testStudentID, testLessonID).
+		Return(&course.LessonAccess{IsEnrolled: true, Available: false}, nil)
+
+	_, err := service.Submit(context.Background(), testStudentID, testLessonID, nil, &SubmitRequest{LinkURL: "https://example.com"})
+	assert.Equal(t, ErrLessonLocked, err)
+}
+
+// TestSubmitDeletesUploadOnFailedInsert tests that a failed insert does not orphan the uploaded file
+func TestSubmitDeletesUploadOnFailedInsert(t *testing.T) {
+	service, m := newTestService()
+	file := &multipart.FileHeader{Filename: "solution.zip"}
+	m.repo.On("FindLatestSubmission", mock.Anything, testStudentID, testLessonID).Return(nil, errors.New("record not found"))
+	m.uploadService.On("UploadFile", mock.Anything, file, uploadFolder).
+		Return(&upload.UploadedFile{Filename: "solution.zip", Path: "submissions/2026/10/abc.zip"}, nil)
+	m.repo.On("CreateSubmission", mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+	m.uploadService.On("DeleteFile", mock.Anything, "submissions/2026/10/abc.zip").Return(nil)
+
+	_, err := service.Submit(context.Background(), testStudentID, testLessonID, file, &SubmitRequest{})
+	assert.Error(t, err)
+	m.uploadService.AssertExpectations(t)
+}
+
+// TestGradeSubmission tests the status guard and that accepting completes the lesson
+func TestGradeSubmission(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         string
+		grade          string
+		saveError      error
+		expectError    error
+		expectComplete bool
+	}{
+		{"Accept completes the lesson", StatusSubmitted, StatusAccepted, nil, nil, true},
+		{"Revision request does not complete", StatusSubmitted, StatusNeedsRevision, nil, nil, false},
+		{"Superseded submission", StatusSuperseded, StatusAccepted, nil, ErrNotGradable, false},
+		{"Already graded", StatusNeedsRevision, StatusAccepted, nil, ErrNotGradable, false},
+		{"Graded concurrently", StatusSubmitted, StatusAccepted, ErrNotGradable, ErrNotGradable, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService()
+			submission := &Submission{ID: 9, UserID: testStudentID, LessonID: testLessonID, CourseID: testCourseID, Status: tt.status}
+			m.repo.On("FindSubmissionByID", mock.Anything, uint(9)).Return(submission, nil)
+			m.repo.On("SaveGrade", mock.Anything, submission).Return(tt.saveError)
+			m.progressRepo.On("MarkLessonComplete", mock.Anything, testStudentID, testLessonID, testCourseID).Return(nil)
+
+			_, err := service.GradeSubmission(context.Background(), testInstructorID, "instructor", 9, &GradeRequest{Status: tt.grade})
+
+			assert.Equal(t, tt.expectError, err)
+			if tt.status != StatusSubmitted {
+				m.repo.AssertNotCalled(t, "SaveGrade", mock.Anything, mock.Anything)
+			}
+			if tt.expectComplete {
+				m.progressRepo.AssertCalled(t, "MarkLessonComplete", mock.Anything, testStudentID, testLessonID, testCourseID)
+			} else {
+				m.progressRepo.AssertNotCalled(t, "MarkLessonComplete", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package attachment
+
+import "time"
+
+// UploadRequest represents the form fields sent with an attachment file
+type UploadRequest struct {
+	Title string `form:"title" json:"title" binding:"omitempty,max=200"` // Defaults to the file name
+}
+
+// DownloadResponse is a short-lived link to an attachment's file
+type DownloadResponse struct {
+	URL        string    `json:"url"`
+	FileName   string    `json:"file_name"`
+	URLExpires time.Time `json:"url_expires_at"`
+}
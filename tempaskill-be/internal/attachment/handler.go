@@ -0,0 +1,149 @@
+package attachment
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	if errors.Is(err, ErrUploadFailed) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	switch err {
+	case ErrLessonNotFound, ErrAttachmentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrUnauthorized, ErrNoAccess:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case ErrTooManyAttachments:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUser returns the authenticated user's ID and role from the JWT middleware
+func getUser(c *gin.Context) (uint, string, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return userID.(uint), role, true
+}
+
+// parseID parses a numeric path parameter
+func parseID(c *gin.Context, param string, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// Upload handles POST /lessons/:id/attachments
+// Accepts multipart/form-data with the resource in the "file" field and an optional "title"
+func (h *Handler) Upload(c *gin.Context) {
+	lessonID, ok := parseID(c, "id", "lesson")
+	if !ok {
+		return
+	}
+
+	var req UploadRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.Upload(c.Request.Context(), userID, userRole, lessonID, file, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Attachment uploaded successfully",
+		"data":    attachment,
+	})
+}
+
+// Delete handles DELETE /lessons/:id/attachments/:attachmentId
+func (h *Handler) Delete(c *gin.Context) {
+	lessonID, ok := parseID(c, "id", "lesson")
+	if !ok {
+		return
+	}
+	attachmentID, ok := parseID(c, "attachmentId", "attachment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), userID, userRole, lessonID, attachmentID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attachment deleted successfully",
+	})
+}
+
+// Download handles GET /lessons/:id/attachments/:attachmentId/download
+// Responds with a short-lived signed link rather than a permanent file URL
+func (h *Handler) Download(c *gin.Context) {
+	lessonID, ok := parseID(c, "id", "lesson")
+	if !ok {
+		return
+	}
+	attachmentID, ok := parseID(c, "attachmentId", "attachment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	download, err := h.service.Download(c.Request.Context(), userID, userRole, lessonID, attachmentID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": download,
+	})
+}
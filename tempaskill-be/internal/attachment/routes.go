@@ -0,0 +1,28 @@
+package attachment
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, notifier course.Notifier, uploadService upload.Service) {
+	// Initialize layers
+	courseRepo := course.NewRepository(db)
+	service := NewService(courseRepo, course.NewService(courseRepo, notifier), uploadService)
+	handler := NewHandler(service)
+
+	// All attachment routes require authentication (attachments are listed in the lesson detail)
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// Learners (same access as the lesson content)
+		protected.GET("/lessons/:id/attachments/:attachmentId/download", handler.Download) // Signed short-lived link
+
+		// Course team (instructor, content collaborators or admin - authorization checked in service layer)
+		protected.POST("/lessons/:id/attachments", handler.Upload)                 // Upload resource
+		protected.DELETE("/lessons/:id/attachments/:attachmentId", handler.Delete) // Delete resource
+	}
+}
@@ -0,0 +1,189 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrLessonNotFound     = errors.New("lesson not found")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrUnauthorized       = errors.New("unauthorized access")
+	ErrNoAccess           = errors.New("enroll in the course to download this resource")
+	ErrTooManyAttachments = errors.New("a lesson can have at most 20 attachments")
+	ErrUploadFailed       = errors.New("file upload failed")
+)
+
+// downloadURLTTL is how long a signed attachment download link stays valid. Links are only
+// handed out after an access check, so keep them short to prevent hot-linking.
+const downloadURLTTL = 5 * time.Minute
+
+// uploadFolder is the storage folder for lesson attachments
+const uploadFolder = "lesson-attachments"
+
+// maxAttachmentsPerLesson caps the resources on a single lesson
+const maxAttachmentsPerLesson = 20
+
+type Service interface {
+	// Course team (instructor, content collaborators or admin)
+	Upload(ctx context.Context, userID uint, userRole string, lessonID uint, file *multipart.FileHeader, req *UploadRequest) (*course.AttachmentResponse, error)
+	Delete(ctx context.Context, userID uint, userRole string, lessonID uint, attachmentID uint) error
+
+	// Anyone who can read the lesson content (same access rules as course.Service.GetLesson)
+	Download(ctx context.Context, userID uint, userRole string, lessonID uint, attachmentID uint) (*DownloadResponse, error)
+}
+
+type service struct {
+	courseRepo    course.Repository
+	courseService course.Service
+	uploadService upload.Service
+}
+
+func NewService(courseRepo course.Repository, courseService course.Service, uploadService upload.Service) Service {
+	return &service{
+		courseRepo:    courseRepo,
+		courseService: courseService,
+		uploadService: uploadService,
+	}
+}
+
+// Helper: Check if user can manage the course's lesson content
+func (s *service) canEditContent(ctx context.Context, userID uint, userRole string, courseID uint) bool {
+	if userRole == "admin" {
+		return true
+	}
+	c, err := s.courseRepo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return false
+	}
+	if c.InstructorID == userID {
+		return true
+	}
+	collaborator, err := s.courseRepo.FindCollaborator(ctx, courseID, userID)
+	return err == nil && course.RoleAllows(collaborator.Role, course.PermissionEditContent)
+}
+
+// Helper: Load an attachment of the given lesson
+func (s *service) findAttachment(ctx context.Context, lessonID uint, attachmentID uint) (*course.LessonAttachment, error) {
+	attachment, err := s.courseRepo.FindLessonAttachmentByID(ctx, attachmentID)
+	if err != nil || attachment.LessonID != lessonID {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// attachmentTitle returns the requested title, or the file name without its extension
+func attachmentTitle(title, fileName string) string {
+	if title = strings.TrimSpace(title); title != "" {
+		return title
+	}
+	base := filepath.Base(fileName)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// Course team
+
+func (s *service) Upload(ctx context.Context, userID uint, userRole string, lessonID uint, file *multipart.FileHeader, req *UploadRequest) (*course.AttachmentResponse, error) {
+	lesson, err := s.courseRepo.FindLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+	if !s.canEditContent(ctx, userID, userRole, lesson.CourseID) {
+		return nil, ErrUnauthorized
+	}
+
+	existing, err := s.courseRepo.FindLessonAttachments(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxAttachmentsPerLesson {
+		return nil, ErrTooManyAttachments
+	}
+
+	uploaded, err := s.uploadService.UploadFile(ctx, file, uploadFolder)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUploadFailed, err)
+	}
+
+	attachment := &course.LessonAttachment{
+		LessonID:   lessonID,
+		CourseID:   lesson.CourseID,
+		Title:      attachmentTitle(req.Title, uploaded.Filename),
+		FileName:   filepath.Base(uploaded.Filename),
+		FilePath:   uploaded.Path,
+		FileSize:   uploaded.Size,
+		MimeType:   uploaded.MimeType,
+		UploadedBy: userID,
+	}
+	if err := s.courseRepo.CreateLessonAttachment(ctx, attachment); err != nil {
+		// Don't leave an orphaned file in storage
+		if deleteErr := s.uploadService.DeleteFile(ctx, uploaded.Path); deleteErr != nil {
+			logger.Warn("Failed to delete upload of unsaved attachment",
+				zap.Error(deleteErr),
+				zap.String("path", uploaded.Path),
+			)
+		}
+		return nil, err
+	}
+
+	return attachment.ToResponse(), nil
+}
+
+func (s *service) Delete(ctx context.Context, userID uint, userRole string, lessonID uint, attachmentID uint) error {
+	attachment, err := s.findAttachment(ctx, lessonID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if !s.canEditContent(ctx, userID, userRole, attachment.CourseID) {
+		return ErrUnauthorized
+	}
+	return s.courseRepo.DeleteLessonAttachment(ctx, attachment.ID)
+}
+
+// Learners
+
+// Download checks the user can read the lesson (enrolled and released, course team, or a
+// free preview) and returns a short-lived signed link to the file
+func (s *service) Download(ctx context.Context, userID uint, userRole string, lessonID uint, attachmentID uint) (*DownloadResponse, error) {
+	attachment, err := s.findAttachment(ctx, lessonID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userRole != "admin" {
+		access, err := s.courseService.CheckLessonAccess(ctx, userID, lessonID)
+		if err != nil {
+			switch err {
+			case course.ErrLessonNotFound, course.ErrCourseNotFound:
+				return nil, ErrLessonNotFound
+			case course.ErrUnauthorized:
+				return nil, ErrNoAccess
+			}
+			return nil, err
+		}
+		if !access.CanViewContent() {
+			return nil, ErrNoAccess
+		}
+	}
+
+	url, err := s.uploadService.SignedURL(ctx, attachment.FilePath, downloadURLTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DownloadResponse{
+		URL:        url,
+		FileName:   attachment.FileName,
+		URLExpires: time.Now().Add(downloadURLTTL),
+	}, nil
+}
@@ -0,0 +1,263 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// The mocks below embed the interface and only implement what the attachment service calls
+
+type mockCourseRepository struct {
+	course.Repository
+	mock.Mock
+}
+
+func (m *mockCourseRepository) FindLessonByID(ctx context.Context, id uint) (*course.Lesson, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.Lesson), args.Error(1)
+}
+
+func (m *mockCourseRepository) FindCourseByID(ctx context.Context, id uint) (*course.Course, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.Course), args.Error(1)
+}
+
+func (m *mockCourseRepository) FindCollaborator(ctx context.Context, courseID, userID uint) (*course.CourseCollaborator, error) {
+	args := m.Called(ctx, courseID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.CourseCollaborator), args.Error(1)
+}
+
+func (m *mockCourseRepository) FindLessonAttachments(ctx context.Context, lessonID uint) ([]*course.LessonAttachment, error) {
+	args := m.Called(ctx, lessonID)
+	return args.Get(0).([]*course.LessonAttachment), args.Error(1)
+}
+
+func (m *mockCourseRepository) FindLessonAttachmentByID(ctx context.Context, id uint) (*course.LessonAttachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.LessonAttachment), args.Error(1)
+}
+
+func (m *mockCourseRepository) CreateLessonAttachment(ctx context.Context, attachment *course.LessonAttachment) error {
+	return m.Called(ctx, attachment).Error(0)
+}
+
+type mockCourseService struct {
+	course.Service
+	mock.Mock
+}
+
+func (m *mockCourseService) CheckLessonAccess(ctx context.Context, userID uint, lessonID uint) (*course.LessonAccess, error) {
+	args := m.Called(ctx, userID, lessonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*course.LessonAccess), args.Error(1)
+}
+
+type mockUploadService struct {
+	upload.Service
+	mock.Mock
+}
+
+func (m *mockUploadService) UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (*upload.UploadedFile, error) {
+	args := m.Called(ctx, file, folder)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*upload.UploadedFile), args.Error(1)
+}
+
+func (m *mockUploadService) SignedURL(ctx context.Context, path string, ttl time.Duration) (string, error) {
+	args := m.Called(ctx, path, ttl)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockUploadService) DeleteFile(ctx context.Context, path string) error {
+	return m.Called(ctx, path).Error(0)
+}
+
+const (
+	testStudentID      = uint(7)
+	testInstructorID   = uint(2)
+	testLessonID       = uint(30)
+	testCourseID       = uint(3)
+	testAttachmentID   = uint(12)
+	testAttachmentPath = "lesson-attachments/2026/10/slides.pdf"
+)
+
+var errNotFound = errors.New("record not found")
+
+type testMocks struct {
+	courseRepo    *mockCourseRepository
+	courseService *mockCourseService
+	uploadService *mockUploadService
+}
+
+func newTestService() (Service, *testMocks) {
+	m := &testMocks{
+		courseRepo:    new(mockCourseRepository),
+		courseService: new(mockCourseService),
+		uploadService: new(mockUploadService),
+	}
+	m.courseRepo.On("FindLessonByID", mock.Anything, testLessonID).Return(&course.Lesson{ID: testLessonID, CourseID: testCourseID}, nil)
+	m.courseRepo.On("FindCourseByID", mock.Anything, testCourseID).Return(&course.Course{ID: testCourseID, InstructorID: testInstructorID}, nil)
+	m.courseRepo.On("FindCollaborator", mock.Anything, testCourseID, mock.Anything).Return(nil, errNotFound)
+	m.courseRepo.On("FindLessonAttachmentByID", mock.Anything, testAttachmentID).
+		Return(&course.LessonAttachment{ID: testAttachmentID, LessonID: testLessonID, CourseID: testCourseID, FileName: "slides.pdf", FilePath: testAttachmentPath}, nil)
+	m.uploadService.On("SignedURL", mock.Anything, testAttachmentPath, downloadURLTTL).Return("https://storage.example.com/slides.pdf?sig=abc", nil)
+	return NewService(m.courseRepo, m.courseService, m.uploadService), m
+}
+
+// TestDownload tests who gets a download link: the same readers as the lesson content
+func TestDownload(t *testing.T) {
+	published := &course.Course{ID: testCourseID, Status: course.CourseStatusPublished}
+	lesson := &course.Lesson{ID: testLessonID, CourseID: testCourseID, IsPublished: true}
+	preview := &course.Lesson{ID: testLessonID, CourseID: testCourseID, IsPublished: true, IsPreview: true}
+
+	tests := []struct {
+		name        string
+		access      *course.LessonAccess
+		accessError error
+		expectError error
+	}{
+		{"Enrolled student", &course.LessonAccess{Lesson: lesson, Course: published, IsEnrolled: true, Available: true}, nil, nil},
+		{"Enrolled, lesson not released yet", &course.LessonAccess{Lesson: lesson, Course: published, IsEnrolled: true, Available: false}, nil, ErrNoAccess},
+		{"Not enrolled", &course.LessonAccess{Lesson: lesson, Course: published}, nil, ErrNoAccess},
+		{"Not enrolled, free preview", &course.LessonAccess{Lesson: preview, Course: published}, nil, nil},
+		{"Course team", &course.LessonAccess{Lesson: lesson, Course: published, IsStaff: true, Available: true}, nil, nil},
+		{"Lesson of an unpublished course", nil, course.ErrUnauthorized, ErrNoAccess},
+		{"Lesson deleted", nil, course.ErrLessonNotFound, ErrLessonNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService()
+			m.courseService.On("CheckLessonAccess", mock.Anything, testStudentID, testLessonID).Return(tt.access, tt.accessError)
+
+			resp, err := service.Download(context.Background(), testStudentID, "student", testLessonID, testAttachmentID)
+
+			assert.Equal(t, tt.expectError, err)
+			if tt.expectError == nil {
+				require.NotNil(t, resp)
+				assert.Equal(t, "slides.pdf", resp.FileName)
+				m.uploadService.AssertCalled(t, "SignedURL", mock.Anything, testAttachmentPath, downloadURLTTL)
+			} else {
+				m.uploadService.AssertNotCalled(t, "SignedURL", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// TestDownloadAdmin tests that admins download without an enrollment check
+func TestDownloadAdmin(t *testing.T) {
+	service, m := newTestService()
+
+	_, err := service.Download(context.Background(), 99, "admin", testLessonID, testAttachmentID)
+
+	require.NoError(t, err)
+	m.courseService.AssertNotCalled(t, "CheckLessonAccess", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestDownloadOtherLesson tests that an attachment is only found through its own lesson
+func TestDownloadOtherLesson(t *testing.T) {
+	service, m := newTestService()
+
+	_, err := service.Download(context.Background(), 99, "admin", 31, testAttachmentID)
+
+	assert.Equal(t, ErrAttachmentNotFound, err)
+	m.uploadService.AssertNotCalled(t, "SignedURL", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUpload tests the course team guard and the attachment limit
+func TestUpload(t *testing.T) {
+	file := &multipart.FileHeader{Filename: "slides.pdf"}
+
+	tests := []struct {
+		name        string
+		userID      uint
+		userRole    string
+		existing    int
+		expectError error
+	}{
+		{"Course instructor", testInstructorID, "instructor", 0, nil},
+		{"Admin", 99, "admin", 0, nil},
+		{"Enrolled student", testStudentID, "student", 0, ErrUnauthorized},
+		{"Lesson already has 20 attachments", testInstructorID, "instructor", maxAttachmentsPerLesson, ErrTooManyAttachments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, m := newTestService()
+			m.courseRepo.On("FindLessonAttachments", mock.Anything, testLessonID).
+				Return(make([]*course.LessonAttachment, tt.existing), nil)
+			m.uploadService.On("UploadFile", mock.Anything, file, uploadFolder).
+				Return(&upload.UploadedFile{Filename: "slides.pdf", Path: testAttachmentPath, Size: 2048}, nil)
+			m.courseRepo.On("CreateLessonAttachment", mock.Anything, mock.Anything).Return(nil)
+
+			resp, err := service.Upload(context.Background(), tt.userID, tt.userRole, testLessonID, file, &UploadRequest{})
+
+			assert.Equal(t, tt.expectError, err)
+			if tt.expectError == nil {
+				require.NotNil(t, resp)
+				assert.Equal(t, "slides", resp.Title)
+			} else {
+				m.uploadService.AssertNotCalled(t, "UploadFile", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// TestUploadDeletesFileOnFailedInsert tests that a failed insert does not orphan the uploaded file
+func TestUploadDeletesFileOnFailedInsert(t *testing.T) {
+	service, m := newTestService()
+	file := &multipart.FileHeader{Filename: "slides.pdf"}
+	m.courseRepo.On("FindLessonAttachments", mock.Anything, testLessonID).Return([]*course.LessonAttachment{}, nil)
+	m.uploadService.On("UploadFile", mock.Anything, file, uploadFolder).
+		Return(&upload.UploadedFile{Filename: "slides.pdf", Path: testAttachmentPath}, nil)
+	m.courseRepo.On("CreateLessonAttachment", mock.Anything, mock.Anything).Return(errors.New("connection lost"))
+	m.uploadService.On("DeleteFile", mock.Anything, testAttachmentPath).Return(nil)
+
+	_, err := service.Upload(context.Background(), testInstructorID, "instructor", testLessonID, file, &UploadRequest{})
+	assert.Error(t, err)
+	m.uploadService.AssertCalled(t, "DeleteFile", mock.Anything, testAttachmentPath)
+}
+
+// TestUploadFailed tests that a storage failure is reported without saving an attachment
+func TestUploadFailed(t *testing.T) {
+	service, m := newTestService()
+	file := &multipart.FileHeader{Filename: "slides.pdf"}
+	m.courseRepo.On("FindLessonAttachments", mock.Anything, testLessonID).Return([]*course.LessonAttachment{}, nil)
+	m.uploadService.On("UploadFile", mock.Anything, file, uploadFolder).Return(nil, errors.New("bucket unavailable"))
+
+	_, err := service.Upload(context.Background(), testInstructorID, "instructor", testLessonID, file, &UploadRequest{})
+	assert.ErrorIs(t, err, ErrUploadFailed)
+	m.courseRepo.AssertNotCalled(t, "CreateLessonAttachment", mock.Anything, mock.Anything)
+}
+
+// TestAttachmentTitle tests the title fallback to the file name
+func TestAttachmentTitle(t *testing.T) {
+	assert.Equal(t, "Week 1 slides", attachmentTitle("  Week 1 slides ", "slides.pdf"))
+	assert.Equal(t, "starter-code", attachmentTitle("", "starter-code.zip"))
+	assert.Equal(t, "notes", attachmentTitle(" ", "../notes.md"))
+}
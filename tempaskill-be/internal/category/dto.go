@@ -0,0 +1,31 @@
+package category
+
+// CreateCategoryRequest represents category creation payload
+type CreateCategoryRequest struct {
+	Name      string `json:"name" binding:"required,min=2,max=50"`
+	Icon      string `json:"icon" binding:"omitempty,max=50"`
+	ParentID  *uint  `json:"parent_id" binding:"omitempty,min=1"` // Makes it a sub-category
+	SortOrder int    `json:"sort_order" binding:"omitempty,min=0"`
+}
+
+// UpdateCategoryRequest represents category update payload.
+// A parent_id of 0 turns a sub-category into a top-level category.
+type UpdateCategoryRequest struct {
+	Name      *string `json:"name" binding:"omitempty,min=2,max=50"`
+	Icon      *string `json:"icon" binding:"omitempty,max=50"`
+	ParentID  *uint   `json:"parent_id"`
+	SortOrder *int    `json:"sort_order" binding:"omitempty,min=0"`
+}
+
+// CategoryResponse is a category with its number of published courses.
+// For a top-level category CourseCount includes its sub-categories.
+type CategoryResponse struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Slug        string              `json:"slug"`
+	Icon        string              `json:"icon"`
+	ParentID    *uint               `json:"parent_id"`
+	SortOrder   int                 `json:"sort_order"`
+	CourseCount int                 `json:"course_count"`
+	Children    []*CategoryResponse `json:"children,omitempty"`
+}
@@ -0,0 +1,143 @@
+package category
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	switch err {
+	case ErrCategoryNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case ErrCategoryExists, ErrCategoryInUse, ErrCategoryHasChildren:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case ErrInvalidParent:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getRole returns the authenticated user's role from the JWT middleware
+func getRole(c *gin.Context) (string, bool) {
+	if _, exists := c.Get("userID"); !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return role, true
+}
+
+// parseID parses a numeric path parameter
+func parseID(c *gin.Context, param string, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ListCategories handles GET /categories
+func (h *Handler) ListCategories(c *gin.Context) {
+	categories, err := h.service.ListCategories(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": categories,
+	})
+}
+
+// CreateCategory handles POST /categories
+func (h *Handler) CreateCategory(c *gin.Context) {
+	var req CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userRole, ok := getRole(c)
+	if !ok {
+		return
+	}
+
+	category, err := h.service.CreateCategory(c.Request.Context(), userRole, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Category created successfully",
+		"data":    category,
+	})
+}
+
+// UpdateCategory handles PATCH /categories/:id
+func (h *Handler) UpdateCategory(c *gin.Context) {
+	categoryID, ok := parseID(c, "id", "category")
+	if !ok {
+		return
+	}
+
+	var req UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userRole, ok := getRole(c)
+	if !ok {
+		return
+	}
+
+	category, err := h.service.UpdateCategory(c.Request.Context(), userRole, categoryID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Category updated successfully",
+		"data":    category,
+	})
+}
+
+// DeleteCategory handles DELETE /categories/:id
+func (h *Handler) DeleteCategory(c *gin.Context) {
+	categoryID, ok := parseID(c, "id", "category")
+	if !ok {
+		return
+	}
+
+	userRole, ok := getRole(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCategory(c.Request.Context(), userRole, categoryID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Category deleted successfully",
+	})
+}
@@ -0,0 +1,33 @@
+package category
+
+import "time"
+
+// Category is an admin-managed course category. Courses store the category name
+// (courses.category), so a rename is carried over to its courses.
+// Sub-categories have a ParentID; nesting is limited to one level.
+type Category struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(50);uniqueIndex;not null" json:"name"`
+	Slug      string    `gorm:"type:varchar(60);uniqueIndex;not null" json:"slug"`
+	Icon      string    `gorm:"type:varchar(50)" json:"icon"` // Icon name used by the frontend (e.g. "code")
+	ParentID  *uint     `gorm:"index" json:"parent_id"`
+	SortOrder int       `gorm:"not null;default:0" json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Category model
+func (Category) TableName() string {
+	return "categories"
+}
+
+// DefaultCategories are the categories that used to be hardcoded, seeded into an empty table
+var DefaultCategories = []Category{
+	{Name: "Web Development", Slug: "web-development", Icon: "globe", SortOrder: 1},
+	{Name: "Mobile Development", Slug: "mobile-development", Icon: "smartphone", SortOrder: 2},
+	{Name: "Backend Development", Slug: "backend-development", Icon: "server", SortOrder: 3},
+	{Name: "Data Science", Slug: "data-science", Icon: "bar-chart", SortOrder: 4},
+	{Name: "DevOps", Slug: "devops", Icon: "cloud", SortOrder: 5},
+	{Name: "Design", Slug: "design", Icon: "palette", SortOrder: 6},
+	{Name: "Database", Slug: "database", Icon: "database", SortOrder: 7},
+}
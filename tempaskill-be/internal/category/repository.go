@@ -0,0 +1,157 @@
+package category
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateCategory(ctx context.Context, category *Category) error
+	FindCategoryByID(ctx context.Context, id uint) (*Category, error)
+	FindCategories(ctx context.Context) ([]*Category, error)
+	NameOrSlugExists(ctx context.Context, name, slug string, excludeID uint) (bool, error)
+	UpdateCategory(ctx context.Context, category *Category, oldName string) error
+	DeleteCategory(ctx context.Context, id uint) error
+	CountChildren(ctx context.Context, id uint) (int64, error)
+	CountCourses(ctx context.Context, name string) (int64, error)
+	CountPublishedCoursesByCategory(ctx context.Context) (map[string]int, error)
+	SeedDefaults(ctx context.Context) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateCategory(ctx context.Context, category *Category) error {
+	if err := r.db.WithContext(ctx).Create(category).Error; err != nil {
+		logger.Error("Failed to create category in database",
+			zap.Error(err),
+			zap.String("name", category.Name),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) FindCategoryByID(ctx context.Context, id uint) (*Category, error) {
+	var category Category
+	if err := r.db.WithContext(ctx).First(&category, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding category by ID",
+				zap.Error(err),
+				zap.Uint("category_id", id),
+			)
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// FindCategories returns all categories in display order
+func (r *repository) FindCategories(ctx context.Context) ([]*Category, error) {
+	var categories []*Category
+	if err := r.db.WithContext(ctx).Order("sort_order ASC, name ASC").Find(&categories).Error; err != nil {
+		logger.Error("Database error finding categories", zap.Error(err))
+		return nil, err
+	}
+	return categories, nil
+}
+
+// NameOrSlugExists reports whether another category already uses the name or slug
+func (r *repository) NameOrSlugExists(ctx context.Context, name, slug string, excludeID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Category{}).
+		Where("(name = ? OR slug = ?) AND id <> ?", name, slug, excludeID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdateCategory saves a category; on a rename its courses are moved to the new name
+// in the same transaction
+func (r *repository) UpdateCategory(ctx context.Context, category *Category, oldName string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(category).Select("name", "slug", "icon", "parent_id", "sort_order").
+			Updates(category).Error; err != nil {
+			return err
+		}
+		if category.Name != oldName {
+			return tx.Table("courses").Where("category = ?", oldName).
+				Update("category", category.Name).Error
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to update category",
+			zap.Error(err),
+			zap.Uint("category_id", category.ID),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) DeleteCategory(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Category{}, id).Error
+}
+
+func (r *repository) CountChildren(ctx context.Context, id uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Category{}).Where("parent_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CountCourses counts the courses (published or not) filed under a category name
+func (r *repository) CountCourses(ctx context.Context, name string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table("courses").
+		Where("category = ? AND deleted_at IS NULL", name).
+		Count(&count).Error
+	return count, err
+}
+
+// CountPublishedCoursesByCategory returns the number of published courses per category name
+func (r *repository) CountPublishedCoursesByCategory(ctx context.Context) (map[string]int, error) {
+	var rows []struct {
+		Category string
+		Count    int
+	}
+	if err := r.db.WithContext(ctx).Table("courses").
+		Select("category, COUNT(*) AS count").
+		Where("status = ? AND deleted_at IS NULL", "published").
+		Group("category").
+		Scan(&rows).Error; err != nil {
+		logger.Error("Database error counting courses by category", zap.Error(err))
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Category] = row.Count
+	}
+	return counts, nil
+}
+
+// SeedDefaults inserts DefaultCategories when the table is empty
+func (r *repository) SeedDefaults(ctx context.Context) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Category{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	defaults := make([]Category, len(DefaultCategories))
+	copy(defaults, DefaultCategories)
+	return r.db.WithContext(ctx).Create(&defaults).Error
+}
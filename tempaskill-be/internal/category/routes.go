@@ -0,0 +1,26 @@
+package category
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware) {
+	// Initialize layers
+	repo := NewRepository(db)
+	service := NewService(repo)
+	handler := NewHandler(service)
+
+	// Public routes
+	router.GET("/categories", handler.ListCategories) // Category tree with published course counts
+
+	// Protected routes (admin only - authorization checked in service layer)
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.POST("/categories", handler.CreateCategory)       // Create category / sub-category
+		protected.PATCH("/categories/:id", handler.UpdateCategory)  // Rename (courses follow), re-parent, reorder
+		protected.DELETE("/categories/:id", handler.DeleteCategory) // Delete unused category
+	}
+}
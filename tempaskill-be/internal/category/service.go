@@ -0,0 +1,210 @@
+package category
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+)
+
+var (
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrUnauthorized        = errors.New("unauthorized access")
+	ErrCategoryExists      = errors.New("a category with this name already exists")
+	ErrInvalidParent       = errors.New("parent must be an existing top-level category")
+	ErrCategoryInUse       = errors.New("category is used by courses; move them to another category first")
+	ErrCategoryHasChildren = errors.New("category has sub-categories; delete or move them first")
+)
+
+type Service interface {
+	// Browsing
+	ListCategories(ctx context.Context) ([]*CategoryResponse, error)
+
+	// Management (admin only)
+	CreateCategory(ctx context.Context, userRole string, req *CreateCategoryRequest) (*Category, error)
+	UpdateCategory(ctx context.Context, userRole string, categoryID uint, req *UpdateCategoryRequest) (*Category, error)
+	DeleteCategory(ctx context.Context, userRole string, categoryID uint) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+// Helper: A parent must exist and be top-level (one level of nesting)
+func (s *service) validateParent(ctx context.Context, parentID uint, categoryID uint) error {
+	if parentID == categoryID {
+		return ErrInvalidParent
+	}
+	parent, err := s.repo.FindCategoryByID(ctx, parentID)
+	if err != nil || parent.ParentID != nil {
+		return ErrInvalidParent
+	}
+	return nil
+}
+
+// ListCategories returns the category tree with published course counts
+func (s *service) ListCategories(ctx context.Context) ([]*CategoryResponse, error) {
+	categories, err := s.repo.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.repo.CountPublishedCoursesByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories, counts), nil
+}
+
+// Helper: Nest sub-categories under their parent (both lists keep the input order);
+// a parent's count includes its sub-categories
+func buildCategoryTree(categories []*Category, counts map[string]int) []*CategoryResponse {
+	nodes := make(map[uint]*CategoryResponse, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryResponse{
+			ID:          category.ID,
+			Name:        category.Name,
+			Slug:        category.Slug,
+			Icon:        category.Icon,
+			ParentID:    category.ParentID,
+			SortOrder:   category.SortOrder,
+			CourseCount: counts[category.Name],
+		}
+	}
+
+	roots := make([]*CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		parent, ok := nodes[*category.ParentID]
+		if !ok {
+			roots = append(roots, node) // Orphan: show it rather than hide its courses
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+		parent.CourseCount += node.CourseCount
+	}
+	return roots
+}
+
+func (s *service) CreateCategory(ctx context.Context, userRole string, req *CreateCategoryRequest) (*Category, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+
+	name := strings.TrimSpace(req.Name)
+	slug := course.GenerateSlug(name)
+	exists, err := s.repo.NameOrSlugExists(ctx, name, slug, 0)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrCategoryExists
+	}
+	if req.ParentID != nil {
+		if err := s.validateParent(ctx, *req.ParentID, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	category := &Category{
+		Name:      name,
+		Slug:      slug,
+		Icon:      req.Icon,
+		ParentID:  req.ParentID,
+		SortOrder: req.SortOrder,
+	}
+	if err := s.repo.CreateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *service) UpdateCategory(ctx context.Context, userRole string, categoryID uint, req *UpdateCategoryRequest) (*Category, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+
+	category, err := s.repo.FindCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	oldName := category.Name
+
+	if req.Name != nil {
+		category.Name = strings.TrimSpace(*req.Name)
+		category.Slug = course.GenerateSlug(category.Name)
+		exists, err := s.repo.NameOrSlugExists(ctx, category.Name, category.Slug, category.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrCategoryExists
+		}
+	}
+	if req.Icon != nil {
+		category.Icon = *req.Icon
+	}
+	if req.SortOrder != nil {
+		category.SortOrder = *req.SortOrder
+	}
+	if req.ParentID != nil {
+		if *req.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			if err := s.validateParent(ctx, *req.ParentID, category.ID); err != nil {
+				return nil, err
+			}
+			// A category with sub-categories cannot become one itself
+			children, err := s.repo.CountChildren(ctx, category.ID)
+			if err != nil {
+				return nil, err
+			}
+			if children > 0 {
+				return nil, ErrInvalidParent
+			}
+			parentID := *req.ParentID
+			category.ParentID = &parentID
+		}
+	}
+
+	if err := s.repo.UpdateCategory(ctx, category, oldName); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (s *service) DeleteCategory(ctx context.Context, userRole string, categoryID uint) error {
+	if userRole != "admin" {
+		return ErrUnauthorized
+	}
+
+	category, err := s.repo.FindCategoryByID(ctx, categoryID)
+	if err != nil {
+		return ErrCategoryNotFound
+	}
+
+	children, err := s.repo.CountChildren(ctx, category.ID)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return ErrCategoryHasChildren
+	}
+	courses, err := s.repo.CountCourses(ctx, category.Name)
+	if err != nil {
+		return err
+	}
+	if courses > 0 {
+		return ErrCategoryInUse
+	}
+
+	return s.repo.DeleteCategory(ctx, category.ID)
+}
@@ -0,0 +1,40 @@
+package category
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildCategoryTree tests sub-category nesting and course count roll-up
+func TestBuildCategoryTree(t *testing.T) {
+	webID := uint(1)
+	missingID := uint(99)
+	categories := []*Category{
+		{ID: 1, Name: "Web Development", SortOrder: 1},
+		{ID: 2, Name: "Data Science", SortOrder: 2},
+		{ID: 3, Name: "Frontend", ParentID: &webID},
+		{ID: 4, Name: "Backend", ParentID: &webID},
+		{ID: 5, Name: "AI/ML", ParentID: &missingID},
+	}
+	counts := map[string]int{"Web Development": 2, "Frontend": 3, "Backend": 1, "AI/ML": 4}
+
+	tree := buildCategoryTree(categories, counts)
+
+	require.Len(t, tree, 3)
+	assert.Equal(t, "Web Development", tree[0].Name)
+	assert.Equal(t, 6, tree[0].CourseCount) // Own courses plus sub-categories
+	require.Len(t, tree[0].Children, 2)
+	assert.Equal(t, "Frontend", tree[0].Children[0].Name)
+	assert.Equal(t, 3, tree[0].Children[0].CourseCount)
+
+	// Categories without courses report zero
+	assert.Equal(t, "Data Science", tree[1].Name)
+	assert.Equal(t, 0, tree[1].CourseCount)
+	assert.Empty(t, tree[1].Children)
+
+	// Orphaned sub-categories surface at the top level
+	assert.Equal(t, "AI/ML", tree[2].Name)
+	assert.Equal(t, 4, tree[2].CourseCount)
+}
@@ -0,0 +1,87 @@
+package course
+
+import "strings"
+
+// diffLines computes a line-based diff between two MDX documents.
+// Common leading/trailing lines are trimmed before running a longest common
+// subsequence over the remaining middle part, which keeps typical lesson
+// edits (a few changed paragraphs) cheap.
+func diffLines(from, to string) []DiffLine {
+	a := splitLines(from)
+	b := splitLines(to)
+
+	// Trim common prefix
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	// Trim common suffix
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		lines = append(lines, DiffLine{Op: DiffOpEqual, Text: line})
+	}
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+
+	// lcs[i][j] = length of LCS of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) && j < len(midB) {
+		switch {
+		case midA[i] == midB[j]:
+			lines = append(lines, DiffLine{Op: DiffOpEqual, Text: midA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, DiffLine{Op: DiffOpRemove, Text: midA[i]})
+			i++
+		default:
+			lines = append(lines, DiffLine{Op: DiffOpAdd, Text: midB[j]})
+			j++
+		}
+	}
+	for ; i < len(midA); i++ {
+		lines = append(lines, DiffLine{Op: DiffOpRemove, Text: midA[i]})
+	}
+	for ; j < len(midB); j++ {
+		lines = append(lines, DiffLine{Op: DiffOpAdd, Text: midB[j]})
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, DiffLine{Op: DiffOpEqual, Text: line})
+	}
+
+	return lines
+}
+
+// splitLines splits content into lines, normalizing Windows line endings
+func splitLines(content string) []string {
+	if content == "" {
+		return []string{}
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
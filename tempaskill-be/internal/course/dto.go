@@ -1,33 +1,80 @@
 package course
 
+import "time"
+
 // CreateCourseRequest represents course creation payload
 type CreateCourseRequest struct {
-	Title        string `json:"title" binding:"required,min=3,max=200"`
-	Description  string `json:"description" binding:"required,min=10"`
-	ThumbnailURL string `json:"thumbnail_url" binding:"omitempty,url,max=255"`
-	Category     string `json:"category" binding:"required"` // Validation moved to service layer for consistency
-	Difficulty   string `json:"difficulty" binding:"required,oneof=beginner intermediate advanced"`
-	Price        int    `json:"price" binding:"omitempty,min=0"`
+	Title          string   `json:"title" binding:"required,min=3,max=200"`
+	Description    string   `json:"description" binding:"required,min=10"`
+	ThumbnailURL   string   `json:"thumbnail_url" binding:"omitempty,url,max=255"`
+	Category       string   `json:"category" binding:"required"` // Validation moved to service layer for consistency
+	Difficulty     string   `json:"difficulty" binding:"required,oneof=beginner intermediate advanced"`
+	Price          int      `json:"price" binding:"omitempty,min=0"`
+	AccessDays     int      `json:"access_duration_days" binding:"omitempty,min=0,max=3650"` // 0 = lifetime access
+	MaxEnrollments int      `json:"max_enrollments" binding:"omitempty,min=0"`               // Seat cap; 0 = unlimited
+	Tags           []string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=30"`       // e.g. ["golang", "gin"]; new tags need an admin
 }
 
 // UpdateCourseRequest represents course update payload
 type UpdateCourseRequest struct {
-	Title        *string `json:"title" binding:"omitempty,min=3,max=200"`
-	Description  *string `json:"description" binding:"omitempty,min=10"`
-	ThumbnailURL *string `json:"thumbnail_url" binding:"omitempty,url,max=255"`
-	Category     *string `json:"category"` // Validation moved to service layer for consistency
-	Difficulty   *string `json:"difficulty" binding:"omitempty,oneof=beginner intermediate advanced"`
-	Price        *int    `json:"price" binding:"omitempty,min=0"`
-	IsPublished  *bool   `json:"is_published"`
+	Title          *string   `json:"title" binding:"omitempty,min=3,max=200"`
+	Description    *string   `json:"description" binding:"omitempty,min=10"`
+	ThumbnailURL   *string   `json:"thumbnail_url" binding:"omitempty,url,max=255"`
+	Category       *string   `json:"category"` // Validation moved to service layer for consistency
+	Difficulty     *string   `json:"difficulty" binding:"omitempty,oneof=beginner intermediate advanced"`
+	Price          *int      `json:"price" binding:"omitempty,min=0"`
+	AccessDays     *int      `json:"access_duration_days" binding:"omitempty,min=0,max=3650"` // New enrollments and renewals only
+	MaxEnrollments *int      `json:"max_enrollments" binding:"omitempty,min=0"`               // Raising it promotes waitlisted students
+	IsPublished    *bool     `json:"is_published"`                                            // Shortcut for older clients, see UpdateCourse
+	KeepSlug       bool      `json:"keep_slug"`                                               // Keep the current slug when the title changes
+	Tags           *[]string `json:"tags" binding:"omitempty,max=10,dive,min=1,max=30"`       // Replaces all tags ([] removes them)
 }
 
 // CreateLessonRequest represents lesson creation payload
+//
+// Type defaults to "text". Content is required for text lessons; the other
+// types require their own payload (video, quiz or assignment).
 type CreateLessonRequest struct {
-	Title       string `json:"title" binding:"required,min=3,max=200"`
-	Content     string `json:"content" binding:"required,min=10"`
-	OrderIndex  int    `json:"order_index" binding:"omitempty,min=0"`
-	Duration    int    `json:"duration" binding:"omitempty,min=0"`
-	IsPublished bool   `json:"is_published" binding:"omitempty"`
+	SectionID   *uint              `json:"section_id" binding:"omitempty,min=1"`
+	Title       string             `json:"title" binding:"required,min=3,max=200"`
+	Type        string             `json:"type" binding:"omitempty,oneof=text video quiz assignment"`
+	Content     string             `json:"content" binding:"omitempty,min=10"`
+	OrderIndex  int                `json:"order_index" binding:"omitempty,min=0"`
+	Duration    int                `json:"duration" binding:"omitempty,min=0"`
+	IsPublished bool               `json:"is_published" binding:"omitempty"`
+	IsPreview   bool               `json:"is_preview"` // Free sample for non-enrolled visitors
+	Video       *VideoPayload      `json:"video"`
+	Quiz        *QuizPayload       `json:"quiz"`
+	Assignment  *AssignmentPayload `json:"assignment"`
+	Release     *ReleasePayload    `json:"release"` // Defaults to immediate
+}
+
+// VideoPayload is the payload of a video lesson
+type VideoPayload struct {
+	URL             string         `json:"url" binding:"required,url,max=500"`
+	DurationSeconds int            `json:"duration_seconds" binding:"omitempty,min=0"`
+	Captions        []CaptionTrack `json:"captions" binding:"omitempty,max=20,dive"`
+}
+
+// QuizPayload is the payload of a quiz lesson
+type QuizPayload struct {
+	QuizID uint `json:"quiz_id" binding:"required,min=1"`
+}
+
+// AssignmentPayload is the payload of an assignment lesson
+type AssignmentPayload struct {
+	Brief    string `json:"brief" binding:"required,min=10"` // MDX
+	MaxScore int    `json:"max_score" binding:"omitempty,min=1,max=1000"`
+}
+
+// ReleasePayload is the drip release rule of a lesson.
+// Only the field matching Rule is used: At (fixed_date), AfterDays (days_after_enrollment)
+// or AfterLessonID (after_lesson, a lesson of the same course).
+type ReleasePayload struct {
+	Rule          string     `json:"rule" binding:"required,oneof=immediate fixed_date days_after_enrollment after_lesson"`
+	At            *time.Time `json:"at,omitempty"`
+	AfterDays     int        `json:"after_days,omitempty" binding:"omitempty,min=1,max=3650"`
+	AfterLessonID *uint      `json:"after_lesson_id,omitempty" binding:"omitempty,min=1"`
 }
 
 // UpdateLessonRequest represents lesson update payload
@@ -39,12 +86,30 @@ type CreateLessonRequest struct {
 // Example:
 //   {"is_published": false} → IsPublished = &false → Update to false
 //   {}                      → IsPublished = nil    → Keep current value
+//
+// SectionID follows the same rule, with 0 meaning "remove from its section".
+//
+// Title, Content and Duration are saved as a new draft revision; students keep
+// seeing the published revision until the draft is published (Publish = true
+// publishes it in the same request).
+//
+// Type and the typed payloads are applied immediately. Changing Type requires
+// the payload of the new type unless the lesson already has one. Release
+// replaces the drip release rule ({"rule": "immediate"} removes it).
 type UpdateLessonRequest struct {
-	Title       *string `json:"title" binding:"omitempty,min=3,max=200"`
-	Content     *string `json:"content" binding:"omitempty,min=10"`
-	OrderIndex  *int    `json:"order_index" binding:"omitempty,min=0"`
-	Duration    *int    `json:"duration" binding:"omitempty,min=0"`
-	IsPublished *bool   `json:"is_published"` // Pointer allows nil vs false distinction
+	SectionID   *uint              `json:"section_id"`
+	Title       *string            `json:"title" binding:"omitempty,min=3,max=200"`
+	Type        *string            `json:"type" binding:"omitempty,oneof=text video quiz assignment"`
+	Content     *string            `json:"content" binding:"omitempty,min=10"`
+	OrderIndex  *int               `json:"order_index" binding:"omitempty,min=0"`
+	Duration    *int               `json:"duration" binding:"omitempty,min=0"`
+	IsPublished *bool              `json:"is_published"` // Pointer allows nil vs false distinction
+	IsPreview   *bool              `json:"is_preview"`
+	Publish     bool               `json:"publish"`      // Publish the draft immediately
+	Video       *VideoPayload      `json:"video"`
+	Quiz        *QuizPayload       `json:"quiz"`
+	Assignment  *AssignmentPayload `json:"assignment"`
+	Release     *ReleasePayload    `json:"release"`
 }
 
 // CourseListQuery represents query parameters for listing courses
@@ -54,20 +119,70 @@ type CourseListQuery struct {
 	Search       string  `form:"search"`
 	Category     string  `form:"category"` // Accept any category, filter in repository
 	Difficulty   string  `form:"difficulty" binding:"omitempty,oneof=beginner intermediate advanced"`
-	Published    *bool   `form:"published"`
-	SortBy       string  `form:"sort_by" binding:"omitempty,oneof=created_at updated_at title price rating popularity enrollment_count"`
+	Published    *bool   `form:"published"` // Same as status=published (false: any other status)
+	Status       string  `form:"status" binding:"omitempty,oneof=draft submitted changes_requested published archived"`
+	SortBy       string  `form:"sort_by" binding:"omitempty,oneof=relevance created_at updated_at submitted_at title price rating popularity enrollment_count"` // Default: relevance when searching, else created_at
 	SortOrder    string  `form:"sort_order" binding:"omitempty,oneof=asc desc"`
 	MinPrice     float64 `form:"min_price" binding:"omitempty,min=0"`
 	MaxPrice     float64 `form:"max_price" binding:"omitempty,min=0"`
-	InstructorID *uint   `form:"instructor_id" binding:"omitempty,min=1"` // Changed to pointer for optional filtering
+	MinRating    float64 `form:"min_rating" binding:"omitempty,min=0,max=5"` // Average rating at least this
+	PriceBucket  string  `form:"price_bucket" binding:"omitempty,oneof=free under_100k 100k_500k over_500k"`
+	InstructorID *uint   `form:"instructor_id" binding:"omitempty,min=1"`     // Changed to pointer for optional filtering
+	Tags         string  `form:"tags"`                                        // Comma-separated tag slugs
+	TagMatch     string  `form:"tag_match" binding:"omitempty,oneof=any all"` // Default: any
+	Cursor       string  `form:"cursor"`                                      // next_cursor of the previous page; replaces page
+}
+
+// ExtendEnrollmentRequest represents an admin change to a student's access period.
+// Exactly one of Days (added to the current end, or to now once lapsed), ExpiresAt or
+// Lifetime must be set.
+type ExtendEnrollmentRequest struct {
+	Days      int        `json:"days" binding:"omitempty,min=1,max=3650"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Lifetime  bool       `json:"lifetime"`
+}
+
+// CourseStatusRequest represents the optional comment sent with a status change
+// (submit, withdraw, archive, restore)
+type CourseStatusRequest struct {
+	Comment string `json:"comment" binding:"omitempty,max=2000"`
+}
+
+// ReviewCourseRequest represents an admin decision on a submitted course
+type ReviewCourseRequest struct {
+	Decision string `json:"decision" binding:"required,oneof=approve request_changes"`
+	Comment  string `json:"comment" binding:"omitempty,max=2000"` // Required when requesting changes
+}
+
+// Review decisions
+const (
+	ReviewDecisionApprove        = "approve"
+	ReviewDecisionRequestChanges = "request_changes"
+)
+
+// PopularTagsQuery represents query parameters for listing popular tags
+type PopularTagsQuery struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"` // Default: 20
+}
+
+// TagPageResponse is a tag landing page: the tag, its published courses and the
+// tags most often used alongside it
+type TagPageResponse struct {
+	Tag         *Tag              `json:"tag"`
+	Courses     []*CourseResponse `json:"courses"`
+	Pagination  PaginationMeta    `json:"pagination"`
+	RelatedTags []*TagWithCount   `json:"related_tags"`
 }
 
 // PaginationMeta represents pagination metadata
+// Total and TotalPages are only counted for page-based requests (0 when following a cursor).
 type PaginationMeta struct {
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	NextCursor string `json:"next_cursor,omitempty"` // Pass as cursor to fetch the next page
+	HasMore    bool   `json:"has_more"`
 }
 
 // CourseListResponse represents paginated course list response
@@ -76,6 +191,30 @@ type CourseListResponse struct {
 	Pagination PaginationMeta    `json:"pagination"`
 }
 
+// CourseFacetsResponse holds how many courses each filter value would return for the
+// current search. Each facet ignores its own filter, so sibling values keep their counts.
+type CourseFacetsResponse struct {
+	Total        int                `json:"total"`
+	Categories   []*FacetCount      `json:"categories"`
+	Difficulties []*FacetCount      `json:"difficulties"`
+	PriceBuckets []*FacetCount      `json:"price_buckets"`
+	Instructors  []*InstructorFacet `json:"instructors"`
+}
+
+// FacetCount is a filter value with its number of matching courses
+type FacetCount struct {
+	Value string `json:"value"` // Query parameter value (category, difficulty, price_bucket)
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// InstructorFacet is an instructor with their number of matching courses
+type InstructorFacet struct {
+	InstructorID uint   `json:"instructor_id"`
+	Name         string `json:"name"`
+	Count        int    `json:"count"`
+}
+
 // ReorderLessonsRequest represents payload for reordering lessons
 type ReorderLessonsRequest struct {
 	Updates []LessonOrderUpdate `json:"updates" binding:"required,min=1,dive"`
@@ -86,3 +225,109 @@ type LessonOrderUpdate struct {
 	LessonID   uint `json:"lesson_id" binding:"required,min=1"`
 	OrderIndex int  `json:"order_index" binding:"min=0"`
 }
+
+// CreateSectionRequest represents section creation payload
+type CreateSectionRequest struct {
+	Title       string `json:"title" binding:"required,min=3,max=200"`
+	Description string `json:"description" binding:"omitempty,max=1000"`
+	OrderIndex  int    `json:"order_index" binding:"omitempty,min=0"`
+}
+
+// UpdateSectionRequest represents section update payload
+type UpdateSectionRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=3,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=1000"`
+	OrderIndex  *int    `json:"order_index" binding:"omitempty,min=0"`
+}
+
+// ReorderSectionsRequest represents payload for reordering sections of a course
+type ReorderSectionsRequest struct {
+	Updates []SectionOrderUpdate `json:"updates" binding:"required,min=1,dive"`
+}
+
+// SectionOrderUpdate represents a single section order update
+type SectionOrderUpdate struct {
+	SectionID  uint `json:"section_id" binding:"required,min=1"`
+	OrderIndex int  `json:"order_index" binding:"min=0"`
+}
+
+// CourseLessonsResponse is the course curriculum grouped by section
+type CourseLessonsResponse struct {
+	Sections           []*SectionResponse `json:"sections"`
+	UnsectionedLessons []*LessonResponse  `json:"unsectioned_lessons"` // Lessons not assigned to any section
+	Lessons            []*LessonResponse  `json:"lessons"`             // Flat list for frontend compatibility
+	TotalLessons       int                `json:"total_lessons"`
+	CompletedLessons   int                `json:"completed_lessons"`
+}
+
+// LessonRevisionDiffQuery represents query parameters for diffing two revisions
+type LessonRevisionDiffQuery struct {
+	From uint `form:"from" binding:"required,min=1"` // Revision ID (older side)
+	To   uint `form:"to" binding:"required,min=1"`   // Revision ID (newer side)
+}
+
+// Diff line operations
+const (
+	DiffOpEqual  = "equal"
+	DiffOpAdd    = "add"
+	DiffOpRemove = "remove"
+)
+
+// DiffLine is a single line of a line-based content diff
+type DiffLine struct {
+	Op   string `json:"op"` // equal, add, remove
+	Text string `json:"text"`
+}
+
+// LessonRevisionDiffResponse is the difference between two lesson revisions
+type LessonRevisionDiffResponse struct {
+	From            *LessonRevisionResponse `json:"from"`
+	To              *LessonRevisionResponse `json:"to"`
+	TitleChanged    bool                    `json:"title_changed"`
+	DurationChanged bool                    `json:"duration_changed"`
+	Additions       int                     `json:"additions"`
+	Deletions       int                     `json:"deletions"`
+	Lines           []DiffLine              `json:"lines"`
+}
+
+// DuplicateCourseRequest optionally names the copy created by POST /courses/:id/duplicate
+type DuplicateCourseRequest struct {
+	Title *string `json:"title" binding:"omitempty,min=3,max=200"` // Default: "<title> (Copy)"
+}
+
+// SetPrerequisitesRequest replaces the prerequisite courses of a course
+type SetPrerequisitesRequest struct {
+	CourseIDs []uint `json:"course_ids" binding:"max=20,dive,min=1"` // Empty list removes all prerequisites
+}
+
+// PrerequisiteResponse is a prerequisite course, with the caller's completion when known
+type PrerequisiteResponse struct {
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Slug        string `json:"slug"`
+	Difficulty  string `json:"difficulty"`
+	IsCompleted *bool  `json:"is_completed,omitempty"` // nil for anonymous requests
+}
+
+// AddCollaboratorRequest invites an existing user to collaborate on a course
+type AddCollaboratorRequest struct {
+	Email        string `json:"email" binding:"required,email"`
+	Role         string `json:"role" binding:"required,oneof=co_instructor editor ta"`
+	RevenueShare int    `json:"revenue_share" binding:"omitempty,min=0,max=100"` // Percent of the instructor share (co-instructors only)
+}
+
+// UpdateCollaboratorRequest changes a collaborator's role or revenue share
+type UpdateCollaboratorRequest struct {
+	Role         *string `json:"role" binding:"omitempty,oneof=co_instructor editor ta"`
+	RevenueShare *int    `json:"revenue_share" binding:"omitempty,min=0,max=100"`
+}
+
+// CollaboratorResponse is a course collaborator with basic user info
+type CollaboratorResponse struct {
+	UserID       uint      `json:"user_id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	Role         string    `json:"role"` // owner, co_instructor, editor, ta
+	RevenueShare int       `json:"revenue_share"`
+	AddedAt      time.Time `json:"added_at"`
+}
@@ -0,0 +1,137 @@
+package course
+
+import "gorm.io/gorm"
+
+// Price buckets (rupiah) for the price_bucket filter and the price facet
+const (
+	PriceBucketFree       = "free"
+	PriceBucketUnder100k  = "under_100k"
+	PriceBucket100kTo500k = "100k_500k"
+	PriceBucketOver500k   = "over_500k"
+)
+
+// PriceBucket is a price range; Max is exclusive, nil = no upper bound
+type PriceBucket struct {
+	Key   string
+	Label string
+	Min   int
+	Max   *int
+}
+
+func intPtr(v int) *int { return &v }
+
+// PriceBuckets in display order
+var PriceBuckets = []PriceBucket{
+	{Key: PriceBucketFree, Label: "Free", Min: 0, Max: intPtr(1)},
+	{Key: PriceBucketUnder100k, Label: "Under Rp100.000", Min: 1, Max: intPtr(100000)},
+	{Key: PriceBucket100kTo500k, Label: "Rp100.000 - Rp500.000", Min: 100000, Max: intPtr(500000)},
+	{Key: PriceBucketOver500k, Label: "Over Rp500.000", Min: 500000},
+}
+
+func findPriceBucket(key string) *PriceBucket {
+	for i := range PriceBuckets {
+		if PriceBuckets[i].Key == key {
+			return &PriceBuckets[i]
+		}
+	}
+	return nil
+}
+
+// priceBucketExpr maps courses.price to its PriceBuckets key
+const priceBucketExpr = `CASE
+	WHEN courses.price < 1 THEN 'free'
+	WHEN courses.price < 100000 THEN 'under_100k'
+	WHEN courses.price < 500000 THEN '100k_500k'
+	ELSE 'over_500k'
+END`
+
+// Facets; each facet's counts ignore its own filter so the other values stay selectable
+const (
+	facetCategory   = "category"
+	facetDifficulty = "difficulty"
+	facetPrice      = "price"
+	facetInstructor = "instructor"
+)
+
+// maxInstructorFacets caps the instructor facet to the instructors with the most courses
+const maxInstructorFacets = 20
+
+// applyCourseFilters applies the course list filters to a query on the courses table,
+// leaving out the filter of the facet named by except ("" applies all of them)
+func applyCourseFilters(db *gorm.DB, query *CourseListQuery, search *courseSearch, except string) *gorm.DB {
+	if search != nil {
+		db = search.filter(db)
+	}
+
+	// A top-level category also matches the courses of its sub-categories
+	if query.Category != "" && except != facetCategory {
+		db = db.Where(`(courses.category = ? OR courses.category IN (
+			SELECT sub.name FROM categories sub
+			INNER JOIN categories parent ON parent.id = sub.parent_id
+			WHERE parent.name = ?
+		))`, query.Category, query.Category)
+	}
+
+	if query.Difficulty != "" && except != facetDifficulty {
+		db = db.Where("courses.difficulty = ?", query.Difficulty)
+	}
+
+	if query.Published != nil {
+		if *query.Published {
+			db = db.Where("courses.status = ?", CourseStatusPublished)
+		} else {
+			db = db.Where("courses.status <> ?", CourseStatusPublished)
+		}
+	}
+	if query.Status != "" {
+		db = db.Where("courses.status = ?", query.Status)
+	}
+
+	if except != facetPrice {
+		if query.MinPrice > 0 {
+			db = db.Where("courses.price >= ?", query.MinPrice)
+		}
+		if query.MaxPrice > 0 {
+			db = db.Where("courses.price <= ?", query.MaxPrice)
+		}
+		if bucket := findPriceBucket(query.PriceBucket); bucket != nil {
+			db = db.Where("courses.price >= ?", bucket.Min)
+			if bucket.Max != nil {
+				db = db.Where("courses.price < ?", *bucket.Max)
+			}
+		}
+	}
+
+	if query.MinRating > 0 {
+		db = db.Where("courses.average_rating >= ?", query.MinRating)
+	}
+
+	// Filter by instructor (support pointer for optional filtering), including courses they collaborate on
+	if query.InstructorID != nil && *query.InstructorID > 0 && except != facetInstructor {
+		db = db.Where("(courses.instructor_id = ? OR EXISTS (SELECT 1 FROM course_collaborators cc WHERE cc.course_id = courses.id AND cc.user_id = ?))", *query.InstructorID, *query.InstructorID)
+	}
+
+	// Tags match by slug: any of them by default, every one with tag_match=all
+	if slugs := parseTagSlugs(query.Tags); len(slugs) > 0 {
+		if query.TagMatch == TagMatchAll {
+			db = db.Where(`(SELECT COUNT(*) FROM course_tags ct
+				INNER JOIN tags t ON t.id = ct.tag_id
+				WHERE ct.course_id = courses.id AND t.slug IN ?) = ?`, slugs, len(slugs))
+		} else {
+			db = db.Where(`EXISTS (SELECT 1 FROM course_tags ct
+				INNER JOIN tags t ON t.id = ct.tag_id
+				WHERE ct.course_id = courses.id AND t.slug IN ?)`, slugs)
+		}
+	}
+
+	return db.Where("courses.deleted_at IS NULL")
+}
+
+// CourseFacetCounts holds the raw facet counts of a course search
+type CourseFacetCounts struct {
+	Total        int
+	Categories   map[string]int
+	Difficulties map[string]int
+	PriceBuckets map[string]int
+	Instructors  []*InstructorFacet // Most courses first
+}
@@ -1,6 +1,7 @@
 package course
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -32,8 +33,22 @@ func (h *Handler) CreateCourse(c *gin.Context) {
 		return
 	}
 
-	course, err := h.service.CreateCourse(c.Request.Context(), userID.(uint), &req)
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	course, err := h.service.CreateCourse(c.Request.Context(), userID.(uint), userRole.(string), &req)
 	if err != nil {
+		if err == ErrInvalidCategory || err == ErrInvalidTag || err == ErrUnknownTag {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrSlugTaken {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -113,8 +128,8 @@ func (h *Handler) ListCourses(c *gin.Context) {
 	if query.Limit == 0 {
 		query.Limit = 10
 	}
-	if query.SortBy == "" {
-		query.SortBy = "created_at"
+	if query.SortBy == "" && query.Search == "" {
+		query.SortBy = "created_at" // Searches default to relevance (see FindAllCoursesWithMeta)
 	}
 	if query.SortOrder == "" {
 		query.SortOrder = "desc"
@@ -142,6 +157,10 @@ func (h *Handler) ListCourses(c *gin.Context) {
 
 	result, err := h.service.ListCourses(c.Request.Context(), userID, &query)
 	if err != nil {
+		if err == ErrInvalidCursor {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -151,6 +170,104 @@ func (h *Handler) ListCourses(c *gin.Context) {
 	})
 }
 
+// GetCourseFacets handles GET /courses/facets
+// Accepts the same filters as GET /courses and returns per-value course counts
+func (h *Handler) GetCourseFacets(c *gin.Context) {
+	var query CourseListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// SECURITY: Instructors can only filter by their own courses (same rule as ListCourses)
+	if query.InstructorID != nil && *query.InstructorID > 0 {
+		uid, _ := c.Get("userID")
+		role, _ := c.Get("userRole")
+		userID, _ := uid.(uint)
+		if userRole, _ := role.(string); userRole == "instructor" && *query.InstructorID != userID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "You can only view your own courses"})
+			return
+		}
+	}
+
+	facets, err := h.service.GetCourseFacets(c.Request.Context(), &query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": facets,
+	})
+}
+
+// ListPopularTags handles GET /tags
+func (h *Handler) ListPopularTags(c *gin.Context) {
+	var query PopularTagsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tags, err := h.service.ListPopularTags(c.Request.Context(), query.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": tags,
+	})
+}
+
+// GetTagPage handles GET /tags/:slug
+// Accepts the pagination, sorting and filters of GET /courses; only published courses are listed
+func (h *Handler) GetTagPage(c *gin.Context) {
+	var query CourseListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Set defaults (same as ListCourses)
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+	if query.SortBy == "" && query.Search == "" {
+		query.SortBy = "popularity"
+	}
+	if query.SortOrder == "" {
+		query.SortOrder = "desc"
+	}
+
+	// Get user ID if authenticated (optional for is_enrolled field)
+	var userID uint
+	if uid, exists := c.Get("userID"); exists {
+		userID = uid.(uint)
+	}
+
+	page, err := h.service.GetTagPage(c.Request.Context(), userID, c.Param("slug"), &query)
+	if err != nil {
+		if err == ErrTagNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrInvalidCursor {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": page,
+	})
+}
+
 // UpdateCourse handles PATCH /courses/:id
 func (h *Handler) UpdateCourse(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -188,10 +305,14 @@ func (h *Handler) UpdateCourse(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
-		if err == ErrNoLessonsToPublish {
+		if err == ErrNoLessonsToPublish || err == ErrInvalidCategory || err == ErrInvalidTag || err == ErrUnknownTag {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+		if err == ErrInvalidStatusChange || err == ErrSlugTaken {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -242,6 +363,225 @@ func (h *Handler) DeleteCourse(c *gin.Context) {
 	})
 }
 
+// writeStatusError maps review workflow errors to HTTP status codes
+func writeStatusError(c *gin.Context, err error) {
+	switch err {
+	case ErrCourseNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case ErrInvalidStatusChange:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case ErrNoLessonsToPublish, ErrCommentRequired, ErrInvalidCursor:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// changeCourseStatus handles the instructor status endpoints (optional {"comment": "..."} body)
+func (h *Handler) changeCourseStatus(c *gin.Context, status string, message string) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	var req CourseStatusRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+
+	course, err := h.service.ChangeCourseStatus(c.Request.Context(), userID.(uint), role, uint(id), status, &req)
+	if err != nil {
+		writeStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    course,
+	})
+}
+
+// SubmitCourse handles POST /courses/:id/submit
+func (h *Handler) SubmitCourse(c *gin.Context) {
+	h.changeCourseStatus(c, CourseStatusSubmitted, "Course submitted for review")
+}
+
+// WithdrawCourse handles POST /courses/:id/withdraw
+func (h *Handler) WithdrawCourse(c *gin.Context) {
+	h.changeCourseStatus(c, CourseStatusDraft, "Course submission withdrawn")
+}
+
+// ArchiveCourse handles POST /courses/:id/archive
+func (h *Handler) ArchiveCourse(c *gin.Context) {
+	h.changeCourseStatus(c, CourseStatusArchived, "Course archived")
+}
+
+// RestoreCourse handles POST /courses/:id/restore
+func (h *Handler) RestoreCourse(c *gin.Context) {
+	h.changeCourseStatus(c, CourseStatusDraft, "Course restored to draft")
+}
+
+// ReviewCourse handles POST /courses/:id/review
+func (h *Handler) ReviewCourse(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	var req ReviewCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+
+	course, err := h.service.ReviewCourse(c.Request.Context(), userID.(uint), role, uint(id), &req)
+	if err != nil {
+		writeStatusError(c, err)
+		return
+	}
+
+	message := "Course approved and published"
+	if req.Decision == ReviewDecisionRequestChanges {
+		message = "Changes requested"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    course,
+	})
+}
+
+// ListReviewQueue handles GET /courses/review-queue
+func (h *Handler) ListReviewQueue(c *gin.Context) {
+	var query CourseListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+
+	result, err := h.service.ListReviewQueue(c.Request.Context(), role, &query)
+	if err != nil {
+		writeStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// GetCourseStatusHistory handles GET /courses/:id/status-history
+func (h *Handler) GetCourseStatusHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+
+	events, err := h.service.GetCourseStatusHistory(c.Request.Context(), userID.(uint), role, uint(id))
+	if err != nil {
+		writeStatusError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": events,
+	})
+}
+
+// DuplicateCourse handles POST /courses/:id/duplicate
+func (h *Handler) DuplicateCourse(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	// Body is optional (custom title for the copy)
+	var req DuplicateCourseRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	course, err := h.service.DuplicateCourse(c.Request.Context(), userID.(uint), userRole.(string), uint(id), &req)
+	if err != nil {
+		if err == ErrCourseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrSlugTaken {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Course duplicated successfully",
+		"data":    course,
+	})
+}
+
 // CreateLesson handles POST /courses/:id/lessons
 func (h *Handler) CreateLesson(c *gin.Context) {
 	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -279,6 +619,14 @@ func (h *Handler) CreateLesson(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
+		if err == ErrSectionNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrContentRequired || err == ErrVideoRequired || err == ErrQuizRequired || err == ErrAssignmentRequired || err == ErrQuizNotFound || err == ErrInvalidReleaseRule {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -388,6 +736,14 @@ func (h *Handler) UpdateLesson(c *gin.Context) {
 			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 			return
 		}
+		if err == ErrSectionNotFound {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrContentRequired || err == ErrVideoRequired || err == ErrQuizRequired || err == ErrAssignmentRequired || err == ErrQuizNotFound || err == ErrInvalidReleaseRule {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -474,7 +830,721 @@ func (h *Handler) ReorderLessons(c *gin.Context) {
 	})
 }
 
-// EnrollCourse handles POST /courses/:id/enroll
+// PublishLesson handles POST /lessons/:id/publish
+func (h *Handler) PublishLesson(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lesson ID"})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	lesson, err := h.service.PublishLesson(c.Request.Context(), userID.(uint), userRole.(string), uint(id))
+	if err != nil {
+		if err == ErrLessonNotFound || err == ErrCourseNotFound || err == ErrRevisionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrNoDraftToPublish {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Lesson draft published successfully",
+		"data":    lesson,
+	})
+}
+
+// ListLessonRevisions handles GET /lessons/:id/revisions
+func (h *Handler) ListLessonRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lesson ID"})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	revisions, err := h.service.ListLessonRevisions(c.Request.Context(), userID.(uint), userRole.(string), uint(id))
+	if err != nil {
+		if err == ErrLessonNotFound || err == ErrCourseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": revisions,
+	})
+}
+
+// GetLessonRevision handles GET /lessons/:id/revisions/:revisionId
+func (h *Handler) GetLessonRevision(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lesson ID"})
+		return
+	}
+
+	revisionID, err := strconv.ParseUint(c.Param("revisionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	revision, err := h.service.GetLessonRevision(c.Request.Context(), userID.(uint), userRole.(string), uint(id), uint(revisionID))
+	if err != nil {
+		if err == ErrLessonNotFound || err == ErrCourseNotFound || err == ErrRevisionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": revision,
+	})
+}
+
+// DiffLessonRevisions handles GET /lessons/:id/revisions/diff?from=&to=
+func (h *Handler) DiffLessonRevisions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lesson ID"})
+		return
+	}
+
+	var query LessonRevisionDiffQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	diff, err := h.service.DiffLessonRevisions(c.Request.Context(), userID.(uint), userRole.(string), uint(id), query.From, query.To)
+	if err != nil {
+		if err == ErrLessonNotFound || err == ErrCourseNotFound || err == ErrRevisionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": diff,
+	})
+}
+
+// RestoreLessonRevision handles POST /lessons/:id/revisions/:revisionId/restore
+func (h *Handler) RestoreLessonRevision(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid lesson ID"})
+		return
+	}
+
+	revisionID, err := strconv.ParseUint(c.Param("revisionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid revision ID"})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	lesson, err := h.service.RestoreLessonRevision(c.Request.Context(), userID.(uint), userRole.(string), uint(id), uint(revisionID))
+	if err != nil {
+		if err == ErrLessonNotFound || err == ErrCourseNotFound || err == ErrRevisionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Lesson revision restored successfully",
+		"data":    lesson,
+	})
+}
+
+// GetCourseSections handles GET /courses/:id/sections
+func (h *Handler) GetCourseSections(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	sections, err := h.service.GetCourseSections(c.Request.Context(), uint(courseID))
+	if err != nil {
+		if err == ErrCourseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": sections,
+	})
+}
+
+// CreateSection handles POST /courses/:id/sections
+func (h *Handler) CreateSection(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	var req CreateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	section, err := h.service.CreateSection(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), &req)
+	if err != nil {
+		if err == ErrCourseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Section created successfully",
+		"data":    section,
+	})
+}
+
+// UpdateSection handles PATCH /courses/:id/sections/:sectionId
+func (h *Handler) UpdateSection(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	sectionID, err := strconv.ParseUint(c.Param("sectionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+		return
+	}
+
+	var req UpdateSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	section, err := h.service.UpdateSection(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), uint(sectionID), &req)
+	if err != nil {
+		if err == ErrCourseNotFound || err == ErrSectionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section updated successfully",
+		"data":    section,
+	})
+}
+
+// DeleteSection handles DELETE /courses/:id/sections/:sectionId
+func (h *Handler) DeleteSection(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	sectionID, err := strconv.ParseUint(c.Param("sectionId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid section ID"})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.service.DeleteSection(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), uint(sectionID))
+	if err != nil {
+		if err == ErrCourseNotFound || err == ErrSectionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Section deleted successfully",
+	})
+}
+
+// ReorderSections handles PATCH /courses/:id/sections/reorder
+func (h *Handler) ReorderSections(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	var req ReorderSectionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.service.ReorderSections(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), req.Updates)
+	if err != nil {
+		if err == ErrCourseNotFound || err == ErrSectionNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Sections reordered successfully",
+	})
+}
+
+// GetCoursePrerequisites handles GET /courses/:id/prerequisites
+func (h *Handler) GetCoursePrerequisites(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	// Optional auth: logged-in users see their completion per prerequisite
+	var userID uint
+	if id, exists := c.Get("userID"); exists {
+		userID = id.(uint)
+	}
+
+	prerequisites, err := h.service.GetCoursePrerequisites(c.Request.Context(), userID, uint(courseID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": prerequisites,
+	})
+}
+
+// SetCoursePrerequisites handles PUT /courses/:id/prerequisites
+func (h *Handler) SetCoursePrerequisites(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	var req SetPrerequisitesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	prerequisites, err := h.service.SetCoursePrerequisites(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), &req)
+	if err != nil {
+		if err == ErrCourseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrInvalidPrerequisite || err == ErrPrerequisiteCycle {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Prerequisites updated successfully",
+		"data":    prerequisites,
+	})
+}
+
+// GetCourseCollaborators handles GET /courses/:id/collaborators
+func (h *Handler) GetCourseCollaborators(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	collaborators, err := h.service.GetCourseCollaborators(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID))
+	if err != nil {
+		if err == ErrCourseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Collaborators retrieved successfully",
+		"data":    collaborators,
+	})
+}
+
+// AddCollaborator handles POST /courses/:id/collaborators
+func (h *Handler) AddCollaborator(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	var req AddCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	collaborator, err := h.service.AddCollaborator(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), &req)
+	if err != nil {
+		if err == ErrCourseNotFound || err == ErrUserNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrCollaboratorExists {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrInvalidCollaborator || err == ErrInvalidRevenueShare {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Collaborator added successfully",
+		"data":    collaborator,
+	})
+}
+
+// UpdateCollaborator handles PATCH /courses/:id/collaborators/:userId
+func (h *Handler) UpdateCollaborator(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	collaboratorID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req UpdateCollaboratorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	collaborator, err := h.service.UpdateCollaborator(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), uint(collaboratorID), &req)
+	if err != nil {
+		if err == ErrCourseNotFound || err == ErrCollaboratorNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrInvalidRevenueShare {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Collaborator updated successfully",
+		"data":    collaborator,
+	})
+}
+
+// RemoveCollaborator handles DELETE /courses/:id/collaborators/:userId
+func (h *Handler) RemoveCollaborator(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	collaboratorID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	// Get user ID and role from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err = h.service.RemoveCollaborator(c.Request.Context(), userID.(uint), userRole.(string), uint(courseID), uint(collaboratorID))
+	if err != nil {
+		if err == ErrCourseNotFound || err == ErrCollaboratorNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Collaborator removed successfully",
+	})
+}
+
+// EnrollCourse handles POST /courses/:id/enroll
 func (h *Handler) EnrollCourse(c *gin.Context) {
 	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -499,10 +1569,27 @@ func (h *Handler) EnrollCourse(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
-		if err == ErrAlreadyEnrolled {
+		if err == ErrAlreadyEnrolled || err == ErrEnrollmentExpired {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
+		var prerequisitesErr *PrerequisitesNotMetError
+		if errors.As(err, &prerequisitesErr) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":                 err.Error(),
+				"missing_prerequisites": prerequisitesErr.Missing,
+			})
+			return
+		}
+		var waitlistedErr *WaitlistedError
+		if errors.As(err, &waitlistedErr) {
+			c.JSON(http.StatusAccepted, gin.H{
+				"message":           err.Error(),
+				"waitlisted":        true,
+				"waitlist_position": waitlistedErr.Position,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -541,3 +1628,152 @@ func (h *Handler) UnenrollCourse(c *gin.Context) {
 		"message": "Successfully unenrolled from course",
 	})
 }
+
+// RenewEnrollment handles POST /courses/:id/enroll/renew (free courses; paid ones are bought again)
+func (h *Handler) RenewEnrollment(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	// Get user ID from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	enrollment, err := h.service.RenewEnrollment(c.Request.Context(), userID.(uint), uint(courseID))
+	if err != nil {
+		if err == ErrCourseNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrCourseNotPublished || err == ErrNotEnrolled {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrPaymentRequired {
+			c.JSON(http.StatusPaymentRequired, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Enrollment renewed successfully",
+		"data":    enrollment,
+	})
+}
+
+// ExtendEnrollment handles PATCH /courses/:id/enrollments/:userId (admin only)
+func (h *Handler) ExtendEnrollment(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	studentID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req ExtendEnrollmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Get user role from JWT middleware
+	userRole, exists := c.Get("userRole")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	enrollment, err := h.service.ExtendEnrollment(c.Request.Context(), userRole.(string), uint(courseID), uint(studentID), &req)
+	if err != nil {
+		if err == ErrUnauthorized {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrNotEnrolled {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err == ErrInvalidExtension {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Enrollment updated successfully",
+		"data":    enrollment,
+	})
+}
+
+// GetWaitlistPosition handles GET /courses/:id/waitlist
+func (h *Handler) GetWaitlistPosition(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	// Get user ID from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	position, err := h.service.GetWaitlistPosition(c.Request.Context(), userID.(uint), uint(courseID))
+	if err != nil {
+		if err == ErrNotWaitlisted {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": gin.H{"course_id": courseID, "position": position},
+	})
+}
+
+// LeaveWaitlist handles DELETE /courses/:id/waitlist
+func (h *Handler) LeaveWaitlist(c *gin.Context) {
+	courseID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course ID"})
+		return
+	}
+
+	// Get user ID from JWT middleware
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.LeaveWaitlist(c.Request.Context(), userID.(uint), uint(courseID)); err != nil {
+		if err == ErrNotWaitlisted {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Left the waitlist",
+	})
+}
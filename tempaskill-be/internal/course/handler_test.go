@@ -2,6 +2,7 @@ package course
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -19,6 +20,13 @@ import (
 	"gorm.io/gorm"
 )
 
+// noopNotifier discards review notifications
+type noopNotifier struct{}
+
+func (noopNotifier) Notify(ctx context.Context, userID uint, notificationType, title, message, link string) error {
+	return nil
+}
+
 type CourseHandlerTestSuite struct {
 	suite.Suite
 	router         *gin.Engine
@@ -42,7 +50,7 @@ func (suite *CourseHandlerTestSuite) SetupSuite() {
 	suite.db = database.GetDB()
 
 	// Auto-migrate models
-	err = suite.db.AutoMigrate(&auth.User{}, &Course{}, &Lesson{}, &Enrollment{})
+	err = suite.db.AutoMigrate(&auth.User{}, &Course{}, &Section{}, &Lesson{}, &LessonRevision{}, &Enrollment{}, &CourseSlugHistory{}, &Tag{}, &CourseTag{}, &CourseStatusEvent{})
 	suite.NoError(err)
 
 	// Setup Gin router
@@ -52,7 +60,7 @@ func (suite *CourseHandlerTestSuite) SetupSuite() {
 
 	// Register course routes
 	v1 := suite.router.Group("/api/v1")
-	RegisterRoutes(v1, suite.db, suite.authMiddleware)
+	RegisterRoutes(v1, suite.db, suite.authMiddleware, noopNotifier{})
 
 	// Create test users
 	suite.createTestUsers(cfg)
@@ -89,7 +97,10 @@ func (suite *CourseHandlerTestSuite) createTestUsers(cfg *config.Config) {
 func (suite *CourseHandlerTestSuite) TearDownSuite() {
 	// Clean up test data
 	suite.db.Exec("DELETE FROM enrollments")
+	suite.db.Exec("DELETE FROM lesson_revisions")
 	suite.db.Exec("DELETE FROM lessons")
+	suite.db.Exec("DELETE FROM course_status_events")
+	suite.db.Exec("DELETE FROM course_sections")
 	suite.db.Exec("DELETE FROM courses")
 	suite.db.Exec("DELETE FROM users")
 }
@@ -97,7 +108,9 @@ func (suite *CourseHandlerTestSuite) TearDownSuite() {
 func (suite *CourseHandlerTestSuite) SetupTest() {
 	// Clean courses, lessons, enrollments before each test
 	suite.db.Exec("DELETE FROM enrollments")
+	suite.db.Exec("DELETE FROM lesson_revisions")
 	suite.db.Exec("DELETE FROM lessons")
+	suite.db.Exec("DELETE FROM course_sections")
 	suite.db.Exec("DELETE FROM courses")
 }
 
@@ -178,6 +191,7 @@ func (suite *CourseHandlerTestSuite) TestListCourses_Success() {
 			Difficulty:   "beginner",
 			InstructorID: suite.instructorID,
 			IsPublished:  true,
+			Status:       CourseStatusPublished,
 		},
 		{
 			Title:        "Course 2",
@@ -186,6 +200,7 @@ func (suite *CourseHandlerTestSuite) TestListCourses_Success() {
 			Difficulty:   "intermediate",
 			InstructorID: suite.instructorID,
 			IsPublished:  true,
+			Status:       CourseStatusPublished,
 		},
 		{
 			Title:        "Unpublished Course",
@@ -226,6 +241,7 @@ func (suite *CourseHandlerTestSuite) TestListCourses_WithFilters() {
 		Difficulty:   "advanced",
 		InstructorID: suite.instructorID,
 		IsPublished:  true,
+		Status:       CourseStatusPublished,
 	})
 
 	req, _ := http.NewRequest("GET", "/api/v1/courses?category=programming&difficulty=advanced", nil)
@@ -255,6 +271,7 @@ func (suite *CourseHandlerTestSuite) TestListCourses_WithSearch() {
 		Difficulty:   "beginner",
 		InstructorID: suite.instructorID,
 		IsPublished:  true,
+		Status:       CourseStatusPublished,
 	})
 
 	req, _ := http.NewRequest("GET", "/api/v1/courses?search=javascript", nil)
@@ -280,6 +297,7 @@ func (suite *CourseHandlerTestSuite) TestGetCourse_Success() {
 		Difficulty:   "beginner",
 		InstructorID: suite.instructorID,
 		IsPublished:  true,
+		Status:       CourseStatusPublished,
 	}
 	suite.db.Create(course)
 
@@ -325,9 +343,10 @@ func (suite *CourseHandlerTestSuite) TestUpdateCourse_Success() {
 		IsPublished:  false,
 	}
 	suite.db.Create(course)
+	suite.db.Create(&Lesson{CourseID: course.ID, Title: "Lesson 1", Slug: "lesson-1", IsPublished: true})
 
 	newTitle := "Updated Title"
-	isPublished := true
+	isPublished := true // Instructors publish through review: the course is submitted
 	reqBody := UpdateCourseRequest{
 		Title:       &newTitle,
 		IsPublished: &isPublished,
@@ -348,7 +367,8 @@ func (suite *CourseHandlerTestSuite) TestUpdateCourse_Success() {
 
 	data := response["data"].(map[string]interface{})
 	assert.Equal(suite.T(), "Updated Title", data["title"])
-	assert.Equal(suite.T(), true, data["is_published"])
+	assert.Equal(suite.T(), CourseStatusSubmitted, data["status"])
+	assert.Equal(suite.T(), false, data["is_published"])
 }
 
 func (suite *CourseHandlerTestSuite) TestUpdateCourse_Forbidden() {
@@ -413,6 +433,7 @@ func (suite *CourseHandlerTestSuite) TestEnrollCourse_Success() {
 		Difficulty:   "beginner",
 		InstructorID: suite.instructorID,
 		IsPublished:  true, // Must be published to enroll
+		Status:       CourseStatusPublished,
 	}
 	suite.db.Create(course)
 
@@ -462,6 +483,7 @@ func (suite *CourseHandlerTestSuite) TestEnrollCourse_AlreadyEnrolled() {
 		Difficulty:   "beginner",
 		InstructorID: suite.instructorID,
 		IsPublished:  true,
+		Status:       CourseStatusPublished,
 	}
 	suite.db.Create(course)
 
@@ -491,6 +513,7 @@ func (suite *CourseHandlerTestSuite) TestUnenrollCourse_Success() {
 		Difficulty:    "beginner",
 		InstructorID:  suite.instructorID,
 		IsPublished:   true,
+		Status:        CourseStatusPublished,
 		EnrolledCount: 1,
 	}
 	suite.db.Create(course)
@@ -525,6 +548,7 @@ func (suite *CourseHandlerTestSuite) TestUnenrollCourse_NotEnrolled() {
 		Difficulty:   "beginner",
 		InstructorID: suite.instructorID,
 		IsPublished:  true,
+		Status:       CourseStatusPublished,
 	}
 	suite.db.Create(course)
 
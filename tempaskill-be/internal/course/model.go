@@ -20,40 +20,390 @@ func (User) TableName() string {
 
 // Course represents a course in the system
 type Course struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	Title         string         `gorm:"type:varchar(200);not null" json:"title"`
-	Slug          string         `gorm:"type:varchar(250);uniqueIndex;not null" json:"slug"`
-	Description   string         `gorm:"type:text" json:"description"`
-	ThumbnailURL  string         `gorm:"type:varchar(255)" json:"thumbnail_url"`
-	Category      string         `gorm:"type:varchar(50);not null" json:"category"`
-	Difficulty    string         `gorm:"type:varchar(20);not null;default:'beginner'" json:"difficulty"` // beginner, intermediate, advanced
-	InstructorID  uint           `gorm:"not null;index" json:"instructor_id"`
-	Price         int            `gorm:"default:0" json:"price"` // in cents/rupiah
-	IsPublished   bool           `gorm:"default:false" json:"is_published"`
-	EnrolledCount int            `gorm:"default:0" json:"enrolled_count"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID             uint           `gorm:"primaryKey" json:"id"`
+	Title          string         `gorm:"type:varchar(200);not null;index:ft_courses_search,class:FULLTEXT" json:"title"`
+	Slug           string         `gorm:"type:varchar(250);uniqueIndex;not null" json:"slug"`
+	Description    string         `gorm:"type:text;index:ft_courses_search,class:FULLTEXT" json:"description"`
+	ThumbnailURL   string         `gorm:"type:varchar(255)" json:"thumbnail_url"`
+	Category       string         `gorm:"type:varchar(50);not null" json:"category"`
+	Difficulty     string         `gorm:"type:varchar(20);not null;default:'beginner'" json:"difficulty"` // beginner, intermediate, advanced
+	InstructorID   uint           `gorm:"not null;index" json:"instructor_id"`
+	Price          int            `gorm:"default:0" json:"price"`                                                     // in cents/rupiah
+	AccessDays     int            `gorm:"column:access_duration_days;not null;default:0" json:"access_duration_days"` // Enrollment length; 0 = lifetime access
+	MaxEnrollments int            `gorm:"not null;default:0" json:"max_enrollments"`                                  // Seat cap for cohorts; 0 = unlimited
+	IsPublished    bool           `gorm:"default:false" json:"is_published"`                                          // Mirrors Status == published for modules reading it directly
+	EnrolledCount  int            `gorm:"default:0" json:"enrolled_count"`
+	AverageRating  float64        `gorm:"type:decimal(3,2);not null;default:0;index" json:"average_rating"` // Kept in sync by the review repository
+	ReviewCount    int            `gorm:"not null;default:0" json:"review_count"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Review workflow (see CanTransition)
+	Status      string     `gorm:"type:varchar(20);not null;default:'draft';index" json:"status"` // draft, submitted, changes_requested, published, archived
+	SubmittedAt *time.Time `json:"submitted_at"`
+	ReviewedAt  *time.Time `json:"reviewed_at"`
+	ReviewedBy  *uint      `json:"reviewed_by"`
+	PublishedAt *time.Time `json:"published_at"`
+	ArchivedAt  *time.Time `json:"archived_at"`
 
 	// Relations
 	Instructor  *User        `gorm:"foreignKey:InstructorID" json:"instructor,omitempty"`
+	Sections    []Section    `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"sections,omitempty"`
 	Lessons     []Lesson     `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"lessons,omitempty"`
 	Enrollments []Enrollment `gorm:"foreignKey:CourseID;constraint:OnDelete:CASCADE" json:"-"`
+
+	// Loaded from course_tags by the service (not a gorm association)
+	Tags []Tag `gorm:"-" json:"tags,omitempty"`
+}
+
+// Section groups related lessons within a course (a.k.a. module)
+type Section struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	CourseID    uint           `gorm:"not null;index" json:"course_id"`
+	Title       string         `gorm:"type:varchar(200);not null" json:"title"`
+	Description string         `gorm:"type:text" json:"description"`
+	OrderIndex  int            `gorm:"not null;default:0" json:"order_index"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 // Lesson represents a lesson within a course
 type Lesson struct {
 	ID          uint           `gorm:"primaryKey" json:"id"`
 	CourseID    uint           `gorm:"not null;index" json:"course_id"`
-	Title       string         `gorm:"type:varchar(200);not null" json:"title"`
+	SectionID   *uint          `gorm:"index" json:"section_id"` // nil = not assigned to any section
+	Title       string         `gorm:"type:varchar(200);not null;index:ft_lessons_search,class:FULLTEXT" json:"title"`
 	Slug        string         `gorm:"type:varchar(250);not null" json:"slug"`
-	Content     string         `gorm:"type:longtext" json:"content"` // MDX content
+	Type        string         `gorm:"type:varchar(20);not null;default:'text'" json:"type"`                // text, video, quiz, assignment
+	Content     string         `gorm:"type:longtext;index:ft_lessons_search,class:FULLTEXT" json:"content"` // MDX content (notes for non-text lessons)
 	OrderIndex  int            `gorm:"not null;default:0" json:"order_index"`
 	Duration    int            `gorm:"default:0" json:"duration"` // estimated reading time in minutes
 	IsPublished bool           `gorm:"default:false" json:"is_published"`
+	IsPreview   bool           `gorm:"default:false" json:"is_preview"` // Free sample: full content for visitors
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Type-specific payload (only the fields of the lesson's type are set)
+	VideoURL           string         `gorm:"type:varchar(500)" json:"video_url,omitempty"`
+	VideoDuration      int            `gorm:"default:0" json:"video_duration,omitempty"` // in seconds
+	CaptionTracks      []CaptionTrack `gorm:"serializer:json;type:json" json:"caption_tracks,omitempty"`
+	QuizID             *uint          `gorm:"index" json:"quiz_id,omitempty"`
+	AssignmentBrief    string         `gorm:"type:text" json:"assignment_brief,omitempty"` // MDX
+	AssignmentMaxScore int            `gorm:"default:0" json:"assignment_max_score,omitempty"`
+
+	// Drip release rule, only enforced for enrolled students (see ReleaseStatus)
+	ReleaseRule          string     `gorm:"type:varchar(30);not null;default:'immediate'" json:"release_rule"` // immediate, fixed_date, days_after_enrollment, after_lesson
+	ReleaseAt            *time.Time `json:"release_at,omitempty"`                                              // fixed_date
+	ReleaseAfterDays     int        `gorm:"default:0" json:"release_after_days,omitempty"`                     // days_after_enrollment
+	ReleaseAfterLessonID *uint      `json:"release_after_lesson_id,omitempty"`                                 // after_lesson
+
+	// Revision pointers: Title/Content/Duration above always hold the published revision
+	PublishedRevisionID *uint `json:"published_revision_id"`
+	DraftRevisionID     *uint `json:"draft_revision_id"` // nil = no unpublished edits
+
+	// Relations
+	Revisions []LessonRevision `gorm:"foreignKey:LessonID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Lesson types
+const (
+	LessonTypeText       = "text"
+	LessonTypeVideo      = "video"
+	LessonTypeQuiz       = "quiz"
+	LessonTypeAssignment = "assignment"
+)
+
+// Lesson release rules
+const (
+	ReleaseRuleImmediate           = "immediate"
+	ReleaseRuleFixedDate           = "fixed_date"
+	ReleaseRuleDaysAfterEnrollment = "days_after_enrollment"
+	ReleaseRuleAfterLesson         = "after_lesson"
+)
+
+// HasReleaseRule reports whether the lesson is drip-fed rather than available on enrollment
+func (l *Lesson) HasReleaseRule() bool {
+	return l.ReleaseRule != "" && l.ReleaseRule != ReleaseRuleImmediate
+}
+
+// ReleaseStatus evaluates the lesson's release rule for a student who enrolled at enrolledAt
+// and has completed the lessons in completed. availableAt is set for time-based rules
+// and nil when the lesson waits for another lesson to be completed.
+// Preview lessons are open to every visitor, so they are never locked for students.
+func (l *Lesson) ReleaseStatus(enrolledAt time.Time, completed map[uint]bool, now time.Time) (available bool, availableAt *time.Time) {
+	if l.IsPreview {
+		return true, nil
+	}
+
+	switch l.ReleaseRule {
+	case ReleaseRuleFixedDate:
+		if l.ReleaseAt == nil {
+			return true, nil
+		}
+		at := *l.ReleaseAt
+		return !now.Before(at), &at
+	case ReleaseRuleDaysAfterEnrollment:
+		at := enrolledAt.AddDate(0, 0, l.ReleaseAfterDays)
+		return !now.Before(at), &at
+	case ReleaseRuleAfterLesson:
+		if l.ReleaseAfterLessonID == nil {
+			return true, nil
+		}
+		return completed[*l.ReleaseAfterLessonID], nil
+	default:
+		return true, nil
+	}
+}
+
+// CaptionTrack is a subtitle file attached to a video lesson (WebVTT)
+type CaptionTrack struct {
+	Language string `json:"language" binding:"required,min=2,max=10"` // BCP 47 tag, e.g. "id", "en-US"
+	Label    string `json:"label" binding:"required,max=50"`
+	URL      string `json:"url" binding:"required,url,max=500"`
+}
+
+// LessonRevision is an immutable snapshot of a lesson's content, created on every save
+type LessonRevision struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	LessonID       uint       `gorm:"not null;uniqueIndex:idx_lesson_revisions_version" json:"lesson_id"`
+	Version        int        `gorm:"not null;uniqueIndex:idx_lesson_revisions_version" json:"version"` // 1, 2, 3... per lesson
+	Title          string     `gorm:"type:varchar(200);not null" json:"title"`
+	Content        string     `gorm:"type:longtext" json:"content"`
+	Duration       int        `gorm:"default:0" json:"duration"`
+	AuthorID       uint       `gorm:"not null" json:"author_id"`
+	RestoredFromID *uint      `json:"restored_from_id"` // Set when created by a rollback
+	PublishedAt    *time.Time `json:"published_at"`     // nil = draft that was never published
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Lesson type and typed payload (see Lesson). Empty Type on revisions saved before
+	// typed payloads were versioned: publishing those keeps the lesson's type.
+	Type               string         `gorm:"type:varchar(20)" json:"type"`
+	VideoURL           string         `gorm:"type:varchar(500)" json:"video_url,omitempty"`
+	VideoDuration      int            `gorm:"default:0" json:"video_duration,omitempty"`
+	CaptionTracks      []CaptionTrack `gorm:"serializer:json;type:json" json:"caption_tracks,omitempty"`
+	QuizID             *uint          `json:"quiz_id,omitempty"`
+	AssignmentBrief    string         `gorm:"type:text" json:"assignment_brief,omitempty"`
+	AssignmentMaxScore int            `gorm:"default:0" json:"assignment_max_score,omitempty"`
+}
+
+// SetPayload snapshots the lesson's type and typed payload into the revision
+func (r *LessonRevision) SetPayload(l *Lesson) {
+	r.Type = l.Type
+	r.VideoURL = l.VideoURL
+	r.VideoDuration = l.VideoDuration
+	r.CaptionTracks = l.CaptionTracks
+	r.QuizID = l.QuizID
+	r.AssignmentBrief = l.AssignmentBrief
+	r.AssignmentMaxScore = l.AssignmentMaxScore
+}
+
+// applyPayload copies the revision's type and typed payload onto the lesson
+func (r *LessonRevision) applyPayload(l *Lesson) {
+	if r.Type == "" {
+		return
+	}
+	l.Type = r.Type
+	l.VideoURL = r.VideoURL
+	l.VideoDuration = r.VideoDuration
+	l.CaptionTracks = r.CaptionTracks
+	l.QuizID = r.QuizID
+	l.AssignmentBrief = r.AssignmentBrief
+	l.AssignmentMaxScore = r.AssignmentMaxScore
+}
+
+// LessonAttachment is a downloadable resource on a lesson (slides, starter code). The file is
+// private in storage and only handed out as a short-lived signed link after an access check.
+type LessonAttachment struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	LessonID   uint           `gorm:"not null;index" json:"lesson_id"`
+	CourseID   uint           `gorm:"not null;index" json:"course_id"`
+	Title      string         `gorm:"type:varchar(200);not null" json:"title"`
+	FileName   string         `gorm:"type:varchar(255);not null" json:"file_name"` // Original name, used for the download
+	FilePath   string         `gorm:"type:varchar(500);not null" json:"-"`         // Storage object path (shared by duplicated courses)
+	FileSize   int64          `gorm:"not null;default:0" json:"file_size"`
+	MimeType   string         `gorm:"type:varchar(100)" json:"mime_type"`
+	OrderIndex int            `gorm:"not null;default:0" json:"order_index"`
+	UploadedBy uint           `gorm:"not null" json:"uploaded_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// CoursePrerequisite requires PrerequisiteCourseID to be completed before enrolling in CourseID
+type CoursePrerequisite struct {
+	ID                   uint      `gorm:"primaryKey" json:"id"`
+	CourseID             uint      `gorm:"not null;uniqueIndex:idx_course_prerequisites_pair" json:"course_id"`
+	PrerequisiteCourseID uint      `gorm:"not null;uniqueIndex:idx_course_prerequisites_pair;index" json:"prerequisite_course_id"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// Course statuses. Only published courses are listed in the public catalog and open for enrollment.
+const (
+	CourseStatusDraft            = "draft"
+	CourseStatusSubmitted        = "submitted"         // Waiting for admin review
+	CourseStatusChangesRequested = "changes_requested" // Reviewer sent it back with comments
+	CourseStatusPublished        = "published"
+	CourseStatusArchived         = "archived" // Taken off the catalog; enrolled students keep access
+)
+
+// Notification types sent to the instructor on review decisions
+const (
+	NotificationCourseApproved         = "course_approved"
+	NotificationCourseChangesRequested = "course_changes_requested"
+	NotificationCourseArchived         = "course_archived"
+)
+
+// Notification types sent to students
+const (
+	NotificationWaitlistPromoted = "waitlist_promoted" // A seat opened up and the student was enrolled
+)
+
+// courseTransitions lists the status changes of the review workflow
+var courseTransitions = map[string][]string{
+	CourseStatusDraft:            {CourseStatusSubmitted},
+	CourseStatusSubmitted:        {CourseStatusDraft, CourseStatusChangesRequested, CourseStatusPublished},
+	CourseStatusChangesRequested: {CourseStatusSubmitted},
+	CourseStatusPublished:        {CourseStatusArchived},
+	CourseStatusArchived:         {CourseStatusDraft},
+}
+
+// CanTransition reports whether a course may move from one status to another.
+// Admins review courses, so they may also publish a course that was never submitted.
+func CanTransition(from, to string, isAdmin bool) bool {
+	if isAdmin && to == CourseStatusPublished && from != CourseStatusPublished {
+		return true
+	}
+	for _, next := range courseTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CourseStatusEvent records a status change with the reviewer's or instructor's comment
+type CourseStatusEvent struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	CourseID   uint      `gorm:"not null;index" json:"course_id"`
+	FromStatus string    `gorm:"type:varchar(20);not null" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	ActorID    uint      `gorm:"not null" json:"actor_id"`
+	Comment    string    `gorm:"type:text" json:"comment"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relations
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+}
+
+// CourseSlugHistory keeps the previous slugs of a course so old links resolve to it
+type CourseSlugHistory struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CourseID  uint      `gorm:"not null;index" json:"course_id"`
+	Slug      string    `gorm:"type:varchar(250);uniqueIndex;not null" json:"slug"`
+	CreatedAt time.Time `json:"created_at"` // When the course stopped using the slug
+}
+
+// Tag is a free-form course label (e.g. "golang", "docker"). Names are stored
+// normalized and tags are identified by slug, so "Go Lang" and "go-lang" are one tag.
+type Tag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(30);uniqueIndex;not null" json:"name"`
+	Slug      string    `gorm:"type:varchar(40);uniqueIndex;not null" json:"slug"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// CourseTag attaches a tag to a course
+type CourseTag struct {
+	CourseID uint `gorm:"primaryKey" json:"course_id"`
+	TagID    uint `gorm:"primaryKey;index" json:"tag_id"`
+}
+
+// TagWithCount is a tag with the number of published courses using it
+type TagWithCount struct {
+	Tag
+	CourseCount int `gorm:"column:course_count" json:"course_count"`
+}
+
+// Tag filter modes (CourseListQuery.TagMatch)
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// Collaborator roles
+const (
+	CollaboratorRoleCoInstructor = "co_instructor" // Full course management, may receive a revenue share
+	CollaboratorRoleEditor       = "editor"        // Lessons and sections only
+	CollaboratorRoleTA           = "ta"            // Grading, students and session participants only
+)
+
+// Course permissions granted to collaborators (the owner and admins hold all of them)
+const (
+	PermissionManageCourse = "manage_course" // Course settings, prerequisites, live sessions
+	PermissionEditContent  = "edit_content"  // Lessons, sections, revisions, quizzes
+	PermissionTeach        = "teach"         // Grading, student lists, session participants
+)
+
+var collaboratorPermissions = map[string][]string{
+	CollaboratorRoleCoInstructor: {PermissionManageCourse, PermissionEditContent, PermissionTeach},
+	CollaboratorRoleEditor:       {PermissionEditContent},
+	CollaboratorRoleTA:           {PermissionTeach},
+}
+
+// RoleAllows reports whether a collaborator role grants the permission
+func RoleAllows(role, permission string) bool {
+	for _, granted := range collaboratorPermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// CourseCollaborator gives another user a role on a course besides its owner (InstructorID)
+type CourseCollaborator struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	CourseID     uint      `gorm:"not null;uniqueIndex:idx_course_collaborators_pair" json:"course_id"`
+	UserID       uint      `gorm:"not null;uniqueIndex:idx_course_collaborators_pair;index" json:"user_id"`
+	Role         string    `gorm:"type:varchar(20);not null" json:"role"`   // co_instructor, editor, ta
+	RevenueShare int       `gorm:"not null;default:0" json:"revenue_share"` // percent of the instructor share, co-instructors only
+	AddedBy      uint      `gorm:"not null" json:"added_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Relations
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// RevenueShare is the percentage of a course's instructor earnings paid to one user
+type RevenueShare struct {
+	UserID  uint
+	Percent int
+}
+
+// SplitRevenue lists who earns from a sale of the course: co-instructors get their
+// configured share and the instructor (owner) keeps the remainder.
+func SplitRevenue(ownerID uint, collaborators []*CourseCollaborator) []RevenueShare {
+	remaining := 100
+	shares := make([]RevenueShare, 0, len(collaborators)+1)
+	for _, collaborator := range collaborators {
+		if collaborator.Role != CollaboratorRoleCoInstructor || collaborator.RevenueShare <= 0 {
+			continue
+		}
+		percent := collaborator.RevenueShare
+		if percent > remaining {
+			percent = remaining
+		}
+		remaining -= percent
+		shares = append(shares, RevenueShare{UserID: collaborator.UserID, Percent: percent})
+	}
+	if remaining > 0 {
+		shares = append([]RevenueShare{{UserID: ownerID, Percent: remaining}}, shares...)
+	}
+	return shares
 }
 
 // Enrollment represents a student's enrollment in a course
@@ -63,62 +413,191 @@ type Enrollment struct {
 	CourseID   uint           `gorm:"not null;index" json:"course_id"`
 	Progress   int            `gorm:"default:0" json:"progress"` // percentage 0-100
 	EnrolledAt time.Time      `json:"enrolled_at"`
+	ExpiresAt  *time.Time     `gorm:"index" json:"expires_at"` // nil = lifetime access
 	UpdatedAt  time.Time      `json:"updated_at"`
 	DeletedAt  gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
+// WaitlistEntry queues a student for a seat in a full course. Entries are promoted
+// in ID (join) order when a seat opens up, buyers whose payment settled while the course
+// was full (PaymentID set) first.
+type WaitlistEntry struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CourseID  uint      `gorm:"not null;uniqueIndex:idx_course_waitlist_course_user" json:"course_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_course_waitlist_course_user;index" json:"user_id"`
+	PaymentID *uint     `gorm:"index" json:"payment_id,omitempty"` // Paid, waiting for a seat (flagged for refund meanwhile)
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsFull reports whether a seat-capped course has no seats left. Lapsed enrollments
+// keep their seat until the student unenrolls.
+func (c *Course) IsFull() bool {
+	return c.MaxEnrollments > 0 && c.EnrolledCount >= c.MaxEnrollments
+}
+
+// IsActive reports whether the enrollment still grants access to the course
+func (e *Enrollment) IsActive(now time.Time) bool {
+	return e.ExpiresAt == nil || e.ExpiresAt.After(now)
+}
+
+// AccessExpiry is the end of an enrollment starting at start for a course with the given
+// access duration (nil: lifetime access)
+func AccessExpiry(start time.Time, days int) *time.Time {
+	if days <= 0 {
+		return nil
+	}
+	expiresAt := start.AddDate(0, 0, days)
+	return &expiresAt
+}
+
+// RenewedExpiry extends an enrollment by days: from its current end while it is still
+// running, from now once it has lapsed. Lifetime enrollments (nil) stay lifetime, and
+// renewing for a course without an access duration grants lifetime access.
+func RenewedExpiry(current *time.Time, now time.Time, days int) *time.Time {
+	if current == nil || days <= 0 {
+		return nil
+	}
+	if current.After(now) {
+		return AccessExpiry(*current, days)
+	}
+	return AccessExpiry(now, days)
+}
+
 // TableName specifies the table name for Course model
 func (Course) TableName() string {
 	return "courses"
 }
 
+// TableName specifies the table name for Section model
+func (Section) TableName() string {
+	return "course_sections"
+}
+
 // TableName specifies the table name for Lesson model
 func (Lesson) TableName() string {
 	return "lessons"
 }
 
+// TableName specifies the table name for LessonRevision model
+func (LessonRevision) TableName() string {
+	return "lesson_revisions"
+}
+
+// TableName specifies the table name for LessonAttachment model
+func (LessonAttachment) TableName() string {
+	return "lesson_attachments"
+}
+
+// TableName specifies the table name for CoursePrerequisite model
+func (CoursePrerequisite) TableName() string {
+	return "course_prerequisites"
+}
+
+// TableName specifies the table name for CourseStatusEvent model
+func (CourseStatusEvent) TableName() string {
+	return "course_status_events"
+}
+
+// TableName specifies the table name for CourseSlugHistory model
+func (CourseSlugHistory) TableName() string {
+	return "course_slug_history"
+}
+
+// TableName specifies the table name for Tag model
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// TableName specifies the table name for CourseTag model
+func (CourseTag) TableName() string {
+	return "course_tags"
+}
+
+// TableName specifies the table name for CourseCollaborator model
+func (CourseCollaborator) TableName() string {
+	return "course_collaborators"
+}
+
 // TableName specifies the table name for Enrollment model
 func (Enrollment) TableName() string {
 	return "enrollments"
 }
 
+// TableName specifies the table name for WaitlistEntry model
+func (WaitlistEntry) TableName() string {
+	return "course_waitlist"
+}
+
 // CourseResponse is the sanitized course data for API responses
 type CourseResponse struct {
-	ID            uint      `json:"id"`
-	Title         string    `json:"title"`
-	Slug          string    `json:"slug"`
-	Description   string    `json:"description"`
-	ThumbnailURL  string    `json:"thumbnail_url"`
-	Category      string    `json:"category"`
-	Difficulty    string    `json:"difficulty"`
-	InstructorID  uint      `json:"instructor_id"`
-	Price         int       `json:"price"`
-	IsPublished   bool      `json:"is_published"`
-	EnrolledCount int       `json:"enrolled_count"`
-	LessonCount   int       `json:"lesson_count"`
-	IsEnrolled    bool      `json:"is_enrolled"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID             uint      `json:"id"`
+	Title          string    `json:"title"`
+	Slug           string    `json:"slug"`
+	Description    string    `json:"description"`
+	ThumbnailURL   string    `json:"thumbnail_url"`
+	Category       string    `json:"category"`
+	Difficulty     string    `json:"difficulty"`
+	InstructorID   uint      `json:"instructor_id"`
+	Price          int       `json:"price"`
+	AccessDays     int       `json:"access_duration_days"`
+	MaxEnrollments int       `json:"max_enrollments"` // 0 = unlimited
+	IsPublished    bool      `json:"is_published"`
+	Status         string    `json:"status"`
+	EnrolledCount  int       `json:"enrolled_count"`
+	AverageRating  float64   `json:"average_rating"`
+	ReviewCount    int       `json:"review_count"`
+	LessonCount    int       `json:"lesson_count"`
+	IsEnrolled     bool      `json:"is_enrolled"`
+	Tags           []Tag     `json:"tags"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// Detail view only
+	Prerequisites    []*PrerequisiteResponse `json:"prerequisites,omitempty"`
+	AccessExpiresAt  *time.Time              `json:"access_expires_at,omitempty"` // Viewer's enrollment end (nil: lifetime or not enrolled)
+	AccessExpired    bool                    `json:"access_expired,omitempty"`    // Viewer's enrollment has lapsed: renew to regain access
+	WaitlistPosition int                     `json:"waitlist_position,omitempty"` // Viewer's place on the waitlist of a full course
+
+	// Search results only
+	Snippets []*SearchSnippet `json:"snippets,omitempty"`
+
+	// Set when the course was requested by an old slug; clients should redirect to it
+	CanonicalSlug string `json:"canonical_slug,omitempty"`
+}
+
+// SearchSnippet shows where a search matched a course. Text is HTML-escaped
+// with the matched terms wrapped in <mark>.
+type SearchSnippet struct {
+	Field       string `json:"field"` // title, description, lesson
+	LessonID    *uint  `json:"lesson_id,omitempty"`
+	LessonTitle string `json:"lesson_title,omitempty"`
+	Text        string `json:"text"`
 }
 
 // ToResponse converts Course to CourseResponse
 func (c *Course) ToResponse(lessonCount int, isEnrolled bool) *CourseResponse {
 	return &CourseResponse{
-		ID:            c.ID,
-		Title:         c.Title,
-		Slug:          c.Slug,
-		Description:   c.Description,
-		ThumbnailURL:  c.ThumbnailURL,
-		Category:      c.Category,
-		Difficulty:    c.Difficulty,
-		InstructorID:  c.InstructorID,
-		Price:         c.Price,
-		IsPublished:   c.IsPublished,
-		EnrolledCount: c.EnrolledCount,
-		LessonCount:   lessonCount,
-		IsEnrolled:    isEnrolled,
-		CreatedAt:     c.CreatedAt,
-		UpdatedAt:     c.UpdatedAt,
+		ID:             c.ID,
+		Title:          c.Title,
+		Slug:           c.Slug,
+		Description:    c.Description,
+		ThumbnailURL:   c.ThumbnailURL,
+		Category:       c.Category,
+		Difficulty:     c.Difficulty,
+		InstructorID:   c.InstructorID,
+		Price:          c.Price,
+		AccessDays:     c.AccessDays,
+		MaxEnrollments: c.MaxEnrollments,
+		IsPublished:    c.IsPublished,
+		Status:         c.Status,
+		EnrolledCount:  c.EnrolledCount,
+		AverageRating:  c.AverageRating,
+		ReviewCount:    c.ReviewCount,
+		LessonCount:    lessonCount,
+		IsEnrolled:     isEnrolled,
+		Tags:           c.Tags,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
 	}
 }
 
@@ -126,13 +605,33 @@ func (c *Course) ToResponse(lessonCount int, isEnrolled bool) *CourseResponse {
 type LessonResponse struct {
 	ID          uint      `json:"id"`
 	CourseID    uint      `json:"course_id"`
+	SectionID   *uint     `json:"section_id"`
 	Title       string    `json:"title"`
 	Slug        string    `json:"slug"`
+	Type        string    `json:"type"`
 	Content     string    `json:"content,omitempty"` // Only include in detail view
 	OrderIndex  int       `json:"order_index"`
 	Duration    int       `json:"duration"`
 	IsPublished bool      `json:"is_published"`
+	IsPreview   bool      `json:"is_preview"` // Full content is available without enrollment
 	CreatedAt   time.Time `json:"created_at"`
+
+	// Typed payload matching Type, only included in detail view
+	Video      *VideoPayload      `json:"video,omitempty"`
+	Quiz       *QuizPayload       `json:"quiz,omitempty"`
+	Assignment *AssignmentPayload `json:"assignment,omitempty"`
+
+	// Downloadable resources, detail view only (files are fetched through the download endpoint)
+	Attachments []*AttachmentResponse `json:"attachments,omitempty"`
+
+	PublishedRevisionID *uint                   `json:"published_revision_id,omitempty"`
+	Draft               *LessonRevisionResponse `json:"draft,omitempty"` // Only for the course instructor
+
+	// Drip release: locked lessons are returned without content or payload
+	Release             *ReleasePayload `json:"release,omitempty"` // Rule configuration, detail view only
+	IsLocked            bool            `json:"is_locked"`
+	AvailableAt         *time.Time      `json:"available_at,omitempty"`           // When a time-based rule releases the lesson
+	UnlockAfterLessonID *uint           `json:"unlock_after_lesson_id,omitempty"` // Lesson to complete first
 }
 
 // ToResponse converts Lesson to LessonResponse
@@ -140,27 +639,212 @@ func (l *Lesson) ToResponse(includeContent bool) *LessonResponse {
 	resp := &LessonResponse{
 		ID:          l.ID,
 		CourseID:    l.CourseID,
+		SectionID:   l.SectionID,
 		Title:       l.Title,
 		Slug:        l.Slug,
+		Type:        l.Type,
 		OrderIndex:  l.OrderIndex,
 		Duration:    l.Duration,
 		IsPublished: l.IsPublished,
+		IsPreview:   l.IsPreview,
 		CreatedAt:   l.CreatedAt,
 	}
 	
 	if includeContent {
 		resp.Content = l.Content
+		resp.PublishedRevisionID = l.PublishedRevisionID
+
+		switch l.Type {
+		case LessonTypeVideo:
+			resp.Video = &VideoPayload{
+				URL:             l.VideoURL,
+				DurationSeconds: l.VideoDuration,
+				Captions:        l.CaptionTracks,
+			}
+		case LessonTypeQuiz:
+			if l.QuizID != nil {
+				resp.Quiz = &QuizPayload{QuizID: *l.QuizID}
+			}
+		case LessonTypeAssignment:
+			resp.Assignment = &AssignmentPayload{
+				Brief:    l.AssignmentBrief,
+				MaxScore: l.AssignmentMaxScore,
+			}
+		}
+
+		if l.HasReleaseRule() {
+			resp.Release = &ReleasePayload{
+				Rule:          l.ReleaseRule,
+				At:            l.ReleaseAt,
+				AfterDays:     l.ReleaseAfterDays,
+				AfterLessonID: l.ReleaseAfterLessonID,
+			}
+		}
 	}
 	
 	return resp
 }
 
+// lock marks the response as not yet released for the student
+func (r *LessonResponse) lock(lesson *Lesson, availableAt *time.Time) {
+	r.IsLocked = true
+	r.AvailableAt = availableAt
+	if lesson.ReleaseRule == ReleaseRuleAfterLesson {
+		r.UnlockAfterLessonID = lesson.ReleaseAfterLessonID
+	}
+}
+
+// LessonAccess is what a user may see of a lesson (see Service.CheckLessonAccess)
+type LessonAccess struct {
+	Lesson      *Lesson
+	Course      *Course
+	IsStaff     bool       // Instructor or collaborator
+	IsEnrolled  bool       // Active (not expired) enrollment
+	Available   bool       // Released to the user (drip release); always true for staff
+	AvailableAt *time.Time // When a time-based rule releases a locked lesson
+}
+
+// CanViewContent reports whether the lesson content is shown: to the course team, to
+// enrolled students once the lesson is released, and to anyone on published previews of
+// courses in the catalog
+func (a *LessonAccess) CanViewContent() bool {
+	if a.IsStaff {
+		return true
+	}
+	if a.IsEnrolled {
+		return a.Available
+	}
+	return a.Lesson.IsPreview && a.Lesson.IsPublished && a.Course.Status == CourseStatusPublished
+}
+
+// IsParticipant reports whether the user takes part in the lesson as a member of the
+// course: the course team, or an enrolled student the lesson is released to
+func (a *LessonAccess) IsParticipant() bool {
+	return a.IsStaff || (a.IsEnrolled && a.Available)
+}
+
+// LessonRevisionResponse is the API representation of a lesson revision
+type LessonRevisionResponse struct {
+	ID             uint       `json:"id"`
+	LessonID       uint       `json:"lesson_id"`
+	Version        int        `json:"version"`
+	Title          string     `json:"title"`
+	Content        string     `json:"content,omitempty"` // Only include in detail view
+	Duration       int        `json:"duration"`
+	Type           string     `json:"type,omitempty"` // Empty on revisions from before typed payloads were versioned
+	AuthorID       uint       `json:"author_id"`
+	RestoredFromID *uint      `json:"restored_from_id,omitempty"`
+	Status         string     `json:"status"` // draft, published, superseded
+	PublishedAt    *time.Time `json:"published_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Typed payload, detail view only (see LessonResponse)
+	Video      *VideoPayload      `json:"video,omitempty"`
+	Quiz       *QuizPayload       `json:"quiz,omitempty"`
+	Assignment *AssignmentPayload `json:"assignment,omitempty"`
+}
+
+// AttachmentResponse is the API representation of a lesson attachment
+type AttachmentResponse struct {
+	ID        uint      `json:"id"`
+	LessonID  uint      `json:"lesson_id"`
+	Title     string    `json:"title"`
+	FileName  string    `json:"file_name"`
+	FileSize  int64     `json:"file_size"`
+	MimeType  string    `json:"mime_type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToResponse converts LessonAttachment to AttachmentResponse
+func (a *LessonAttachment) ToResponse() *AttachmentResponse {
+	return &AttachmentResponse{
+		ID:        a.ID,
+		LessonID:  a.LessonID,
+		Title:     a.Title,
+		FileName:  a.FileName,
+		FileSize:  a.FileSize,
+		MimeType:  a.MimeType,
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+// Revision statuses relative to the owning lesson
+const (
+	RevisionStatusDraft      = "draft"
+	RevisionStatusPublished  = "published"
+	RevisionStatusSuperseded = "superseded"
+)
+
+// ToResponse converts LessonRevision to LessonRevisionResponse.
+// The status depends on the lesson's current revision pointers.
+func (r *LessonRevision) ToResponse(lesson *Lesson, includeContent bool) *LessonRevisionResponse {
+	resp := &LessonRevisionResponse{
+		ID:             r.ID,
+		LessonID:       r.LessonID,
+		Version:        r.Version,
+		Title:          r.Title,
+		Duration:       r.Duration,
+		Type:           r.Type,
+		AuthorID:       r.AuthorID,
+		RestoredFromID: r.RestoredFromID,
+		Status:         RevisionStatusSuperseded,
+		PublishedAt:    r.PublishedAt,
+		CreatedAt:      r.CreatedAt,
+	}
+
+	switch {
+	case lesson.PublishedRevisionID != nil && *lesson.PublishedRevisionID == r.ID:
+		resp.Status = RevisionStatusPublished
+	case lesson.DraftRevisionID != nil && *lesson.DraftRevisionID == r.ID:
+		resp.Status = RevisionStatusDraft
+	}
+
+	if includeContent {
+		resp.Content = r.Content
+		if r.Type != "" {
+			typed := &Lesson{}
+			r.applyPayload(typed)
+			typedResp := typed.ToResponse(true)
+			resp.Video, resp.Quiz, resp.Assignment = typedResp.Video, typedResp.Quiz, typedResp.Assignment
+		}
+	}
+
+	return resp
+}
+
+// SectionResponse is a section with its lessons and completion counts
+type SectionResponse struct {
+	ID             uint              `json:"id"`
+	CourseID       uint              `json:"course_id"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	OrderIndex     int               `json:"order_index"`
+	LessonCount    int               `json:"lesson_count"`
+	CompletedCount int               `json:"completed_count"` // Always 0 for anonymous users
+	Lessons        []*LessonResponse `json:"lessons"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+// ToResponse converts Section to SectionResponse (lessons are filled by the service)
+func (s *Section) ToResponse() *SectionResponse {
+	return &SectionResponse{
+		ID:          s.ID,
+		CourseID:    s.CourseID,
+		Title:       s.Title,
+		Description: s.Description,
+		OrderIndex:  s.OrderIndex,
+		Lessons:     []*LessonResponse{},
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
 // CourseWithMeta extends Course with metadata for batch queries
 // This helps solve N+1 query problem by including counts in a single query
 type CourseWithMeta struct {
 	Course
-	LessonCount int  `gorm:"column:lesson_count" json:"-"`
-	IsEnrolled  bool `gorm:"column:is_enrolled" json:"-"`
+	LessonCount int     `gorm:"column:lesson_count" json:"-"`
+	IsEnrolled  bool    `gorm:"column:is_enrolled" json:"-"`
+	Relevance   float64 `gorm:"column:relevance" json:"-"` // Search score (sort_by=relevance)
 }
 
 // ToResponse converts CourseWithMeta to CourseResponse
@@ -3,11 +3,14 @@ package course
 import (
 	"context"
 	"errors"
-	"strings"
+	"time"
 
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository interface {
@@ -15,10 +18,20 @@ type Repository interface {
 	CreateCourse(ctx context.Context, course *Course) error
 	FindCourseByID(ctx context.Context, id uint) (*Course, error)
 	FindCourseBySlug(ctx context.Context, slug string) (*Course, error)
+	FindCourseBySlugHistory(ctx context.Context, slug string) (*Course, error)
 	FindAllCourses(ctx context.Context, query *CourseListQuery) ([]*Course, int, error)
-	FindAllCoursesWithMeta(ctx context.Context, userID uint, query *CourseListQuery) ([]*CourseWithMeta, int, error)
+	FindAllCoursesWithMeta(ctx context.Context, userID uint, query *CourseListQuery) ([]*CourseWithMeta, int, string, error)
+	FindSearchMatchedLessons(ctx context.Context, courseIDs []uint, booleanQuery string) ([]*Lesson, error)
+	FindCourseFacetCounts(ctx context.Context, query *CourseListQuery) (*CourseFacetCounts, error)
+	CategoryExists(ctx context.Context, name string) (bool, error)
+	FindCategoryNames(ctx context.Context) ([]string, error)
 	UpdateCourse(ctx context.Context, course *Course) error
+	UpdateCourseStatus(ctx context.Context, course *Course, event *CourseStatusEvent) error
+	BackfillCourseStatus(ctx context.Context) error
+	FindCourseStatusEvents(ctx context.Context, courseID uint) ([]*CourseStatusEvent, error)
 	DeleteCourse(ctx context.Context, id uint) error
+	SlugExists(ctx context.Context, slug string, courseID uint) (bool, error)
+	DuplicateCourse(ctx context.Context, sourceID uint, target *Course, authorID uint) error
 	IncrementEnrolledCount(ctx context.Context, courseID uint) error
 	DecrementEnrolledCount(ctx context.Context, courseID uint) error
 
@@ -31,12 +44,70 @@ type Repository interface {
 	CountLessonsByCourseID(ctx context.Context, courseID uint) (int, error)
 	BatchUpdateLessonOrder(ctx context.Context, updates []LessonOrderUpdate) error
 
+	// Lesson revision operations
+	CreateLessonWithRevision(ctx context.Context, lesson *Lesson, revision *LessonRevision) error
+	SaveLessonRevision(ctx context.Context, lesson *Lesson, revision *LessonRevision) error
+	PublishLessonRevision(ctx context.Context, lesson *Lesson, revision *LessonRevision) error
+	FindLessonRevisionByID(ctx context.Context, id uint) (*LessonRevision, error)
+	FindLessonRevisions(ctx context.Context, lessonID uint) ([]*LessonRevision, error)
+
+	// Lesson attachment operations (files are stored by the upload module)
+	CreateLessonAttachment(ctx context.Context, attachment *LessonAttachment) error
+	FindLessonAttachmentByID(ctx context.Context, id uint) (*LessonAttachment, error)
+	FindLessonAttachments(ctx context.Context, lessonID uint) ([]*LessonAttachment, error)
+	DeleteLessonAttachment(ctx context.Context, id uint) error
+
+	// Section operations
+	CreateSection(ctx context.Context, section *Section) error
+	FindSectionByID(ctx context.Context, id uint) (*Section, error)
+	FindSectionsByCourseID(ctx context.Context, courseID uint) ([]*Section, error)
+	UpdateSection(ctx context.Context, section *Section) error
+	DeleteSection(ctx context.Context, id uint) error
+	BatchUpdateSectionOrder(ctx context.Context, courseID uint, updates []SectionOrderUpdate) error
+
+	// Progress lookups (lesson_progress is owned by the progress module)
+	FindCompletedLessonIDs(ctx context.Context, userID, courseID uint) ([]uint, error)
+
+	// Quiz lookups (quizzes are owned by the quiz module)
+	QuizBelongsToCourse(ctx context.Context, quizID, courseID uint) (bool, error)
+
+	// Prerequisite operations
+	FindPrerequisiteCourses(ctx context.Context, courseID uint) ([]*Course, error)
+	FindPrerequisiteIDs(ctx context.Context, courseID uint) ([]uint, error)
+	ReplacePrerequisites(ctx context.Context, courseID uint, prerequisiteIDs []uint) error
+
+	// Tag operations
+	ReplaceCourseTags(ctx context.Context, courseID uint, tags []*Tag) error
+	FindTagsByCourseIDs(ctx context.Context, courseIDs []uint) (map[uint][]Tag, error)
+	FindTagBySlug(ctx context.Context, slug string) (*Tag, error)
+	FindTagsBySlugs(ctx context.Context, slugs []string) ([]*Tag, error)
+	FindPopularTags(ctx context.Context, limit int) ([]*TagWithCount, error)
+	FindRelatedTags(ctx context.Context, tagID uint, limit int) ([]*TagWithCount, error)
+
+	// Collaborator operations
+	FindCollaborators(ctx context.Context, courseID uint) ([]*CourseCollaborator, error)
+	FindCollaborator(ctx context.Context, courseID, userID uint) (*CourseCollaborator, error)
+	CreateCollaborator(ctx context.Context, collaborator *CourseCollaborator) error
+	UpdateCollaborator(ctx context.Context, collaborator *CourseCollaborator) error
+	DeleteCollaborator(ctx context.Context, courseID, userID uint) error
+	FindUserByID(ctx context.Context, id uint) (*User, error)
+	FindUserByEmail(ctx context.Context, email string) (*User, error)
+
 	// Enrollment operations
 	CreateEnrollment(ctx context.Context, enrollment *Enrollment) error
-	FindEnrollment(ctx context.Context, userID, courseID uint) (*Enrollment, error)
+	FindEnrollment(ctx context.Context, userID, courseID uint) (*Enrollment, error) // Includes lapsed enrollments
+	UpdateEnrollmentExpiry(ctx context.Context, enrollmentID uint, expiresAt *time.Time) error
 	DeleteEnrollment(ctx context.Context, userID, courseID uint) error
-	IsUserEnrolled(ctx context.Context, userID, courseID uint) (bool, error)
+	IsUserEnrolled(ctx context.Context, userID, courseID uint) (bool, error) // Active (not expired) enrollments only
 	GetUserEnrollments(ctx context.Context, userID uint) ([]*Enrollment, error) // New method
+
+	// Capacity and waitlist operations
+	EnrollWithinCapacity(ctx context.Context, enrollment *Enrollment) (bool, error) // false: the course is full
+	AddToWaitlist(ctx context.Context, entry *WaitlistEntry) error
+	FindWaitlistPosition(ctx context.Context, courseID, userID uint) (int, error) // 0: not on the waitlist
+	RemoveFromWaitlist(ctx context.Context, courseID, userID uint) (bool, error)
+	NextWaitlistEntry(ctx context.Context, courseID uint, skipUserIDs []uint) (*WaitlistEntry, error) // nil: nobody (else) waiting
+	PromoteFromWaitlist(ctx context.Context, course *Course, userID uint, now time.Time) (*Enrollment, error) // nil: no free seat
 }
 
 type repository struct {
@@ -49,8 +120,18 @@ func NewRepository(db *gorm.DB) Repository {
 
 // Course operations
 
+// IsDuplicateKey reports whether err is a MySQL unique index violation
+func IsDuplicateKey(err error) bool {
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
+
+// CreateCourse inserts the course; ErrSlugTaken means a concurrent save took its slug
 func (r *repository) CreateCourse(ctx context.Context, course *Course) error {
 	err := r.db.WithContext(ctx).Create(course).Error
+	if IsDuplicateKey(err) {
+		return ErrSlugTaken
+	}
 	if err != nil {
 		logger.Error("Failed to create course in database",
 			zap.Error(err),
@@ -94,6 +175,15 @@ func (r *repository) FindCourseBySlug(ctx context.Context, slug string) (*Course
 	return &course, nil
 }
 
+// FindCourseBySlugHistory finds a course by one of its previous slugs
+func (r *repository) FindCourseBySlugHistory(ctx context.Context, slug string) (*Course, error) {
+	var history CourseSlugHistory
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&history).Error; err != nil {
+		return nil, err
+	}
+	return r.FindCourseByID(ctx, history.CourseID)
+}
+
 func (r *repository) FindAllCourses(ctx context.Context, query *CourseListQuery) ([]*Course, int, error) {
 	var courses []*Course
 	var total int64
@@ -101,9 +191,8 @@ func (r *repository) FindAllCourses(ctx context.Context, query *CourseListQuery)
 	db := r.db.WithContext(ctx).Model(&Course{})
 
 	// Apply filters
-	if query.Search != "" {
-		searchTerm := "%" + strings.ToLower(query.Search) + "%"
-		db = db.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", searchTerm, searchTerm)
+	if search := parseSearch(query.Search); search != nil {
+		db = search.filter(db)
 	}
 
 	if query.Category != "" {
@@ -115,7 +204,11 @@ func (r *repository) FindAllCourses(ctx context.Context, query *CourseListQuery)
 	}
 
 	if query.Published != nil {
-		db = db.Where("is_published = ?", *query.Published)
+		if *query.Published {
+			db = db.Where("status = ?", CourseStatusPublished)
+		} else {
+			db = db.Where("status <> ?", CourseStatusPublished)
+		}
 	}
 
 	// Count total
@@ -153,48 +246,28 @@ func (r *repository) FindAllCourses(ctx context.Context, query *CourseListQuery)
 }
 
 // FindAllCoursesWithMeta optimized version that fetches courses with metadata in 1-2 queries
-// Solves N+1 query problem by using JOINs and subqueries
-func (r *repository) FindAllCoursesWithMeta(ctx context.Context, userID uint, query *CourseListQuery) ([]*CourseWithMeta, int, error) {
+// Solves N+1 query problem by using JOINs and subqueries.
+// With query.Cursor the page is read by keyset (no OFFSET, no COUNT: total is 0); the
+// returned cursor points at the next page either way ("" on the last page).
+func (r *repository) FindAllCoursesWithMeta(ctx context.Context, userID uint, query *CourseListQuery) ([]*CourseWithMeta, int, string, error) {
 	var coursesWithMeta []*CourseWithMeta
 	var total int64
 
-	db := r.db.WithContext(ctx).Model(&Course{})
-
-	// Apply filters (same as FindAllCourses)
-	if query.Search != "" {
-		searchTerm := "%" + strings.ToLower(query.Search) + "%"
-		db = db.Where("LOWER(title) LIKE ? OR LOWER(description) LIKE ?", searchTerm, searchTerm)
-	}
-
-	if query.Category != "" {
-		db = db.Where("category = ?", query.Category)
-	}
-
-	if query.Difficulty != "" {
-		db = db.Where("difficulty = ?", query.Difficulty)
-	}
-
-	if query.Published != nil {
-		db = db.Where("is_published = ?", *query.Published)
-	}
-
-	// Add new filters to count query
-	if query.MinPrice > 0 {
-		db = db.Where("price >= ?", query.MinPrice)
-	}
-
-	if query.MaxPrice > 0 {
-		db = db.Where("price <= ?", query.MaxPrice)
-	}
-
-	// Filter by instructor (support pointer for optional filtering)
-	if query.InstructorID != nil && *query.InstructorID > 0 {
-		db = db.Where("instructor_id = ?", *query.InstructorID)
-	}
+	search := parseSearch(query.Search)
+	keys, keyValues := courseSortKeys(query, search.fulltext())
 
-	// Count total
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, err
+	var after []interface{}
+	if query.Cursor != "" {
+		var err error
+		if after, err = cursor.Decode(query.Cursor, len(keys)); err != nil {
+			return nil, 0, "", err
+		}
+	} else {
+		// Count total (same filters as FindAllCourses)
+		db := applyCourseFilters(r.db.WithContext(ctx).Table("courses"), query, search, "")
+		if err := db.Count(&total).Error; err != nil {
+			return nil, 0, "", err
+		}
 	}
 
 	// Apply pagination
@@ -207,6 +280,9 @@ func (r *repository) FindAllCoursesWithMeta(ctx context.Context, userID uint, qu
 		limit = 10
 	}
 	offset := (page - 1) * limit
+	if after != nil {
+		offset = 0
+	}
 
 	// Build optimized query with LEFT JOINs
 	// This reduces N+1 queries to just 1 query
@@ -222,10 +298,18 @@ func (r *repository) FindAllCoursesWithMeta(ctx context.Context, userID uint, qu
 		selectClause += `,
 		0 as is_enrolled`
 	}
+
+	// Relevance: course title/description matches weigh double the best lesson match
+	var selectArgs []interface{}
+	if search.fulltext() {
+		selectClause += `,
+		(MATCH(courses.title, courses.description) AGAINST (?) * 2 + COALESCE(lesson_relevance.score, 0)) as relevance`
+		selectArgs = append(selectArgs, search.Natural)
+	}
 	
-	db = r.db.WithContext(ctx).
+	db := r.db.WithContext(ctx).
 		Table("courses").
-		Select(selectClause).
+		Select(selectClause, selectArgs...).
 		Joins(`
 			LEFT JOIN (
 				SELECT course_id, COUNT(*) as count 
@@ -241,103 +325,542 @@ func (r *repository) FindAllCoursesWithMeta(ctx context.Context, userID uint, qu
 			LEFT JOIN enrollments ON enrollments.course_id = courses.id 
 			AND enrollments.user_id = ? 
 			AND enrollments.deleted_at IS NULL
-		`, userID)
+			AND (enrollments.expires_at IS NULL OR enrollments.expires_at > ?)
+		`, userID, time.Now())
+	}
+
+	if search.fulltext() {
+		db = db.Joins(`
+			LEFT JOIN (
+				SELECT course_id, MAX(MATCH(title, content) AGAINST (?)) as score
+				FROM lessons
+				WHERE deleted_at IS NULL
+				AND is_published = true
+				AND MATCH(title, content) AGAINST (? IN BOOLEAN MODE)
+				GROUP BY course_id
+			) AS lesson_relevance ON lesson_relevance.course_id = courses.id
+		`, search.Natural, search.Boolean)
 	}
 
 	// Apply same filters as count query
-	if query.Search != "" {
-		searchTerm := "%" + strings.ToLower(query.Search) + "%"
-		db = db.Where("LOWER(courses.title) LIKE ? OR LOWER(courses.description) LIKE ?", searchTerm, searchTerm)
+	db = applyCourseFilters(db, query, search, "")
+
+	// Continue after the cursor row. relevance is a SELECT alias, which only HAVING can see.
+	if after != nil {
+		condition, args := cursor.Condition(keys, after)
+		if keys[0].Column == "relevance" {
+			db = db.Having(condition, args...)
+		} else {
+			db = db.Where(condition, args...)
+		}
 	}
 
-	if query.Category != "" {
-		db = db.Where("courses.category = ?", query.Category)
+	// Fetch courses with metadata, plus one row to tell whether a next page exists
+	if err := db.Order(cursor.OrderClause(keys)).Limit(limit + 1).Offset(offset).Scan(&coursesWithMeta).Error; err != nil {
+		logger.Error("Database error fetching courses with metadata",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+			zap.Int("limit", limit),
+			zap.Int("offset", offset),
+		)
+		return nil, 0, "", err
 	}
 
-	if query.Difficulty != "" {
-		db = db.Where("courses.difficulty = ?", query.Difficulty)
+	coursesWithMeta, next := cursor.Next(coursesWithMeta, limit, keyValues)
+	return coursesWithMeta, int(total), next, nil
+}
+
+// Helper: The keyset for a course list sort (sort column, then id as tie-breaker) and how to
+// read its values from a row for the next-page cursor
+func courseSortKeys(query *CourseListQuery, searching bool) ([]cursor.Key, func(*CourseWithMeta) []interface{}) {
+	sortBy := query.SortBy
+	if sortBy == "relevance" && !searching {
+		sortBy = "" // Nothing to rank without a search term
+	}
+	if sortBy == "" {
+		sortBy = "created_at" // default sort
+		if searching {
+			sortBy = "relevance"
+		}
 	}
+	desc := query.SortOrder != "asc" // default order: desc
 
-	if query.Published != nil {
-		db = db.Where("courses.is_published = ?", *query.Published)
+	id := cursor.Key{Column: "courses.id", Desc: desc}
+	switch sortBy {
+	case "relevance":
+		return []cursor.Key{{Column: "relevance", Desc: desc}, {Column: "courses.enrolled_count", Desc: true}, id},
+			func(c *CourseWithMeta) []interface{} { return []interface{}{c.Relevance, c.EnrolledCount, c.ID} }
+	case "title":
+		return []cursor.Key{{Column: "courses.title", Desc: desc}, id},
+			func(c *CourseWithMeta) []interface{} { return []interface{}{c.Title, c.ID} }
+	case "price":
+		return []cursor.Key{{Column: "courses.price", Desc: desc}, id},
+			func(c *CourseWithMeta) []interface{} { return []interface{}{c.Price, c.ID} }
+	case "rating":
+		// Equal averages: the better-established course (more reviews) first
+		return []cursor.Key{{Column: "courses.average_rating", Desc: desc}, {Column: "courses.review_count", Desc: true}, id},
+			func(c *CourseWithMeta) []interface{} { return []interface{}{c.AverageRating, c.ReviewCount, c.ID} }
+	case "popularity", "enrollment_count":
+		return []cursor.Key{{Column: "courses.enrolled_count", Desc: desc}, id},
+			func(c *CourseWithMeta) []interface{} { return []interface{}{c.EnrolledCount, c.ID} }
+	case "updated_at":
+		return []cursor.Key{{Column: "courses.updated_at", Desc: desc}, id},
+			func(c *CourseWithMeta) []interface{} { return []interface{}{c.UpdatedAt, c.ID} }
+	case "submitted_at":
+		// Never-submitted courses sort by creation date (keyset keys cannot be NULL)
+		return []cursor.Key{{Column: "COALESCE(courses.submitted_at, courses.created_at)", Desc: desc}, id},
+			func(c *CourseWithMeta) []interface{} {
+				if c.SubmittedAt != nil {
+					return []interface{}{*c.SubmittedAt, c.ID}
+				}
+				return []interface{}{c.CreatedAt, c.ID}
+			}
+	default: // created_at
+		return []cursor.Key{{Column: "courses.created_at", Desc: desc}, id},
+			func(c *CourseWithMeta) []interface{} { return []interface{}{c.CreatedAt, c.ID} }
 	}
+}
 
-	// Add new filters
-	if query.MinPrice > 0 {
-		db = db.Where("courses.price >= ?", query.MinPrice)
+// FindSearchMatchedLessons returns the published lessons of the given courses that match
+// a boolean FULLTEXT query, best match first (used to build search snippets)
+func (r *repository) FindSearchMatchedLessons(ctx context.Context, courseIDs []uint, booleanQuery string) ([]*Lesson, error) {
+	var lessons []*Lesson
+	if err := r.db.WithContext(ctx).
+		Select("id", "course_id", "title", "content").
+		Where("course_id IN ?", courseIDs).
+		Where("is_published = ?", true).
+		Where("MATCH(title, content) AGAINST (? IN BOOLEAN MODE)", booleanQuery).
+		Order(clause.Expr{SQL: "MATCH(title, content) AGAINST (? IN BOOLEAN MODE) DESC", Vars: []interface{}{booleanQuery}}).
+		Find(&lessons).Error; err != nil {
+		logger.Error("Database error finding search matched lessons",
+			zap.Error(err),
+			zap.String("query", booleanQuery),
+		)
+		return nil, err
 	}
+	return lessons, nil
+}
 
-	if query.MaxPrice > 0 {
-		db = db.Where("courses.price <= ?", query.MaxPrice)
+// FindCourseFacetCounts counts the courses matching the list filters per category, difficulty,
+// price bucket and instructor. Each facet is counted without its own filter.
+func (r *repository) FindCourseFacetCounts(ctx context.Context, query *CourseListQuery) (*CourseFacetCounts, error) {
+	search := parseSearch(query.Search)
+	counts := &CourseFacetCounts{}
+
+	var total int64
+	if err := applyCourseFilters(r.db.WithContext(ctx).Table("courses"), query, search, "").
+		Count(&total).Error; err != nil {
+		logger.Error("Database error counting courses for facets",
+			zap.Error(err),
+			zap.String("search", query.Search),
+		)
+		return nil, err
 	}
+	counts.Total = int(total)
 
-	// Filter by instructor (support pointer for optional filtering)
-	if query.InstructorID != nil && *query.InstructorID > 0 {
-		db = db.Where("courses.instructor_id = ?", *query.InstructorID)
+	type facetRow struct {
+		Value string
+		Count int
+	}
+	countBy := func(facet, expr string) (map[string]int, error) {
+		var rows []facetRow
+		if err := applyCourseFilters(r.db.WithContext(ctx).Table("courses"), query, search, facet).
+			Select(expr + " AS value, COUNT(*) AS count").
+			Group("value").
+			Scan(&rows).Error; err != nil {
+			logger.Error("Database error counting course facet",
+				zap.Error(err),
+				zap.String("facet", facet),
+				zap.String("search", query.Search),
+			)
+			return nil, err
+		}
+		result := make(map[string]int, len(rows))
+		for _, row := range rows {
+			result[row.Value] = row.Count
+		}
+		return result, nil
 	}
 
-	// Add WHERE for soft deletes
-	db = db.Where("courses.deleted_at IS NULL")
+	var err error
+	if counts.Categories, err = countBy(facetCategory, "courses.category"); err != nil {
+		return nil, err
+	}
+	if counts.Difficulties, err = countBy(facetDifficulty, "courses.difficulty"); err != nil {
+		return nil, err
+	}
+	if counts.PriceBuckets, err = countBy(facetPrice, priceBucketExpr); err != nil {
+		return nil, err
+	}
 
-	// Apply sorting
-	sortBy := query.SortBy
-	if sortBy == "" {
-		sortBy = "created_at" // default sort
+	if err := applyCourseFilters(r.db.WithContext(ctx).Table("courses"), query, search, facetInstructor).
+		Select("courses.instructor_id, users.name, COUNT(*) AS count").
+		Joins("INNER JOIN users ON users.id = courses.instructor_id").
+		Group("courses.instructor_id, users.name").
+		Order("count DESC, users.name ASC").
+		Limit(maxInstructorFacets).
+		Scan(&counts.Instructors).Error; err != nil {
+		logger.Error("Database error counting instructor facet",
+			zap.Error(err),
+			zap.String("search", query.Search),
+		)
+		return nil, err
 	}
 
-	sortOrder := query.SortOrder
-	if sortOrder == "" {
-		sortOrder = "desc" // default order
+	return counts, nil
+}
+
+// CategoryExists reports whether a category name is in the categories table (managed by the category module)
+func (r *repository) CategoryExists(ctx context.Context, name string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Table("categories").Where("name = ?", name).Count(&count).Error; err != nil {
+		logger.Error("Database error checking category",
+			zap.Error(err),
+			zap.String("category", name),
+		)
+		return false, err
 	}
+	return count > 0, nil
+}
 
-	// Build order clause
-	var orderClause string
-	switch sortBy {
-	case "title":
-		orderClause = "courses.title " + sortOrder
-	case "price":
-		orderClause = "courses.price " + sortOrder
-	case "rating":
-		// For rating, we need to join with review summary or calculate on the fly
-		// For now, we'll sort by enrolled_count as a proxy for popularity
-		orderClause = "courses.enrolled_count " + sortOrder
-	case "popularity":
-		orderClause = "courses.enrolled_count " + sortOrder
-	case "enrollment_count":
-		orderClause = "courses.enrolled_count " + sortOrder
-	case "updated_at":
-		orderClause = "courses.updated_at " + sortOrder
-	case "created_at":
-		fallthrough
-	default:
-		orderClause = "courses.created_at " + sortOrder
+// FindCategoryNames returns all category names in display order
+func (r *repository) FindCategoryNames(ctx context.Context) ([]string, error) {
+	var names []string
+	if err := r.db.WithContext(ctx).Table("categories").
+		Order("sort_order ASC, name ASC").
+		Pluck("name", &names).Error; err != nil {
+		logger.Error("Database error finding category names", zap.Error(err))
+		return nil, err
 	}
+	return names, nil
+}
 
-	// Fetch courses with metadata
-	if err := db.Order(orderClause).Limit(limit).Offset(offset).Scan(&coursesWithMeta).Error; err != nil {
-		logger.Error("Database error fetching courses with metadata",
+// UpdateCourse saves the editable course fields. When the slug changes, the previous one is
+// added to the slug history in the same transaction so shared links keep working.
+func (r *repository) UpdateCourse(ctx context.Context, course *Course) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var oldSlug string
+		if err := tx.Model(&Course{}).Select("slug").Where("id = ?", course.ID).Scan(&oldSlug).Error; err != nil {
+			return err
+		}
+		if oldSlug != "" && oldSlug != course.Slug {
+			// A course may take back one of its own old slugs
+			if err := tx.Where("course_id = ? AND slug = ?", course.ID, course.Slug).
+				Delete(&CourseSlugHistory{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Create(&CourseSlugHistory{CourseID: course.ID, Slug: oldSlug}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Status and is_published only change through UpdateCourseStatus
+		return tx.Model(course).Select(
+			"title", "slug", "description", "thumbnail_url", "category",
+			"difficulty", "price", "access_duration_days", "max_enrollments",
+		).Updates(course).Error
+	})
+	if IsDuplicateKey(err) {
+		return ErrSlugTaken // The slug (current or old) is the only unique column written here
+	}
+	if err != nil {
+		logger.Error("Failed to update course",
 			zap.Error(err),
-			zap.Uint("user_id", userID),
-			zap.Int("limit", limit),
-			zap.Int("offset", offset),
+			zap.Uint("course_id", course.ID),
 		)
-		return nil, 0, err
+		return err
 	}
+	return nil
+}
 
-	return coursesWithMeta, int(total), nil
+// UpdateCourseStatus saves a status change (with its timestamps and the is_published mirror)
+// together with its history event
+func (r *repository) UpdateCourseStatus(ctx context.Context, course *Course, event *CourseStatusEvent) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(course).Select(
+			"status", "is_published", "submitted_at", "reviewed_at", "reviewed_by",
+			"published_at", "archived_at",
+		).Updates(course).Error; err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		logger.Error("Failed to update course status",
+			zap.Error(err),
+			zap.Uint("course_id", course.ID),
+			zap.String("status", course.Status),
+		)
+		return err
+	}
+	return nil
 }
 
-func (r *repository) UpdateCourse(ctx context.Context, course *Course) error {
-	return r.db.WithContext(ctx).Model(course).Select(
-		"title", "slug", "description", "thumbnail_url", "category",
-		"difficulty", "price", "is_published",
-	).Updates(course).Error
+// BackfillCourseStatus publishes courses that were live before the review workflow existed.
+// AutoMigrate adds the status column as 'draft', and is_published only stays true together
+// with status = 'published', so the update is a no-op once it has run.
+func (r *repository) BackfillCourseStatus(ctx context.Context) error {
+	err := r.db.WithContext(ctx).Model(&Course{}).
+		Where("is_published = ? AND status = ?", true, CourseStatusDraft).
+		UpdateColumns(map[string]interface{}{
+			"status":       CourseStatusPublished,
+			"published_at": gorm.Expr("COALESCE(published_at, updated_at)"),
+		}).Error
+	if err != nil {
+		logger.Error("Failed to backfill course status", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// FindCourseStatusEvents returns the status history of a course, oldest first
+func (r *repository) FindCourseStatusEvents(ctx context.Context, courseID uint) ([]*CourseStatusEvent, error) {
+	var events []*CourseStatusEvent
+	if err := r.db.WithContext(ctx).Preload("Actor").
+		Where("course_id = ?", courseID).
+		Order("created_at ASC, id ASC").
+		Find(&events).Error; err != nil {
+		logger.Error("Database error finding course status events",
+			zap.Error(err),
+			zap.Uint("course_id", courseID),
+		)
+		return nil, err
+	}
+	return events, nil
 }
 
 func (r *repository) DeleteCourse(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&Course{}, id).Error
 }
 
+// SlugExists reports whether a course slug is taken by a course other than courseID (0 for a
+// new course), including soft-deleted courses (the unique index still covers them) and the
+// old slugs kept for redirects
+func (r *repository) SlugExists(ctx context.Context, slug string, courseID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(&Course{}).
+		Where("slug = ? AND id <> ?", slug, courseID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	if count > 0 {
+		return true, nil
+	}
+
+	if err := r.db.WithContext(ctx).Model(&CourseSlugHistory{}).
+		Where("slug = ? AND course_id <> ?", slug, courseID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// DuplicateCourse creates target as a deep copy of the source course in one transaction:
+// sections, lessons (live content as a fresh first revision), quizzes, prerequisites and tags.
+// Enrollments, reviews, progress and collaborators are not copied.
+func (r *repository) DuplicateCourse(ctx context.Context, sourceID uint, target *Course, authorID uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Instructor", "Sections", "Lessons", "Enrollments").Create(target).Error; err != nil {
+			if IsDuplicateKey(err) {
+				return ErrSlugTaken
+			}
+			return err
+		}
+
+		// Sections
+		var sections []Section
+		if err := tx.Where("course_id = ?", sourceID).Order("order_index ASC").Find(&sections).Error; err != nil {
+			return err
+		}
+		sectionIDs := make(map[uint]uint, len(sections))
+		for _, section := range sections {
+			oldID := section.ID
+			section.ID = 0
+			section.CourseID = target.ID
+			section.CreatedAt, section.UpdatedAt = time.Time{}, time.Time{}
+			if err := tx.Create(&section).Error; err != nil {
+				return err
+			}
+			sectionIDs[oldID] = section.ID
+		}
+
+		quizIDs, err := duplicateQuizzes(tx, sourceID, target.ID, authorID)
+		if err != nil {
+			return err
+		}
+
+		// Lessons keep their published content; pending drafts and history stay with the source
+		var lessons []Lesson
+		if err := tx.Where("course_id = ?", sourceID).Order("order_index ASC").Find(&lessons).Error; err != nil {
+			return err
+		}
+		lessonIDs := make(map[uint]uint, len(lessons))
+		copies := make([]*Lesson, 0, len(lessons))
+		now := time.Now()
+		for i := range lessons {
+			lesson := lessons[i]
+			oldID := lesson.ID
+			lesson.ID = 0
+			lesson.CourseID = target.ID
+			lesson.CreatedAt, lesson.UpdatedAt = time.Time{}, time.Time{}
+			lesson.PublishedRevisionID, lesson.DraftRevisionID = nil, nil
+			if lesson.SectionID != nil {
+				sectionID := sectionIDs[*lesson.SectionID]
+				lesson.SectionID = &sectionID
+			}
+			if lesson.QuizID != nil {
+				quizID, ok := quizIDs[*lesson.QuizID]
+				if !ok {
+					lesson.QuizID = nil
+				} else {
+					lesson.QuizID = &quizID
+				}
+			}
+			if err := tx.Create(&lesson).Error; err != nil {
+				return err
+			}
+
+			revision := &LessonRevision{
+				LessonID:    lesson.ID,
+				Version:     1,
+				Title:       lesson.Title,
+				Content:     lesson.Content,
+				Duration:    lesson.Duration,
+				AuthorID:    authorID,
+				PublishedAt: &now,
+			}
+			revision.SetPayload(&lesson)
+			if err := tx.Create(revision).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&lesson).Update("published_revision_id", revision.ID).Error; err != nil {
+				return err
+			}
+
+			lessonIDs[oldID] = lesson.ID
+			copies = append(copies, &lesson)
+		}
+
+		// Attachments point at the same stored files
+		var attachments []LessonAttachment
+		if err := tx.Where("course_id = ?", sourceID).Find(&attachments).Error; err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			lessonID, ok := lessonIDs[attachment.LessonID]
+			if !ok {
+				continue
+			}
+			attachment.ID = 0
+			attachment.LessonID = lessonID
+			attachment.CourseID = target.ID
+			attachment.UploadedBy = authorID
+			attachment.CreatedAt, attachment.UpdatedAt = time.Time{}, time.Time{}
+			if err := tx.Create(&attachment).Error; err != nil {
+				return err
+			}
+		}
+
+		// after_lesson release rules point at the copied lessons
+		for _, lesson := range copies {
+			if lesson.ReleaseAfterLessonID == nil {
+				continue
+			}
+			if err := tx.Model(lesson).Update("release_after_lesson_id", lessonIDs[*lesson.ReleaseAfterLessonID]).Error; err != nil {
+				return err
+			}
+		}
+
+		// Prerequisites
+		var prerequisiteIDs []uint
+		if err := tx.Model(&CoursePrerequisite{}).Where("course_id = ?", sourceID).
+			Pluck("prerequisite_course_id", &prerequisiteIDs).Error; err != nil {
+			return err
+		}
+		for _, id := range prerequisiteIDs {
+			if err := tx.Create(&CoursePrerequisite{CourseID: target.ID, PrerequisiteCourseID: id}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Tags
+		if err := tx.Exec(`INSERT INTO course_tags (course_id, tag_id)
+			SELECT ?, tag_id FROM course_tags WHERE course_id = ?`, target.ID, sourceID).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to duplicate course",
+			zap.Error(err),
+			zap.Uint("source_course_id", sourceID),
+		)
+		return err
+	}
+	return nil
+}
+
+// duplicateQuizzes copies the quizzes of a course (with questions and options) and returns
+// old quiz ID => new quiz ID. Quiz tables are owned by the quiz module, so they are copied
+// with INSERT ... SELECT instead of importing its models.
+func duplicateQuizzes(tx *gorm.DB, sourceCourseID, targetCourseID, authorID uint) (map[uint]uint, error) {
+	var quizIDs []uint
+	if err := tx.Table("quizzes").Where("course_id = ? AND deleted_at IS NULL", sourceCourseID).
+		Pluck("id", &quizIDs).Error; err != nil {
+		return nil, err
+	}
+
+	mapping := make(map[uint]uint, len(quizIDs))
+	for _, quizID := range quizIDs {
+		if err := tx.Exec(`INSERT INTO quizzes (course_id, title, description, pass_mark, max_attempts,
+				questions_per_attempt, shuffle_questions, shuffle_options, created_by, created_at, updated_at)
+			SELECT ?, title, description, pass_mark, max_attempts, questions_per_attempt,
+				shuffle_questions, shuffle_options, ?, NOW(), NOW()
+			FROM quizzes WHERE id = ?`, targetCourseID, authorID, quizID).Error; err != nil {
+			return nil, err
+		}
+		newQuizID, err := lastInsertID(tx)
+		if err != nil {
+			return nil, err
+		}
+		mapping[quizID] = newQuizID
+
+		var questionIDs []uint
+		if err := tx.Table("quiz_questions").Where("quiz_id = ? AND deleted_at IS NULL", quizID).
+			Order("order_index ASC").Pluck("id", &questionIDs).Error; err != nil {
+			return nil, err
+		}
+		for _, questionID := range questionIDs {
+			if err := tx.Exec(`INSERT INTO quiz_questions (quiz_id, type, prompt, explanation, points, order_index,
+					accepted_answers, case_sensitive, created_at, updated_at)
+				SELECT ?, type, prompt, explanation, points, order_index, accepted_answers, case_sensitive, NOW(), NOW()
+				FROM quiz_questions WHERE id = ?`, newQuizID, questionID).Error; err != nil {
+				return nil, err
+			}
+			newQuestionID, err := lastInsertID(tx)
+			if err != nil {
+				return nil, err
+			}
+			if err := tx.Exec(`INSERT INTO quiz_question_options (question_id, text, is_correct, order_index)
+				SELECT ?, text, is_correct, order_index
+				FROM quiz_question_options WHERE question_id = ? ORDER BY order_index`, newQuestionID, questionID).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// lastInsertID returns the auto-increment ID of the last INSERT on the transaction's connection
+func lastInsertID(tx *gorm.DB) (uint, error) {
+	var id uint
+	if err := tx.Raw("SELECT LAST_INSERT_ID()").Scan(&id).Error; err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
 func (r *repository) IncrementEnrolledCount(ctx context.Context, courseID uint) error {
 	return r.db.WithContext(ctx).Model(&Course{}).Where("id = ?", courseID).
 		UpdateColumn("enrolled_count", gorm.Expr("enrolled_count + ?", 1)).Error
@@ -434,22 +957,541 @@ func (r *repository) BatchUpdateLessonOrder(ctx context.Context, updates []Lesso
 	})
 }
 
-// Enrollment operations
+// Lesson revision operations
 
-func (r *repository) CreateEnrollment(ctx context.Context, enrollment *Enrollment) error {
-	return r.db.WithContext(ctx).Create(enrollment).Error
-}
+// CreateLessonWithRevision creates a lesson together with its first (published) revision
+func (r *repository) CreateLessonWithRevision(ctx context.Context, lesson *Lesson, revision *LessonRevision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(lesson).Error; err != nil {
+			logger.Error("Failed to create lesson in database",
+				zap.Error(err),
+				zap.Uint("course_id", lesson.CourseID),
+				zap.String("title", lesson.Title),
+			)
+			return err
+		}
 
-func (r *repository) FindEnrollment(ctx context.Context, userID, courseID uint) (*Enrollment, error) {
-	var enrollment Enrollment
-	if err := r.db.WithContext(ctx).Where("user_id = ? AND course_id = ?", userID, courseID).
-		First(&enrollment).Error; err != nil {
-		return nil, err
-	}
-	return &enrollment, nil
+		revision.LessonID = lesson.ID
+		revision.Version = 1
+		if err := tx.Create(revision).Error; err != nil {
+			logger.Error("Failed to create initial lesson revision",
+				zap.Error(err),
+				zap.Uint("lesson_id", lesson.ID),
+			)
+			return err
+		}
+
+		lesson.PublishedRevisionID = &revision.ID
+		return tx.Model(lesson).Update("published_revision_id", revision.ID).Error
+	})
 }
 
-func (r *repository) DeleteEnrollment(ctx context.Context, userID, courseID uint) error {
+// SaveLessonRevision stores a new revision (next version number) and updates the lesson.
+// A revision with PublishedAt set becomes the lesson's published revision and clears
+// the pending draft; otherwise it becomes the lesson's draft.
+func (r *repository) SaveLessonRevision(ctx context.Context, lesson *Lesson, revision *LessonRevision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lastVersion int
+		if err := tx.Model(&LessonRevision{}).
+			Where("lesson_id = ?", lesson.ID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&lastVersion).Error; err != nil {
+			return err
+		}
+
+		revision.LessonID = lesson.ID
+		revision.Version = lastVersion + 1
+		if err := tx.Create(revision).Error; err != nil {
+			logger.Error("Failed to create lesson revision",
+				zap.Error(err),
+				zap.Uint("lesson_id", lesson.ID),
+				zap.Int("version", revision.Version),
+			)
+			return err
+		}
+
+		if revision.PublishedAt != nil {
+			lesson.PublishedRevisionID = &revision.ID
+			lesson.DraftRevisionID = nil
+		} else {
+			lesson.DraftRevisionID = &revision.ID
+		}
+
+		// Select("*") so nil revision/section pointers are persisted (see UpdateLesson)
+		return tx.Model(lesson).Select("*").Updates(lesson).Error
+	})
+}
+
+// PublishLessonRevision marks an existing revision as published and saves the lesson
+// (whose fields the caller has already copied from the revision)
+func (r *repository) PublishLessonRevision(ctx context.Context, lesson *Lesson, revision *LessonRevision) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(revision).Update("published_at", revision.PublishedAt).Error; err != nil {
+			logger.Error("Failed to publish lesson revision",
+				zap.Error(err),
+				zap.Uint("lesson_id", lesson.ID),
+				zap.Uint("revision_id", revision.ID),
+			)
+			return err
+		}
+		return tx.Model(lesson).Select("*").Updates(lesson).Error
+	})
+}
+
+func (r *repository) FindLessonRevisionByID(ctx context.Context, id uint) (*LessonRevision, error) {
+	var revision LessonRevision
+	if err := r.db.WithContext(ctx).First(&revision, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding lesson revision by ID",
+				zap.Error(err),
+				zap.Uint("revision_id", id),
+			)
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// FindLessonRevisions returns all revisions of a lesson, newest first (content omitted)
+func (r *repository) FindLessonRevisions(ctx context.Context, lessonID uint) ([]*LessonRevision, error) {
+	var revisions []*LessonRevision
+	if err := r.db.WithContext(ctx).
+		Omit("content").
+		Where("lesson_id = ?", lessonID).
+		Order("version DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// Lesson attachment operations
+
+// CreateLessonAttachment adds an attachment after the lesson's existing ones
+func (r *repository) CreateLessonAttachment(ctx context.Context, attachment *LessonAttachment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&LessonAttachment{}).Where("lesson_id = ?", attachment.LessonID).Count(&count).Error; err != nil {
+			return err
+		}
+		attachment.OrderIndex = int(count)
+		return tx.Create(attachment).Error
+	})
+}
+
+func (r *repository) FindLessonAttachmentByID(ctx context.Context, id uint) (*LessonAttachment, error) {
+	var attachment LessonAttachment
+	if err := r.db.WithContext(ctx).First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *repository) FindLessonAttachments(ctx context.Context, lessonID uint) ([]*LessonAttachment, error) {
+	var attachments []*LessonAttachment
+	if err := r.db.WithContext(ctx).
+		Where("lesson_id = ?", lessonID).
+		Order("order_index ASC, id ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// DeleteLessonAttachment soft-deletes the attachment row. The stored file is kept: duplicated
+// courses may share it.
+func (r *repository) DeleteLessonAttachment(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&LessonAttachment{}, id).Error
+}
+
+// Section operations
+
+func (r *repository) CreateSection(ctx context.Context, section *Section) error {
+	err := r.db.WithContext(ctx).Create(section).Error
+	if err != nil {
+		logger.Error("Failed to create section in database",
+			zap.Error(err),
+			zap.Uint("course_id", section.CourseID),
+			zap.String("title", section.Title),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) FindSectionByID(ctx context.Context, id uint) (*Section, error) {
+	var section Section
+	if err := r.db.WithContext(ctx).First(&section, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding section by ID",
+				zap.Error(err),
+				zap.Uint("section_id", id),
+			)
+		}
+		return nil, err
+	}
+	return &section, nil
+}
+
+func (r *repository) FindSectionsByCourseID(ctx context.Context, courseID uint) ([]*Section, error) {
+	var sections []*Section
+	if err := r.db.WithContext(ctx).Where("course_id = ?", courseID).
+		Order("order_index ASC, id ASC").Find(&sections).Error; err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+func (r *repository) UpdateSection(ctx context.Context, section *Section) error {
+	return r.db.WithContext(ctx).Model(section).Select(
+		"title", "description", "order_index",
+	).Updates(section).Error
+}
+
+// DeleteSection removes a section and detaches its lessons (lessons are kept, not deleted)
+func (r *repository) DeleteSection(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Lesson{}).Where("section_id = ?", id).
+			Update("section_id", nil).Error; err != nil {
+			logger.Error("Failed to detach lessons from section",
+				zap.Error(err),
+				zap.Uint("section_id", id),
+			)
+			return err
+		}
+		return tx.Delete(&Section{}, id).Error
+	})
+}
+
+// BatchUpdateSectionOrder updates order_index for multiple sections of a course in a transaction
+func (r *repository) BatchUpdateSectionOrder(ctx context.Context, courseID uint, updates []SectionOrderUpdate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, update := range updates {
+			// Scope by course so a section of another course can't be touched
+			if err := tx.Model(&Section{}).
+				Where("id = ? AND course_id = ?", update.SectionID, courseID).
+				Update("order_index", update.OrderIndex).Error; err != nil {
+				logger.Error("Failed to update section order",
+					zap.Error(err),
+					zap.Uint("section_id", update.SectionID),
+					zap.Int("order_index", update.OrderIndex),
+				)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindCompletedLessonIDs returns IDs of lessons the user has completed in a course
+func (r *repository) FindCompletedLessonIDs(ctx context.Context, userID, courseID uint) ([]uint, error) {
+	var lessonIDs []uint
+	if err := r.db.WithContext(ctx).Table("lesson_progress").
+		Where("user_id = ? AND course_id = ?", userID, courseID).
+		Pluck("lesson_id", &lessonIDs).Error; err != nil {
+		return nil, err
+	}
+	return lessonIDs, nil
+}
+
+// QuizBelongsToCourse checks that a quiz exists and belongs to the course
+func (r *repository) QuizBelongsToCourse(ctx context.Context, quizID, courseID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Table("quizzes").
+		Where("id = ? AND course_id = ? AND deleted_at IS NULL", quizID, courseID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Prerequisite operations
+
+// FindPrerequisiteCourses returns the courses required before enrolling in a course
+func (r *repository) FindPrerequisiteCourses(ctx context.Context, courseID uint) ([]*Course, error) {
+	var courses []*Course
+	if err := r.db.WithContext(ctx).
+		Joins("INNER JOIN course_prerequisites ON course_prerequisites.prerequisite_course_id = courses.id").
+		Where("course_prerequisites.course_id = ?", courseID).
+		Order("course_prerequisites.id ASC").
+		Find(&courses).Error; err != nil {
+		logger.Error("Database error finding course prerequisites",
+			zap.Error(err),
+			zap.Uint("course_id", courseID),
+		)
+		return nil, err
+	}
+	return courses, nil
+}
+
+func (r *repository) FindPrerequisiteIDs(ctx context.Context, courseID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&CoursePrerequisite{}).
+		Where("course_id = ?", courseID).
+		Pluck("prerequisite_course_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ReplacePrerequisites swaps the full prerequisite set of a course in one transaction
+func (r *repository) ReplacePrerequisites(ctx context.Context, courseID uint, prerequisiteIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("course_id = ?", courseID).Delete(&CoursePrerequisite{}).Error; err != nil {
+			return err
+		}
+
+		if len(prerequisiteIDs) == 0 {
+			return nil
+		}
+		links := make([]CoursePrerequisite, 0, len(prerequisiteIDs))
+		for _, id := range prerequisiteIDs {
+			links = append(links, CoursePrerequisite{CourseID: courseID, PrerequisiteCourseID: id})
+		}
+		if err := tx.Create(&links).Error; err != nil {
+			logger.Error("Failed to save course prerequisites",
+				zap.Error(err),
+				zap.Uint("course_id", courseID),
+			)
+			return err
+		}
+		return nil
+	})
+}
+
+// Tag operations
+
+// ReplaceCourseTags swaps the full tag set of a course in one transaction. Missing tags are
+// created (the service only lets admins introduce them); existing ones are matched by slug and the given tags are filled with the stored rows.
+func (r *repository) ReplaceCourseTags(ctx context.Context, courseID uint, tags []*Tag) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, tag := range tags {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&Tag{Name: tag.Name, Slug: tag.Slug}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("slug = ?", tag.Slug).First(tag).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("course_id = ?", courseID).Delete(&CourseTag{}).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		links := make([]CourseTag, 0, len(tags))
+		for _, tag := range tags {
+			links = append(links, CourseTag{CourseID: courseID, TagID: tag.ID})
+		}
+		return tx.Create(&links).Error
+	})
+	if err != nil {
+		logger.Error("Failed to save course tags",
+			zap.Error(err),
+			zap.Uint("course_id", courseID),
+		)
+		return err
+	}
+	return nil
+}
+
+// FindTagsByCourseIDs returns the tags of each course (course ID => tags sorted by name)
+func (r *repository) FindTagsByCourseIDs(ctx context.Context, courseIDs []uint) (map[uint][]Tag, error) {
+	tags := make(map[uint][]Tag, len(courseIDs))
+	if len(courseIDs) == 0 {
+		return tags, nil
+	}
+
+	var rows []struct {
+		CourseID uint
+		Tag
+	}
+	if err := r.db.WithContext(ctx).Table("tags").
+		Select("course_tags.course_id, tags.id, tags.name, tags.slug").
+		Joins("INNER JOIN course_tags ON course_tags.tag_id = tags.id").
+		Where("course_tags.course_id IN ?", courseIDs).
+		Order("tags.name ASC").
+		Scan(&rows).Error; err != nil {
+		logger.Error("Database error finding course tags", zap.Error(err))
+		return nil, err
+	}
+	for _, row := range rows {
+		tags[row.CourseID] = append(tags[row.CourseID], row.Tag)
+	}
+	return tags, nil
+}
+
+func (r *repository) FindTagBySlug(ctx context.Context, slug string) (*Tag, error) {
+	var tag Tag
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&tag).Error; err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *repository) FindTagsBySlugs(ctx context.Context, slugs []string) ([]*Tag, error) {
+	var tags []*Tag
+	if err := r.db.WithContext(ctx).Where("slug IN ?", slugs).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// tagCounts selects tags with their number of published courses, most used first
+func (r *repository) tagCounts(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Table("tags").
+		Select("tags.id, tags.name, tags.slug, COUNT(*) AS course_count").
+		Joins("INNER JOIN course_tags ON course_tags.tag_id = tags.id").
+		Joins("INNER JOIN courses ON courses.id = course_tags.course_id AND courses.status = ? AND courses.deleted_at IS NULL", CourseStatusPublished).
+		Group("tags.id, tags.name, tags.slug").
+		Order("course_count DESC, tags.name ASC")
+}
+
+// FindPopularTags returns the tags used by the most published courses
+func (r *repository) FindPopularTags(ctx context.Context, limit int) ([]*TagWithCount, error) {
+	var tags []*TagWithCount
+	if err := r.tagCounts(ctx).Limit(limit).Scan(&tags).Error; err != nil {
+		logger.Error("Database error finding popular tags", zap.Error(err))
+		return nil, err
+	}
+	return tags, nil
+}
+
+// FindRelatedTags returns the tags most often found on the published courses of a tag;
+// course_count is the number of courses sharing both tags
+func (r *repository) FindRelatedTags(ctx context.Context, tagID uint, limit int) ([]*TagWithCount, error) {
+	var tags []*TagWithCount
+	if err := r.tagCounts(ctx).
+		Joins("INNER JOIN course_tags shared ON shared.course_id = course_tags.course_id AND shared.tag_id = ?", tagID).
+		Where("tags.id <> ?", tagID).
+		Limit(limit).
+		Scan(&tags).Error; err != nil {
+		logger.Error("Database error finding related tags",
+			zap.Error(err),
+			zap.Uint("tag_id", tagID),
+		)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Collaborator operations
+
+// FindCollaborators returns the collaborators of a course with their user info
+func (r *repository) FindCollaborators(ctx context.Context, courseID uint) ([]*CourseCollaborator, error) {
+	var collaborators []*CourseCollaborator
+	if err := r.db.WithContext(ctx).Preload("User").
+		Where("course_id = ?", courseID).
+		Order("created_at ASC").
+		Find(&collaborators).Error; err != nil {
+		logger.Error("Failed to find course collaborators",
+			zap.Error(err),
+			zap.Uint("course_id", courseID),
+		)
+		return nil, err
+	}
+	return collaborators, nil
+}
+
+// FindCollaborator returns the user's collaborator entry on a course
+func (r *repository) FindCollaborator(ctx context.Context, courseID, userID uint) (*CourseCollaborator, error) {
+	var collaborator CourseCollaborator
+	if err := r.db.WithContext(ctx).
+		Where("course_id = ? AND user_id = ?", courseID, userID).
+		First(&collaborator).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Failed to find course collaborator",
+				zap.Error(err),
+				zap.Uint("course_id", courseID),
+				zap.Uint("user_id", userID),
+			)
+		}
+		return nil, err
+	}
+	return &collaborator, nil
+}
+
+func (r *repository) CreateCollaborator(ctx context.Context, collaborator *CourseCollaborator) error {
+	if err := r.db.WithContext(ctx).Create(collaborator).Error; err != nil {
+		logger.Error("Failed to create course collaborator",
+			zap.Error(err),
+			zap.Uint("course_id", collaborator.CourseID),
+			zap.Uint("user_id", collaborator.UserID),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) UpdateCollaborator(ctx context.Context, collaborator *CourseCollaborator) error {
+	if err := r.db.WithContext(ctx).Model(collaborator).Updates(map[string]interface{}{
+		"role":          collaborator.Role,
+		"revenue_share": collaborator.RevenueShare,
+	}).Error; err != nil {
+		logger.Error("Failed to update course collaborator",
+			zap.Error(err),
+			zap.Uint("course_id", collaborator.CourseID),
+			zap.Uint("user_id", collaborator.UserID),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) DeleteCollaborator(ctx context.Context, courseID, userID uint) error {
+	return r.db.WithContext(ctx).Where("course_id = ? AND user_id = ?", courseID, userID).
+		Delete(&CourseCollaborator{}).Error
+}
+
+// FindUserByID looks up a course team member's basic info
+func (r *repository) FindUserByID(ctx context.Context, id uint) (*User, error) {
+	var user User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindUserByEmail looks up a user to invite as collaborator
+func (r *repository) FindUserByEmail(ctx context.Context, email string) (*User, error) {
+	var user User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Enrollment operations
+
+func (r *repository) CreateEnrollment(ctx context.Context, enrollment *Enrollment) error {
+	return r.db.WithContext(ctx).Create(enrollment).Error
+}
+
+func (r *repository) FindEnrollment(ctx context.Context, userID, courseID uint) (*Enrollment, error) {
+	var enrollment Enrollment
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND course_id = ?", userID, courseID).
+		First(&enrollment).Error; err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+// UpdateEnrollmentExpiry sets when an enrollment's access ends (nil: lifetime access)
+func (r *repository) UpdateEnrollmentExpiry(ctx context.Context, enrollmentID uint, expiresAt *time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&Enrollment{ID: enrollmentID}).
+		Select("expires_at").Updates(&Enrollment{ExpiresAt: expiresAt}).Error; err != nil {
+		logger.Error("Failed to update enrollment expiry",
+			zap.Error(err),
+			zap.Uint("enrollment_id", enrollmentID),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) DeleteEnrollment(ctx context.Context, userID, courseID uint) error {
 	return r.db.WithContext(ctx).Where("user_id = ? AND course_id = ?", userID, courseID).
 		Delete(&Enrollment{}).Error
 }
@@ -458,6 +1500,7 @@ func (r *repository) IsUserEnrolled(ctx context.Context, userID, courseID uint)
 	var count int64
 	if err := r.db.WithContext(ctx).Model(&Enrollment{}).
 		Where("user_id = ? AND course_id = ?", userID, courseID).
+		Where("expires_at IS NULL OR expires_at > ?", time.Now()).
 		Count(&count).Error; err != nil {
 		return false, err
 	}
@@ -475,3 +1518,150 @@ func (r *repository) GetUserEnrollments(ctx context.Context, userID uint) ([]*En
 	}
 	return enrollments, nil
 }
+
+// Capacity and waitlist operations
+
+// takeSeat counts a new enrollment against the course's seat cap. The check and the
+// increment are one statement, so concurrent enrollments cannot overfill the course.
+func takeSeat(tx *gorm.DB, courseID uint) (bool, error) {
+	result := tx.Model(&Course{}).
+		Where("id = ? AND (max_enrollments = 0 OR enrolled_count < max_enrollments)", courseID).
+		UpdateColumn("enrolled_count", gorm.Expr("enrolled_count + ?", 1))
+	return result.RowsAffected > 0, result.Error
+}
+
+// EnrollWithinCapacity takes a seat and creates the enrollment in one transaction. A
+// waitlist entry of the student for the course is removed.
+func (r *repository) EnrollWithinCapacity(ctx context.Context, enrollment *Enrollment) (bool, error) {
+	enrolled := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ok, err := takeSeat(tx, enrollment.CourseID)
+		if err != nil || !ok {
+			return err
+		}
+		if err := tx.Create(enrollment).Error; err != nil {
+			return err
+		}
+		enrolled = true
+		return tx.Where("course_id = ? AND user_id = ?", enrollment.CourseID, enrollment.UserID).
+			Delete(&WaitlistEntry{}).Error
+	})
+	if err != nil {
+		logger.Error("Failed to create enrollment",
+			zap.Error(err),
+			zap.Uint("course_id", enrollment.CourseID),
+			zap.Uint("user_id", enrollment.UserID),
+		)
+		return false, err
+	}
+	return enrolled, nil
+}
+
+// AddToWaitlist queues a student; joining twice keeps the original place. A paid entry
+// marks an existing one as paid, which moves it ahead of the unpaid entries.
+func (r *repository) AddToWaitlist(ctx context.Context, entry *WaitlistEntry) error {
+	onConflict := clause.OnConflict{DoNothing: true}
+	if entry.PaymentID != nil {
+		onConflict = clause.OnConflict{DoUpdates: clause.AssignmentColumns([]string{"payment_id"})}
+	}
+	return r.db.WithContext(ctx).Clauses(onConflict).Create(entry).Error
+}
+
+// FindWaitlistPosition returns the 1-based place of a student on the course waitlist
+func (r *repository) FindWaitlistPosition(ctx context.Context, courseID, userID uint) (int, error) {
+	var entry WaitlistEntry
+	err := r.db.WithContext(ctx).Where("course_id = ? AND user_id = ?", courseID, userID).
+		Limit(1).Find(&entry).Error
+	if err != nil || entry.ID == 0 {
+		return 0, err
+	}
+
+	// Paid entries go first, then join order (see PromoteFromWaitlist)
+	query := r.db.WithContext(ctx).Model(&WaitlistEntry{}).Where("course_id = ?", courseID)
+	if entry.PaymentID != nil {
+		query = query.Where("payment_id IS NOT NULL AND id < ?", entry.ID)
+	} else {
+		query = query.Where("payment_id IS NOT NULL OR id < ?", entry.ID)
+	}
+	var ahead int64
+	if err := query.Count(&ahead).Error; err != nil {
+		return 0, err
+	}
+	return int(ahead) + 1, nil
+}
+
+func (r *repository) RemoveFromWaitlist(ctx context.Context, courseID, userID uint) (bool, error) {
+	result := r.db.WithContext(ctx).Where("course_id = ? AND user_id = ?", courseID, userID).
+		Delete(&WaitlistEntry{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// NextWaitlistEntry returns the first waitlisted student of the course, paid entries first,
+// leaving out the given students
+func (r *repository) NextWaitlistEntry(ctx context.Context, courseID uint, skipUserIDs []uint) (*WaitlistEntry, error) {
+	query := r.db.WithContext(ctx).Where("course_id = ?", courseID)
+	if len(skipUserIDs) > 0 {
+		query = query.Where("user_id NOT IN ?", skipUserIDs)
+	}
+	var entry WaitlistEntry
+	if err := query.Order("payment_id IS NULL, id ASC").Limit(1).Find(&entry).Error; err != nil || entry.ID == 0 {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// PromoteFromWaitlist enrolls a waitlisted student when the course has a free seat
+// (ErrNotWaitlisted: the student left the waitlist). The entry is locked so concurrent
+// promotions cannot enroll the same student twice. A promoted buyer's payment no longer
+// needs a refund.
+func (r *repository) PromoteFromWaitlist(ctx context.Context, course *Course, userID uint, now time.Time) (*Enrollment, error) {
+	var promoted *Enrollment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var entry WaitlistEntry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("course_id = ? AND user_id = ?", course.ID, userID).
+			Limit(1).Find(&entry).Error; err != nil {
+			return err
+		}
+		if entry.ID == 0 {
+			return ErrNotWaitlisted
+		}
+
+		ok, err := takeSeat(tx, course.ID)
+		if err != nil || !ok {
+			return err
+		}
+		enrollment := &Enrollment{
+			UserID:     entry.UserID,
+			CourseID:   course.ID,
+			EnrolledAt: now,
+			ExpiresAt:  AccessExpiry(now, course.AccessDays),
+		}
+		if err := tx.Create(enrollment).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&entry).Error; err != nil {
+			return err
+		}
+		if entry.PaymentID != nil {
+			// payment_transactions is owned by the payment module
+			if err := tx.Table("payment_transactions").Where("id = ?", *entry.PaymentID).
+				Update("needs_refund", false).Error; err != nil {
+				return err
+			}
+		}
+		promoted = enrollment
+		return nil
+	})
+	if err == ErrNotWaitlisted {
+		return nil, err
+	}
+	if err != nil {
+		logger.Error("Failed to promote waitlisted student",
+			zap.Error(err),
+			zap.Uint("course_id", course.ID),
+		)
+		return nil, err
+	}
+	return promoted, nil
+}
@@ -6,10 +6,10 @@ import (
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
 )
 
-func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware) {
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, notifier Notifier) {
 	// Initialize layers
 	repo := NewRepository(db)
-	service := NewService(repo)
+	service := NewService(repo, notifier)
 	handler := NewHandler(service)
 
 	// Public routes (no authentication required)
@@ -19,8 +19,13 @@ func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middle
 		public.GET("/courses", authMiddleware.OptionalAuth(), handler.ListCourses)                    // List all courses with filters
 		// Apply OptionalAuth to support is_enrolled field for logged-in users
 		public.GET("/courses/slug/:slug", authMiddleware.OptionalAuth(), handler.GetCourseBySlug)     // Get course by slug (must be before :id)
+		public.GET("/courses/facets", authMiddleware.OptionalAuth(), handler.GetCourseFacets)         // Filter counts for the current search (same filters as /courses)
 		public.GET("/courses/:id", handler.GetCourse)                  // Get course by ID
-		public.GET("/courses/:id/lessons", authMiddleware.OptionalAuth(), handler.GetCourseLessons)   // Get course lessons (authenticated users see unpublished lessons)
+		public.GET("/courses/:id/lessons", authMiddleware.OptionalAuth(), handler.GetCourseLessons)   // Get course lessons grouped by section (authenticated users see unpublished lessons)
+		public.GET("/courses/:id/sections", handler.GetCourseSections) // Get course sections with lesson counts
+		public.GET("/courses/:id/prerequisites", authMiddleware.OptionalAuth(), handler.GetCoursePrerequisites) // Get prerequisite courses (with completion for logged-in users)
+		public.GET("/tags", handler.ListPopularTags)                                                  // Popular tags with published course counts
+		public.GET("/tags/:slug", authMiddleware.OptionalAuth(), handler.GetTagPage)                  // Tag page: published courses and related tags
 		// Apply OptionalAuth to include content for enrolled users
 		public.GET("/lessons/:id", authMiddleware.OptionalAuth(), handler.GetLesson)                  // Get lesson detail (public for preview)
 	}
@@ -30,9 +35,19 @@ func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middle
 	protected.Use(authMiddleware.RequireAuth())
 	{
 		// Course management (instructor only - authorization checked in service layer)
-		protected.POST("/courses", handler.CreateCourse)          // Create new course
-		protected.PATCH("/courses/:id", handler.UpdateCourse)     // Update course
-		protected.DELETE("/courses/:id", handler.DeleteCourse)    // Delete course
+		protected.POST("/courses", handler.CreateCourse)                   // Create new course
+		protected.PATCH("/courses/:id", handler.UpdateCourse)              // Update course
+		protected.DELETE("/courses/:id", handler.DeleteCourse)             // Delete course
+		protected.POST("/courses/:id/duplicate", handler.DuplicateCourse)  // Deep-copy into a new unpublished course
+
+		// Review workflow: draft → submitted → changes_requested / published → archived
+		protected.POST("/courses/:id/submit", handler.SubmitCourse)                 // Submit for review (instructor)
+		protected.POST("/courses/:id/withdraw", handler.WithdrawCourse)             // Withdraw a submission back to draft
+		protected.POST("/courses/:id/archive", handler.ArchiveCourse)               // Take a published course off the catalog
+		protected.POST("/courses/:id/restore", handler.RestoreCourse)               // Archived course back to draft
+		protected.GET("/courses/:id/status-history", handler.GetCourseStatusHistory) // Status changes with comments
+		protected.GET("/courses/review-queue", handler.ListReviewQueue)             // Submitted courses (admin only)
+		protected.POST("/courses/:id/review", handler.ReviewCourse)                 // Approve or request changes (admin only)
 
 		// Lesson management (instructor only - authorization checked in service layer)
 		protected.POST("/courses/:id/lessons", handler.CreateLesson)  // Create lesson
@@ -40,8 +55,38 @@ func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middle
 		protected.DELETE("/lessons/:id", handler.DeleteLesson)         // Delete lesson
 		protected.PATCH("/lessons/reorder", handler.ReorderLessons)    // Reorder lessons
 
+		// Lesson revisions (instructor only - authorization checked in service layer)
+		protected.POST("/lessons/:id/publish", handler.PublishLesson)                                  // Publish pending draft
+		protected.GET("/lessons/:id/revisions", handler.ListLessonRevisions)                           // List revisions
+		protected.GET("/lessons/:id/revisions/diff", handler.DiffLessonRevisions)                      // Diff two revisions (?from=&to=)
+		protected.GET("/lessons/:id/revisions/:revisionId", handler.GetLessonRevision)                 // Get revision with content
+		protected.POST("/lessons/:id/revisions/:revisionId/restore", handler.RestoreLessonRevision)    // Roll back to a revision
+
+		// Section management (instructor only - authorization checked in service layer)
+		protected.POST("/courses/:id/sections", handler.CreateSection)                // Create section
+		protected.PATCH("/courses/:id/sections/reorder", handler.ReorderSections)     // Reorder sections
+		protected.PATCH("/courses/:id/sections/:sectionId", handler.UpdateSection)    // Update section
+		protected.DELETE("/courses/:id/sections/:sectionId", handler.DeleteSection)   // Delete section
+
+		// Prerequisites (instructor only - authorization checked in service layer)
+		protected.PUT("/courses/:id/prerequisites", handler.SetCoursePrerequisites) // Replace prerequisite courses
+
+		// Collaborators (team can view; instructor or admin manage, collaborators may remove themselves)
+		protected.GET("/courses/:id/collaborators", handler.GetCourseCollaborators)        // List course team
+		protected.POST("/courses/:id/collaborators", handler.AddCollaborator)              // Add collaborator by email
+		protected.PATCH("/courses/:id/collaborators/:userId", handler.UpdateCollaborator)  // Change role / revenue share
+		protected.DELETE("/courses/:id/collaborators/:userId", handler.RemoveCollaborator) // Remove collaborator
+
 		// Enrollment (student)
 		protected.POST("/courses/:id/enroll", handler.EnrollCourse)    // Enroll in course
 		protected.DELETE("/courses/:id/enroll", handler.UnenrollCourse) // Unenroll from course
+		protected.POST("/courses/:id/enroll/renew", handler.RenewEnrollment) // Renew a free course's access period
+
+		// Waitlist of full courses (joined by enrolling; students are promoted as seats open up)
+		protected.GET("/courses/:id/waitlist", handler.GetWaitlistPosition) // Own place on the waitlist
+		protected.DELETE("/courses/:id/waitlist", handler.LeaveWaitlist)    // Leave the waitlist
+
+		// Access periods (admin only - authorization checked in service layer)
+		protected.PATCH("/courses/:id/enrollments/:userId", handler.ExtendEnrollment) // Extend, set or remove a student's expiry
 	}
 }
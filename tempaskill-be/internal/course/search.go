@@ -0,0 +1,280 @@
+package course
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// Snippet settings
+const (
+	snippetRadius     = 60 // Characters of context on each side of the first match
+	maxLessonSnippets = 2  // Lesson snippets per course
+
+	// Shorter words are never in the FULLTEXT index, and a required one would match nothing
+	minSearchTermLength = 2
+)
+
+// searchCondition matches courses on their title/description or on the title/content
+// of one of their published lessons (FULLTEXT indexes ft_courses_search, ft_lessons_search)
+const searchCondition = `(MATCH(courses.title, courses.description) AGAINST (? IN BOOLEAN MODE)
+	OR EXISTS (
+		SELECT 1 FROM lessons search_lessons
+		WHERE search_lessons.course_id = courses.id
+		AND search_lessons.deleted_at IS NULL
+		AND search_lessons.is_published = true
+		AND MATCH(search_lessons.title, search_lessons.content) AGAINST (? IN BOOLEAN MODE)
+	))`
+
+// likeSearchCondition is the fallback for input without a word the FULLTEXT index can match
+const likeSearchCondition = `(courses.title LIKE ? OR courses.description LIKE ?)`
+
+// likeEscaper escapes LIKE wildcards so the fallback matches the input literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Explicit boolean syntax: +word, -word, ~word, <word, >word, "phrase", word*, (group)
+var booleanSyntax = regexp.MustCompile(`(^|\s)[+\-~<>]|["*()]`)
+
+var searchWord = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+
+// Characters kept in boolean input besides words and whitespace; anything else (e.g. the
+// "@" proximity operator, which InnoDB rejects outside phrases) becomes a separator
+const booleanOperators = `+-~<>"*()`
+
+// courseSearch is a parsed search query
+type courseSearch struct {
+	Boolean string   // AGAINST (... IN BOOLEAN MODE), decides what matches
+	Natural string   // AGAINST (... IN NATURAL LANGUAGE MODE), scores relevance
+	Terms   []string // Lowercase words highlighted in snippets
+	Like    string   // Set instead of the above when no word is long enough ("c++", "R")
+}
+
+// fulltext reports whether the search uses the FULLTEXT indexes (and so can rank and
+// highlight results)
+func (s *courseSearch) fulltext() bool {
+	return s != nil && s.Boolean != ""
+}
+
+// filter restricts a query on the courses table to the courses matching the search
+func (s *courseSearch) filter(db *gorm.DB) *gorm.DB {
+	if !s.fulltext() {
+		pattern := "%" + likeEscaper.Replace(s.Like) + "%"
+		return db.Where(likeSearchCondition, pattern, pattern)
+	}
+	return db.Where(searchCondition, s.Boolean, s.Boolean)
+}
+
+// parseSearch turns the search box input into FULLTEXT queries. Plain input requires every
+// word, matched as a prefix ("prog" finds "programming"); input that already uses boolean
+// syntax is passed through when it is well formed, and read as plain words otherwise.
+// Input without a word long enough for the index falls back to a LIKE match on the course
+// title and description. Returns nil when there is nothing to search for.
+func parseSearch(search string) *courseSearch {
+	search = strings.TrimSpace(search)
+	if search == "" {
+		return nil
+	}
+
+	var terms []string
+	for _, field := range strings.Fields(search) {
+		if strings.HasPrefix(field, "-") {
+			continue // Excluded words are never highlighted
+		}
+		for _, word := range searchWord.FindAllString(strings.ToLower(field), -1) {
+			if utf8.RuneCountInString(word) >= minSearchTermLength {
+				terms = append(terms, word)
+			}
+		}
+	}
+	if len(terms) == 0 {
+		return &courseSearch{Like: search}
+	}
+
+	boolean := normalizeBoolean(search)
+	if !booleanSyntax.MatchString(search) || !validBoolean(boolean) {
+		required := make([]string, len(terms))
+		for i, term := range terms {
+			required[i] = "+" + term + "*"
+		}
+		boolean = strings.Join(required, " ")
+	}
+
+	return &courseSearch{
+		Boolean: boolean,
+		Natural: strings.Join(terms, " "),
+		Terms:   terms,
+	}
+}
+
+// normalizeBoolean replaces the characters InnoDB's boolean parser does not expect with spaces
+func normalizeBoolean(search string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) || r == '_' || strings.ContainsRune(booleanOperators, r) {
+			return r
+		}
+		return ' '
+	}, search)
+}
+
+// validBoolean reports whether boolean syntax is well formed: quotes and parentheses are
+// balanced, groups are not empty, an operator starting a word is followed by a word, phrase
+// or group, and * ends a word. Malformed syntax is a MySQL error, not an empty result.
+func validBoolean(query string) bool {
+	runes := []rune(query)
+	depth := 0
+	inPhrase := false
+	isWord := func(i int) bool {
+		return i >= 0 && i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsNumber(runes[i]) || runes[i] == '_')
+	}
+	for i, r := range runes {
+		if inPhrase {
+			inPhrase = r != '"'
+			continue
+		}
+		switch r {
+		case '"':
+			inPhrase = true
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 || runes[i-1] == '(' {
+				return false
+			}
+		case '*':
+			if !isWord(i - 1) {
+				return false
+			}
+		case '+', '-', '~', '<', '>':
+			if isWord(i - 1) {
+				continue // Inside a word ("e-commerce") it is a separator
+			}
+			if next := i + 1; !isWord(next) && (next >= len(runes) || (runes[next] != '"' && runes[next] != '(')) {
+				return false
+			}
+		}
+	}
+	return depth == 0 && !inPhrase
+}
+
+// MDX markup removed before building snippets
+var (
+	mdxImage      = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	mdxLink       = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	mdxTag        = regexp.MustCompile(`<[^>]+>`)
+	mdxFormatting = regexp.MustCompile("[#*_`>|]+")
+)
+
+// plainText strips MDX markup and collapses whitespace
+func plainText(mdx string) string {
+	text := mdxImage.ReplaceAllString(mdx, " ")
+	text = mdxLink.ReplaceAllString(text, "$1")
+	text = mdxTag.ReplaceAllString(text, " ")
+	text = mdxFormatting.ReplaceAllString(text, " ")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// buildSnippet returns an HTML-escaped excerpt of text around the first search term,
+// with every term occurrence wrapped in <mark>. ok is false when no term occurs.
+func buildSnippet(text string, terms []string) (snippet string, ok bool) {
+	runes := []rune(plainText(text))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	needles := make([][]rune, len(terms))
+	for i, term := range terms {
+		needles[i] = []rune(term)
+	}
+
+	first := -1
+	for i := range lower {
+		if matchTermAt(lower, i, needles) > 0 {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return "", false
+	}
+
+	// Context window, snapped to word boundaries
+	start, end := first-snippetRadius, first+snippetRadius
+	if start <= 0 {
+		start = 0
+	} else if space := indexRune(runes[start:first], ' '); space >= 0 {
+		start += space + 1
+	}
+	if end >= len(runes) {
+		end = len(runes)
+	} else if space := lastIndexRune(runes[first:end], ' '); space > 0 {
+		end = first + space
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	plainFrom := start
+	for i := start; i < end; {
+		n := matchTermAt(lower, i, needles)
+		if n == 0 {
+			i++
+			continue
+		}
+		if i+n > end {
+			n = end - i
+		}
+		b.WriteString(html.EscapeString(string(runes[plainFrom:i])))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(runes[i : i+n])))
+		b.WriteString("</mark>")
+		i += n
+		plainFrom = i
+	}
+	b.WriteString(html.EscapeString(string(runes[plainFrom:end])))
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// matchTermAt returns the length of the longest term starting a word at position i, or 0
+func matchTermAt(text []rune, i int, terms [][]rune) int {
+	if i > 0 && isWordRune(text[i-1]) {
+		return 0
+	}
+	longest := 0
+	for _, term := range terms {
+		if len(term) > longest && i+len(term) <= len(text) && string(text[i:i+len(term)]) == string(term) {
+			longest = len(term)
+		}
+	}
+	return longest
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func indexRune(runes []rune, target rune) int {
+	for i, r := range runes {
+		if r == target {
+			return i
+		}
+	}
+	return -1
+}
+
+func lastIndexRune(runes []rune, target rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == target {
+			return i
+		}
+	}
+	return -1
+}
@@ -5,65 +5,158 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
 )
 
 var (
-	ErrCourseNotFound      = errors.New("course not found")
-	ErrLessonNotFound      = errors.New("lesson not found")
-	ErrUnauthorized        = errors.New("unauthorized access")
-	ErrAlreadyEnrolled     = errors.New("already enrolled in this course")
-	ErrNotEnrolled         = errors.New("not enrolled in this course")
-	ErrCourseNotPublished  = errors.New("course is not published")
-	ErrInvalidSlug         = errors.New("invalid slug format")
-	ErrNoLessonsToPublish  = errors.New("course must have at least one lesson to be published")
-	ErrInvalidCategory     = errors.New("invalid category")
+	ErrCourseNotFound       = errors.New("course not found")
+	ErrLessonNotFound       = errors.New("lesson not found")
+	ErrUnauthorized         = errors.New("unauthorized access")
+	ErrAlreadyEnrolled      = errors.New("already enrolled in this course")
+	ErrNotEnrolled          = errors.New("not enrolled in this course")
+	ErrCourseNotPublished   = errors.New("course is not published")
+	ErrInvalidSlug          = errors.New("invalid slug format")
+	ErrNoLessonsToPublish   = errors.New("course must have at least one lesson to be published")
+	ErrInvalidCategory      = errors.New("invalid category")
+	ErrSectionNotFound      = errors.New("section not found")
+	ErrRevisionNotFound     = errors.New("lesson revision not found")
+	ErrNoDraftToPublish     = errors.New("lesson has no draft to publish")
+	ErrContentRequired      = errors.New("text lessons require content")
+	ErrVideoRequired        = errors.New("video lessons require a video payload")
+	ErrQuizRequired         = errors.New("quiz lessons require a quiz payload")
+	ErrAssignmentRequired   = errors.New("assignment lessons require an assignment payload")
+	ErrQuizNotFound         = errors.New("quiz not found in this course")
+	ErrInvalidPrerequisite  = errors.New("prerequisite course not found or is the course itself")
+	ErrPrerequisiteCycle    = errors.New("prerequisites would create a cycle")
+	ErrInvalidReleaseRule   = errors.New("invalid release rule: fixed_date requires at, days_after_enrollment requires after_days, after_lesson requires another lesson of the course without a circular chain")
+	ErrCollaboratorNotFound = errors.New("collaborator not found")
+	ErrCollaboratorExists   = errors.New("user is already a collaborator on this course")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrInvalidCollaborator  = errors.New("the course instructor cannot be added as collaborator")
+	ErrInvalidRevenueShare  = errors.New("only co-instructors can receive a revenue share and shares cannot exceed 100% in total")
+	ErrInvalidTag           = errors.New("tags must contain letters or digits and a course can have at most 10 tags")
+	ErrTagNotFound          = errors.New("tag not found")
+	ErrUnknownTag           = errors.New("only admins can create new tags, pick an existing tag")
+	ErrSlugTaken            = errors.New("course slug is already taken")
+	ErrInvalidStatusChange  = errors.New("this status change is not allowed from the course's current status")
+	ErrCommentRequired      = errors.New("a comment is required when requesting changes")
+	ErrInvalidCursor        = cursor.ErrInvalidCursor
+	ErrEnrollmentExpired    = errors.New("your access to this course has expired; renew it to continue")
+	ErrPaymentRequired      = errors.New("paid courses are renewed by purchasing them again")
+	ErrInvalidExtension     = errors.New("set exactly one of days, expires_at (in the future) or lifetime")
+	ErrNotWaitlisted        = errors.New("not on the waitlist of this course")
 )
 
-// Valid categories
-var ValidCategories = []string{
-	"Web Development",
-	"Mobile Development",
-	"Backend Development",
-	"Data Science",
-	"DevOps",
-	"Design",
-	"Database",
+// PrerequisitesNotMetError is returned by EnrollCourse (and by buying a course) when required
+// courses are not completed
+type PrerequisitesNotMetError struct {
+	Missing []*PrerequisiteResponse
+}
+
+func (e *PrerequisitesNotMetError) Error() string {
+	titles := make([]string, 0, len(e.Missing))
+	for _, course := range e.Missing {
+		titles = append(titles, course.Title)
+	}
+	return "complete the prerequisite courses first: " + strings.Join(titles, ", ")
+}
+
+// WaitlistedError is returned by EnrollCourse when the course is full and the student
+// was put on (or already is on) its waitlist
+type WaitlistedError struct {
+	Position int
+}
+
+func (e *WaitlistedError) Error() string {
+	return fmt.Sprintf("this course is full; you are number %d on the waitlist", e.Position)
 }
 
 type Service interface {
 	// Course operations
-	CreateCourse(ctx context.Context, userID uint, req *CreateCourseRequest) (*Course, error)
+	CreateCourse(ctx context.Context, userID uint, userRole string, req *CreateCourseRequest) (*Course, error)
 	GetCourseByID(ctx context.Context, userID uint, id uint) (*CourseResponse, error)
 	GetCourseBySlug(ctx context.Context, userID uint, slug string) (*CourseResponse, error)
 	ListCourses(ctx context.Context, userID uint, query *CourseListQuery) (*CourseListResponse, error)
+	GetCourseFacets(ctx context.Context, query *CourseListQuery) (*CourseFacetsResponse, error)
 	UpdateCourse(ctx context.Context, userID uint, userRole string, courseID uint, req *UpdateCourseRequest) (*Course, error)
 	DeleteCourse(ctx context.Context, userID uint, userRole string, courseID uint) error
+	DuplicateCourse(ctx context.Context, userID uint, userRole string, courseID uint, req *DuplicateCourseRequest) (*Course, error)
+
+	// Review workflow operations
+	ChangeCourseStatus(ctx context.Context, userID uint, userRole string, courseID uint, status string, req *CourseStatusRequest) (*Course, error)
+	ReviewCourse(ctx context.Context, userID uint, userRole string, courseID uint, req *ReviewCourseRequest) (*Course, error)
+	ListReviewQueue(ctx context.Context, userRole string, query *CourseListQuery) (*CourseListResponse, error)
+	GetCourseStatusHistory(ctx context.Context, userID uint, userRole string, courseID uint) ([]*CourseStatusEvent, error)
+
+	// Tag operations
+	ListPopularTags(ctx context.Context, limit int) ([]*TagWithCount, error)
+	GetTagPage(ctx context.Context, userID uint, slug string, query *CourseListQuery) (*TagPageResponse, error)
 
 	// Lesson operations
 	CreateLesson(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateLessonRequest) (*Lesson, error)
 	GetLesson(ctx context.Context, userID uint, lessonID uint) (*LessonResponse, error)
-	GetCourseLessons(ctx context.Context, userID uint, courseID uint) ([]*LessonResponse, error)
+	CheckLessonAccess(ctx context.Context, userID uint, lessonID uint) (*LessonAccess, error)
+	GetCourseLessons(ctx context.Context, userID uint, courseID uint) (*CourseLessonsResponse, error)
 	UpdateLesson(ctx context.Context, userID uint, userRole string, lessonID uint, req *UpdateLessonRequest) (*Lesson, error)
 	DeleteLesson(ctx context.Context, userID uint, userRole string, lessonID uint) error
 	ReorderLessons(ctx context.Context, userID uint, userRole string, updates []LessonOrderUpdate) error
 
+	// Lesson revision operations
+	PublishLesson(ctx context.Context, userID uint, userRole string, lessonID uint) (*Lesson, error)
+	ListLessonRevisions(ctx context.Context, userID uint, userRole string, lessonID uint) ([]*LessonRevisionResponse, error)
+	GetLessonRevision(ctx context.Context, userID uint, userRole string, lessonID uint, revisionID uint) (*LessonRevisionResponse, error)
+	DiffLessonRevisions(ctx context.Context, userID uint, userRole string, lessonID uint, fromID uint, toID uint) (*LessonRevisionDiffResponse, error)
+	RestoreLessonRevision(ctx context.Context, userID uint, userRole string, lessonID uint, revisionID uint) (*Lesson, error)
+
+	// Section operations
+	CreateSection(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateSectionRequest) (*Section, error)
+	GetCourseSections(ctx context.Context, courseID uint) ([]*SectionResponse, error)
+	UpdateSection(ctx context.Context, userID uint, userRole string, courseID uint, sectionID uint, req *UpdateSectionRequest) (*Section, error)
+	DeleteSection(ctx context.Context, userID uint, userRole string, courseID uint, sectionID uint) error
+	ReorderSections(ctx context.Context, userID uint, userRole string, courseID uint, updates []SectionOrderUpdate) error
+
+	// Prerequisite operations
+	GetCoursePrerequisites(ctx context.Context, userID uint, courseID uint) ([]*PrerequisiteResponse, error)
+	CheckPrerequisites(ctx context.Context, userID uint, courseID uint) error
+	SetCoursePrerequisites(ctx context.Context, userID uint, userRole string, courseID uint, req *SetPrerequisitesRequest) ([]*PrerequisiteResponse, error)
+
+	// Collaborator operations
+	GetCourseCollaborators(ctx context.Context, userID uint, userRole string, courseID uint) ([]*CollaboratorResponse, error)
+	AddCollaborator(ctx context.Context, userID uint, userRole string, courseID uint, req *AddCollaboratorRequest) (*CollaboratorResponse, error)
+	UpdateCollaborator(ctx context.Context, userID uint, userRole string, courseID uint, collaboratorID uint, req *UpdateCollaboratorRequest) (*CollaboratorResponse, error)
+	RemoveCollaborator(ctx context.Context, userID uint, userRole string, courseID uint, collaboratorID uint) error
+
 	// Enrollment operations
 	EnrollCourse(ctx context.Context, userID uint, courseID uint) error
 	UnenrollCourse(ctx context.Context, userID uint, courseID uint) error
+	RenewEnrollment(ctx context.Context, userID uint, courseID uint) (*Enrollment, error)
+	GetWaitlistPosition(ctx context.Context, userID uint, courseID uint) (int, error)
+	LeaveWaitlist(ctx context.Context, userID uint, courseID uint) error
+	ExtendEnrollment(ctx context.Context, userRole string, courseID uint, studentID uint, req *ExtendEnrollmentRequest) (*Enrollment, error)
+}
+
+// Notifier delivers in-app notifications (implemented by the notification module)
+type Notifier interface {
+	Notify(ctx context.Context, userID uint, notificationType, title, message, link string) error
 }
 
 type service struct {
-	repo Repository
+	repo     Repository
+	notifier Notifier
 }
 
-func NewService(repo Repository) Service {
-	return &service{repo: repo}
+func NewService(repo Repository, notifier Notifier) Service {
+	return &service{repo: repo, notifier: notifier}
 }
 
-// Helper: Generate slug from title
-func generateSlug(title string) string {
+// GenerateSlug turns a title into a URL slug ("Intro to Go!" => "intro-to-go")
+func GenerateSlug(title string) string {
 	// Convert to lowercase
 	slug := strings.ToLower(title)
 	
@@ -77,42 +170,152 @@ func generateSlug(title string) string {
 	return slug
 }
 
-// Helper: Validate category
-func isValidCategory(category string) bool {
-	for _, valid := range ValidCategories {
-		if category == valid {
-			return true
+// Helper: Validate category against the admin-managed categories table
+func (s *service) validateCategory(ctx context.Context, category string) error {
+	exists, err := s.repo.CategoryExists(ctx, category)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrInvalidCategory
+	}
+	return nil
+}
+
+// Tag limits
+const (
+	maxCourseTags      = 10
+	defaultPopularTags = 20
+	maxRelatedTags     = 10
+)
+
+// Helper: Normalize tag names (trimmed, lowercase, single spaces) and drop duplicates by slug
+func normalizeTags(names []string) ([]*Tag, error) {
+	tags := make([]*Tag, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+		slug := GenerateSlug(name)
+		if slug == "" {
+			return nil, ErrInvalidTag
+		}
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		tags = append(tags, &Tag{Name: name, Slug: slug})
+	}
+	if len(tags) > maxCourseTags {
+		return nil, ErrInvalidTag
+	}
+	return tags, nil
+}
+
+// Helper: Tags are a shared vocabulary, so only admins may introduce new ones
+func (s *service) checkNewTags(ctx context.Context, tags []*Tag, userRole string) error {
+	if userRole == "admin" || len(tags) == 0 {
+		return nil
+	}
+	slugs := make([]string, len(tags))
+	for i, tag := range tags {
+		slugs[i] = tag.Slug
+	}
+	existing, err := s.repo.FindTagsBySlugs(ctx, slugs)
+	if err != nil {
+		return err
+	}
+	if len(existing) < len(tags) {
+		return ErrUnknownTag
+	}
+	return nil
+}
+
+// Helper: Split the comma-separated tags filter into unique slugs
+func parseTagSlugs(tags string) []string {
+	var slugs []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(tags, ",") {
+		slug := GenerateSlug(tag)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		slugs = append(slugs, slug)
+	}
+	return slugs
+}
+
+// Helper: Copy saved tags onto a course for the response
+func tagValues(tags []*Tag) []Tag {
+	values := make([]Tag, len(tags))
+	for i, tag := range tags {
+		values[i] = *tag
+	}
+	return values
+}
+
+// Helper: Load the tags of the given courses into their responses
+func (s *service) attachCourseTags(ctx context.Context, courses []*CourseResponse) error {
+	courseIDs := make([]uint, len(courses))
+	for i, c := range courses {
+		courseIDs[i] = c.ID
+	}
+	tags, err := s.repo.FindTagsByCourseIDs(ctx, courseIDs)
+	if err != nil {
+		return err
+	}
+	for _, c := range courses {
+		c.Tags = tags[c.ID]
+		if c.Tags == nil {
+			c.Tags = []Tag{}
 		}
 	}
-	return false
+	return nil
 }
 
 // Course operations
 
-func (s *service) CreateCourse(ctx context.Context, userID uint, req *CreateCourseRequest) (*Course, error) {
+func (s *service) CreateCourse(ctx context.Context, userID uint, userRole string, req *CreateCourseRequest) (*Course, error) {
 	// Validate category
-	if !isValidCategory(req.Category) {
-		return nil, ErrInvalidCategory
+	if err := s.validateCategory(ctx, req.Category); err != nil {
+		return nil, err
 	}
 
-	slug := generateSlug(req.Title)
-	
-	course := &Course{
-		Title:        req.Title,
-		Slug:         slug,
-		Description:  req.Description,
-		ThumbnailURL: req.ThumbnailURL,
-		Category:     req.Category,
-		Difficulty:   req.Difficulty,
-		InstructorID: userID,
-		Price:        req.Price,
-		IsPublished:  false,
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkNewTags(ctx, tags, userRole); err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.CreateCourse(ctx, course); err != nil {
+	course := &Course{
+		Title:          req.Title,
+		Description:    req.Description,
+		ThumbnailURL:   req.ThumbnailURL,
+		Category:       req.Category,
+		Difficulty:     req.Difficulty,
+		InstructorID:   userID,
+		Price:          req.Price,
+		AccessDays:     req.AccessDays,
+		MaxEnrollments: req.MaxEnrollments,
+		IsPublished:    false,
+		Status:         CourseStatusDraft,
+	}
+
+	if err := s.saveWithUniqueSlug(ctx, course, GenerateSlug(req.Title), func() error {
+		return s.repo.CreateCourse(ctx, course)
+	}); err != nil {
 		return nil, err
 	}
 
+	if len(tags) > 0 {
+		if err := s.repo.ReplaceCourseTags(ctx, course.ID, tags); err != nil {
+			return nil, err
+		}
+		course.Tags = tagValues(tags)
+	}
+
 	return course, nil
 }
 
@@ -129,18 +332,31 @@ func (s *service) GetCourseByID(ctx context.Context, userID uint, id uint) (*Cou
 	}
 
 	// Check enrollment status
-	isEnrolled := false
+	var enrollment *Enrollment
 	if userID > 0 {
-		isEnrolled, _ = s.repo.IsUserEnrolled(ctx, userID, id)
+		enrollment, _ = s.repo.FindEnrollment(ctx, userID, id)
+	}
+
+	resp := course.ToResponse(lessonCount, enrollment != nil && enrollment.IsActive(time.Now()))
+	s.setViewerAccess(ctx, resp, course, userID, enrollment)
+	resp.Prerequisites, _ = s.GetCoursePrerequisites(ctx, userID, course.ID)
+	if err := s.attachCourseTags(ctx, []*CourseResponse{resp}); err != nil {
+		return nil, err
 	}
 
-	return course.ToResponse(lessonCount, isEnrolled), nil
+	return resp, nil
 }
 
 func (s *service) GetCourseBySlug(ctx context.Context, userID uint, slug string) (*CourseResponse, error) {
 	course, err := s.repo.FindCourseBySlug(ctx, slug)
+	oldSlug := false
 	if err != nil {
-		return nil, ErrCourseNotFound
+		// Renamed courses are still found by their old slugs
+		course, err = s.repo.FindCourseBySlugHistory(ctx, slug)
+		if err != nil {
+			return nil, ErrCourseNotFound
+		}
+		oldSlug = true
 	}
 
 	// Count lessons
@@ -150,17 +366,27 @@ func (s *service) GetCourseBySlug(ctx context.Context, userID uint, slug string)
 	}
 
 	// Check enrollment status
-	isEnrolled := false
+	var enrollment *Enrollment
 	if userID > 0 {
-		isEnrolled, _ = s.repo.IsUserEnrolled(ctx, userID, course.ID)
+		enrollment, _ = s.repo.FindEnrollment(ctx, userID, course.ID)
+	}
+
+	resp := course.ToResponse(lessonCount, enrollment != nil && enrollment.IsActive(time.Now()))
+	s.setViewerAccess(ctx, resp, course, userID, enrollment)
+	resp.Prerequisites, _ = s.GetCoursePrerequisites(ctx, userID, course.ID)
+	if err := s.attachCourseTags(ctx, []*CourseResponse{resp}); err != nil {
+		return nil, err
+	}
+	if oldSlug {
+		resp.CanonicalSlug = course.Slug
 	}
 
-	return course.ToResponse(lessonCount, isEnrolled), nil
+	return resp, nil
 }
 
 func (s *service) ListCourses(ctx context.Context, userID uint, query *CourseListQuery) (*CourseListResponse, error) {
 	// Use optimized query that solves N+1 problem (1 query instead of 201 for 100 courses)
-	coursesWithMeta, total, err := s.repo.FindAllCoursesWithMeta(ctx, userID, query)
+	coursesWithMeta, total, nextCursor, err := s.repo.FindAllCoursesWithMeta(ctx, userID, query)
 	if err != nil {
 		return nil, err
 	}
@@ -170,6 +396,16 @@ func (s *service) ListCourses(ctx context.Context, userID uint, query *CourseLis
 	for _, courseWithMeta := range coursesWithMeta {
 		courseResponses = append(courseResponses, courseWithMeta.ToResponse())
 	}
+	if err := s.attachCourseTags(ctx, courseResponses); err != nil {
+		return nil, err
+	}
+
+	// Show where the search matched
+	if search := parseSearch(query.Search); search.fulltext() && len(courseResponses) > 0 {
+		if err := s.attachSearchSnippets(ctx, courseResponses, search); err != nil {
+			return nil, err
+		}
+	}
 
 	// Calculate pagination
 	page := query.Page
@@ -189,25 +425,133 @@ func (s *service) ListCourses(ctx context.Context, userID uint, query *CourseLis
 			Limit:      limit,
 			Total:      total,
 			TotalPages: totalPages,
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
 		},
 	}, nil
 }
 
+// GetCourseFacets returns the facet counts for the catalog sidebar under the current filters
+func (s *service) GetCourseFacets(ctx context.Context, query *CourseListQuery) (*CourseFacetsResponse, error) {
+	counts, err := s.repo.FindCourseFacetCounts(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.FindCategoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildCourseFacets(counts, categories), nil
+}
+
+// Helper: Turn raw facet counts into the response, listing every known value (zero counts
+// included) in display order. Unknown categories found in the data are appended.
+func buildCourseFacets(counts *CourseFacetCounts, categories []string) *CourseFacetsResponse {
+	facets := &CourseFacetsResponse{
+		Total:        counts.Total,
+		Categories:   []*FacetCount{},
+		Difficulties: []*FacetCount{},
+		PriceBuckets: []*FacetCount{},
+		Instructors:  counts.Instructors,
+	}
+	if facets.Instructors == nil {
+		facets.Instructors = []*InstructorFacet{}
+	}
+
+	known := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		known[category] = true
+		facets.Categories = append(facets.Categories, &FacetCount{Value: category, Label: category, Count: counts.Categories[category]})
+	}
+	var unknown []string
+	for category := range counts.Categories {
+		if !known[category] {
+			unknown = append(unknown, category)
+		}
+	}
+	sort.Strings(unknown)
+	for _, category := range unknown {
+		facets.Categories = append(facets.Categories, &FacetCount{Value: category, Label: category, Count: counts.Categories[category]})
+	}
+
+	for _, difficulty := range []struct{ value, label string }{
+		{"beginner", "Beginner"},
+		{"intermediate", "Intermediate"},
+		{"advanced", "Advanced"},
+	} {
+		facets.Difficulties = append(facets.Difficulties, &FacetCount{Value: difficulty.value, Label: difficulty.label, Count: counts.Difficulties[difficulty.value]})
+	}
+
+	for _, bucket := range PriceBuckets {
+		facets.PriceBuckets = append(facets.PriceBuckets, &FacetCount{Value: bucket.Key, Label: bucket.Label, Count: counts.PriceBuckets[bucket.Key]})
+	}
+
+	return facets
+}
+
+// Helper: Add snippets of the matched title, description and best matching lessons to search results
+func (s *service) attachSearchSnippets(ctx context.Context, courses []*CourseResponse, search *courseSearch) error {
+	courseIDs := make([]uint, len(courses))
+	for i, c := range courses {
+		courseIDs[i] = c.ID
+	}
+	lessons, err := s.repo.FindSearchMatchedLessons(ctx, courseIDs, search.Boolean)
+	if err != nil {
+		return err
+	}
+	lessonsByCourse := make(map[uint][]*Lesson)
+	for _, lesson := range lessons {
+		lessonsByCourse[lesson.CourseID] = append(lessonsByCourse[lesson.CourseID], lesson)
+	}
+
+	for _, c := range courses {
+		if text, ok := buildSnippet(c.Title, search.Terms); ok {
+			c.Snippets = append(c.Snippets, &SearchSnippet{Field: "title", Text: text})
+		}
+		if text, ok := buildSnippet(c.Description, search.Terms); ok {
+			c.Snippets = append(c.Snippets, &SearchSnippet{Field: "description", Text: text})
+		}
+
+		added := 0
+		for _, lesson := range lessonsByCourse[c.ID] {
+			if added == maxLessonSnippets {
+				break
+			}
+			// Prefer the content; a lesson may match on its title alone
+			text, ok := buildSnippet(lesson.Content, search.Terms)
+			if !ok {
+				text, ok = buildSnippet(lesson.Title, search.Terms)
+			}
+			if !ok {
+				continue
+			}
+			lessonID := lesson.ID
+			c.Snippets = append(c.Snippets, &SearchSnippet{
+				Field:       "lesson",
+				LessonID:    &lessonID,
+				LessonTitle: lesson.Title,
+				Text:        text,
+			})
+			added++
+		}
+	}
+	return nil
+}
+
 func (s *service) UpdateCourse(ctx context.Context, userID uint, userRole string, courseID uint, req *UpdateCourseRequest) (*Course, error) {
 	course, err := s.repo.FindCourseByID(ctx, courseID)
 	if err != nil {
 		return nil, ErrCourseNotFound
 	}
 
-	// Check authorization - allow instructor or admin
-	if course.InstructorID != userID && userRole != "admin" {
+	// Check authorization - allow instructor, co-instructors or admin
+	if !s.canAccess(ctx, course, userID, userRole, PermissionManageCourse) {
 		return nil, ErrUnauthorized
 	}
 
 	// Update fields if provided
 	if req.Title != nil {
 		course.Title = *req.Title
-		course.Slug = generateSlug(*req.Title)
 	}
 	if req.Description != nil {
 		course.Description = *req.Description
@@ -217,33 +561,70 @@ func (s *service) UpdateCourse(ctx context.Context, userID uint, userRole string
 	}
 	if req.Category != nil {
 		// Validate category
-		if !isValidCategory(*req.Category) {
-			return nil, ErrInvalidCategory
+		if err := s.validateCategory(ctx, *req.Category); err != nil {
+			return nil, err
 		}
 		course.Category = *req.Category
 	}
+	var tags []*Tag
+	if req.Tags != nil {
+		if tags, err = normalizeTags(*req.Tags); err != nil {
+			return nil, err
+		}
+		if err := s.checkNewTags(ctx, tags, userRole); err != nil {
+			return nil, err
+		}
+	}
 	if req.Difficulty != nil {
 		course.Difficulty = *req.Difficulty
 	}
 	if req.Price != nil {
 		course.Price = *req.Price
 	}
+	if req.AccessDays != nil {
+		course.AccessDays = *req.AccessDays
+	}
+	if req.MaxEnrollments != nil {
+		course.MaxEnrollments = *req.MaxEnrollments
+	}
+
+	save := func() error { return s.repo.UpdateCourse(ctx, course) }
+	if req.Title != nil && !req.KeepSlug {
+		// A changed slug moves the old one to the slug history (see repository.UpdateCourse)
+		err = s.saveWithUniqueSlug(ctx, course, GenerateSlug(*req.Title), save)
+	} else {
+		err = save()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A raised (or removed) seat cap lets waitlisted students in
+	if req.MaxEnrollments != nil {
+		s.promoteWaitlist(ctx, course)
+	}
+
+	// is_published is kept for older clients: true publishes (admins) or submits the course
+	// for review, false archives a published course or withdraws a submission
 	if req.IsPublished != nil {
-		// Validate: Course must have at least 1 lesson to be published
-		if *req.IsPublished {
-			lessonCount, err := s.repo.CountLessonsByCourseID(ctx, courseID)
-			if err != nil {
+		if status := publishShortcutStatus(course.Status, *req.IsPublished, userRole == "admin"); status != "" {
+			if err := s.changeCourseStatus(ctx, course, status, userID, userRole == "admin", ""); err != nil {
 				return nil, err
 			}
-			if lessonCount == 0 {
-				return nil, ErrNoLessonsToPublish
-			}
 		}
-		course.IsPublished = *req.IsPublished
 	}
 
-	if err := s.repo.UpdateCourse(ctx, course); err != nil {
-		return nil, err
+	if req.Tags != nil {
+		if err := s.repo.ReplaceCourseTags(ctx, course.ID, tags); err != nil {
+			return nil, err
+		}
+		course.Tags = tagValues(tags)
+	} else {
+		tagsByCourse, err := s.repo.FindTagsByCourseIDs(ctx, []uint{course.ID})
+		if err != nil {
+			return nil, err
+		}
+		course.Tags = tagsByCourse[course.ID]
 	}
 
 	return course, nil
@@ -255,7 +636,7 @@ func (s *service) DeleteCourse(ctx context.Context, userID uint, userRole string
 		return ErrCourseNotFound
 	}
 
-	// Check authorization - allow instructor or admin
+	// Check authorization - allow instructor or admin (collaborators cannot delete the course)
 	if course.InstructorID != userID && userRole != "admin" {
 		return ErrUnauthorized
 	}
@@ -263,186 +644,1558 @@ func (s *service) DeleteCourse(ctx context.Context, userID uint, userRole string
 	return s.repo.DeleteCourse(ctx, courseID)
 }
 
-// Lesson operations
-
-func (s *service) CreateLesson(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateLessonRequest) (*Lesson, error) {
-	// Verify course exists and user is instructor
-	course, err := s.repo.FindCourseByID(ctx, courseID)
+// DuplicateCourse deep-copies a course into a new unpublished course owned by the caller
+func (s *service) DuplicateCourse(ctx context.Context, userID uint, userRole string, courseID uint, req *DuplicateCourseRequest) (*Course, error) {
+	source, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionManageCourse)
 	if err != nil {
-		return nil, ErrCourseNotFound
-	}
-
-	// Check authorization - allow instructor or admin
-	if course.InstructorID != userID && userRole != "admin" {
-		return nil, ErrUnauthorized
+		return nil, err
 	}
 
-	slug := generateSlug(req.Title)
-
-	lesson := &Lesson{
-		CourseID:    courseID,
-		Title:       req.Title,
-		Slug:        slug,
-		Content:     req.Content,
-		OrderIndex:  req.OrderIndex,
-		Duration:    req.Duration,
-		IsPublished: req.IsPublished,
+	title := copyTitle(source.Title)
+	if req != nil && req.Title != nil {
+		title = *req.Title
 	}
 
-	if err := s.repo.CreateLesson(ctx, lesson); err != nil {
+	course := &Course{
+		Title:          title,
+		Description:    source.Description,
+		ThumbnailURL:   source.ThumbnailURL,
+		Category:       source.Category,
+		Difficulty:     source.Difficulty,
+		InstructorID:   userID,
+		Price:          source.Price,
+		AccessDays:     source.AccessDays,
+		MaxEnrollments: source.MaxEnrollments,
+		IsPublished:    false,
+	}
+
+	if err := s.saveWithUniqueSlug(ctx, course, GenerateSlug(title), func() error {
+		return s.repo.DuplicateCourse(ctx, source.ID, course, userID)
+	}); err != nil {
 		return nil, err
 	}
 
-	return lesson, nil
+	return course, nil
 }
 
-func (s *service) GetLesson(ctx context.Context, userID uint, lessonID uint) (*LessonResponse, error) {
-	lesson, err := s.repo.FindLessonByID(ctx, lessonID)
-	if err != nil {
-		return nil, ErrLessonNotFound
-	}
+// Review workflow operations
+
+// Helper: Target status of the is_published shortcut ("" = nothing to change)
+func publishShortcutStatus(current string, publish bool, isAdmin bool) string {
+	switch {
+	case publish && isAdmin && current != CourseStatusPublished:
+		return CourseStatusPublished
+	case publish && !isAdmin && (current == CourseStatusDraft || current == CourseStatusChangesRequested):
+		return CourseStatusSubmitted
+	case !publish && current == CourseStatusPublished:
+		return CourseStatusArchived
+	case !publish && current == CourseStatusSubmitted:
+		return CourseStatusDraft
+	}
+	return ""
+}
 
-	// Check if user is enrolled or is the instructor
-	course, err := s.repo.FindCourseByID(ctx, lesson.CourseID)
-	if err != nil {
-		return nil, ErrCourseNotFound
+// Helper: Move a course to a new status, stamp the matching timestamps, record the change
+// and notify the instructor when someone else made it
+func (s *service) changeCourseStatus(ctx context.Context, course *Course, status string, actorID uint, isAdmin bool, comment string) error {
+	if !CanTransition(course.Status, status, isAdmin) {
+		return ErrInvalidStatusChange
 	}
 
-	isInstructor := course.InstructorID == userID
-	isEnrolled := false
-	if userID > 0 {
-		isEnrolled, _ = s.repo.IsUserEnrolled(ctx, userID, lesson.CourseID)
+	// Only courses with lessons can be reviewed and published
+	if status == CourseStatusSubmitted || status == CourseStatusPublished {
+		lessonCount, err := s.repo.CountLessonsByCourseID(ctx, course.ID)
+		if err != nil {
+			return err
+		}
+		if lessonCount == 0 {
+			return ErrNoLessonsToPublish
+		}
 	}
 
-	// Only enrolled users or instructor can see unpublished lessons
-	if !lesson.IsPublished && !isInstructor && !isEnrolled {
-		return nil, ErrUnauthorized
+	now := time.Now()
+	event := &CourseStatusEvent{
+		CourseID:   course.ID,
+		FromStatus: course.Status,
+		ToStatus:   status,
+		ActorID:    actorID,
+		Comment:    comment,
+	}
+	course.Status = status
+	course.IsPublished = status == CourseStatusPublished
+	switch status {
+	case CourseStatusSubmitted:
+		course.SubmittedAt = &now
+	case CourseStatusChangesRequested:
+		course.ReviewedAt, course.ReviewedBy = &now, &actorID
+	case CourseStatusPublished:
+		course.ReviewedAt, course.ReviewedBy = &now, &actorID
+		course.PublishedAt = &now
+	case CourseStatusArchived:
+		course.ArchivedAt = &now
+	}
+
+	if err := s.repo.UpdateCourseStatus(ctx, course, event); err != nil {
+		return err
 	}
 
-	// Include content only for enrolled users or instructor
-	includeContent := isInstructor || isEnrolled
-	return lesson.ToResponse(includeContent), nil
+	if actorID != course.InstructorID {
+		s.notifyStatusChange(ctx, course, event)
+	}
+	return nil
 }
 
-func (s *service) GetCourseLessons(ctx context.Context, userID uint, courseID uint) ([]*LessonResponse, error) {
-	course, err := s.repo.FindCourseByID(ctx, courseID)
-	if err != nil {
-		return nil, ErrCourseNotFound
+// Helper: Tell the instructor about a review decision or an archive made by someone else.
+// Notifications are best effort: the status change is already saved.
+func (s *service) notifyStatusChange(ctx context.Context, course *Course, event *CourseStatusEvent) {
+	var notificationType, title, message, link string
+	switch event.ToStatus {
+	case CourseStatusPublished:
+		notificationType = NotificationCourseApproved
+		title = "Course approved"
+		message = fmt.Sprintf("\"%s\" has been approved and is now published.", course.Title)
+		link = "/courses/" + course.Slug
+	case CourseStatusChangesRequested:
+		notificationType = NotificationCourseChangesRequested
+		title = "Changes requested"
+		message = fmt.Sprintf("A reviewer asked for changes to \"%s\".", course.Title)
+		link = fmt.Sprintf("/instructor/courses/%d/edit", course.ID)
+	case CourseStatusArchived:
+		notificationType = NotificationCourseArchived
+		title = "Course archived"
+		message = fmt.Sprintf("\"%s\" was taken off the catalog.", course.Title)
+		link = fmt.Sprintf("/instructor/courses/%d/edit", course.ID)
+	default:
+		return
+	}
+	if event.Comment != "" {
+		message += "\n\n" + event.Comment
+	}
+
+	if err := s.notifier.Notify(ctx, course.InstructorID, notificationType, title, message, link); err != nil {
+		logger.Warn("Failed to notify instructor of course status change",
+			zap.Error(err),
+			zap.Uint("course_id", course.ID),
+			zap.String("status", event.ToStatus),
+		)
 	}
+}
 
-	lessons, err := s.repo.FindLessonsByCourseID(ctx, courseID)
+// ChangeCourseStatus moves a course through the instructor side of the workflow: submit,
+// withdraw a submission, archive or restore an archived course to draft
+func (s *service) ChangeCourseStatus(ctx context.Context, userID uint, userRole string, courseID uint, status string, req *CourseStatusRequest) (*Course, error) {
+	course, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionManageCourse)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if user is enrolled or is the instructor
-	isInstructor := course.InstructorID == userID
-	isEnrolled := false
-	if userID > 0 {
-		isEnrolled, _ = s.repo.IsUserEnrolled(ctx, userID, courseID)
+	// Publishing goes through ReviewCourse
+	if status == CourseStatusPublished || status == CourseStatusChangesRequested {
+		return nil, ErrInvalidStatusChange
 	}
-
-	// Convert to response format
-	responses := make([]*LessonResponse, 0, len(lessons))
-	for _, lesson := range lessons {
-		// Show published lessons to everyone, unpublished only to instructor/enrolled
-		if lesson.IsPublished || isInstructor || isEnrolled {
-			// Don't include content in list view
-			responses = append(responses, lesson.ToResponse(false))
-		}
+	if err := s.changeCourseStatus(ctx, course, status, userID, false, req.Comment); err != nil {
+		return nil, err
 	}
-	return responses, nil
+	return course, nil
 }
 
-func (s *service) UpdateLesson(ctx context.Context, userID uint, userRole string, lessonID uint, req *UpdateLessonRequest) (*Lesson, error) {
-	lesson, err := s.repo.FindLessonByID(ctx, lessonID)
-	if err != nil {
-		return nil, ErrLessonNotFound
+// ReviewCourse records an admin decision on a submitted course
+func (s *service) ReviewCourse(ctx context.Context, userID uint, userRole string, courseID uint, req *ReviewCourseRequest) (*Course, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
 	}
-
-	// Verify authorization
-	course, err := s.repo.FindCourseByID(ctx, lesson.CourseID)
+	course, err := s.repo.FindCourseByID(ctx, courseID)
 	if err != nil {
 		return nil, ErrCourseNotFound
 	}
 
-	// Check authorization - allow instructor or admin
-	if course.InstructorID != userID && userRole != "admin" {
-		return nil, ErrUnauthorized
+	status := CourseStatusPublished
+	if req.Decision == ReviewDecisionRequestChanges {
+		if strings.TrimSpace(req.Comment) == "" {
+			return nil, ErrCommentRequired
+		}
+		status = CourseStatusChangesRequested
 	}
 
-	// Update fields
-	if req.Title != nil {
-		lesson.Title = *req.Title
-		lesson.Slug = generateSlug(*req.Title)
-	}
-	if req.Content != nil {
-		lesson.Content = *req.Content
-	}
-	if req.OrderIndex != nil {
-		lesson.OrderIndex = *req.OrderIndex
-	}
-	if req.Duration != nil {
-		lesson.Duration = *req.Duration
-	}
-	if req.IsPublished != nil {
-		lesson.IsPublished = *req.IsPublished
+	// Reviews only apply to submitted courses (no admin shortcut here)
+	if err := s.changeCourseStatus(ctx, course, status, userID, false, req.Comment); err != nil {
+		return nil, err
 	}
+	return course, nil
+}
 
-	if err := s.repo.UpdateLesson(ctx, lesson); err != nil {
-		return nil, err
+// ListReviewQueue lists submitted courses, longest waiting first (admin only)
+func (s *service) ListReviewQueue(ctx context.Context, userRole string, query *CourseListQuery) (*CourseListResponse, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
 	}
 
-	return lesson, nil
+	query.Status = CourseStatusSubmitted
+	query.Published = nil
+	if query.SortBy == "" {
+		query.SortBy = "submitted_at"
+		query.SortOrder = "asc"
+	}
+	return s.ListCourses(ctx, 0, query)
 }
 
-func (s *service) DeleteLesson(ctx context.Context, userID uint, userRole string, lessonID uint) error {
-	lesson, err := s.repo.FindLessonByID(ctx, lessonID)
+// GetCourseStatusHistory returns the status changes of a course with their comments
+func (s *service) GetCourseStatusHistory(ctx context.Context, userID uint, userRole string, courseID uint) ([]*CourseStatusEvent, error) {
+	course, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionEditContent)
 	if err != nil {
-		return ErrLessonNotFound
+		return nil, err
 	}
+	return s.repo.FindCourseStatusEvents(ctx, course.ID)
+}
 
-	// Verify authorization
-	course, err := s.repo.FindCourseByID(ctx, lesson.CourseID)
+// Tag operations
+
+// ListPopularTags returns the tags used by the most published courses
+func (s *service) ListPopularTags(ctx context.Context, limit int) ([]*TagWithCount, error) {
+	if limit < 1 {
+		limit = defaultPopularTags
+	}
+	tags, err := s.repo.FindPopularTags(ctx, limit)
 	if err != nil {
-		return ErrCourseNotFound
+		return nil, err
 	}
-
-	// Check authorization - allow instructor or admin
-	if course.InstructorID != userID && userRole != "admin" {
-		return ErrUnauthorized
+	if tags == nil {
+		tags = []*TagWithCount{}
 	}
-
-	return s.repo.DeleteLesson(ctx, lessonID)
+	return tags, nil
 }
 
-// ReorderLessons updates order_index for multiple lessons
-func (s *service) ReorderLessons(ctx context.Context, userID uint, userRole string, updates []LessonOrderUpdate) error {
-	// Validate that user owns all lessons being reordered
-	// We'll check the first lesson to get the course, then verify ownership
-	if len(updates) == 0 {
-		return errors.New("no updates provided")
+// GetTagPage returns a tag with its published courses (paginated and sorted like ListCourses)
+// and the tags most often used alongside it
+func (s *service) GetTagPage(ctx context.Context, userID uint, slug string, query *CourseListQuery) (*TagPageResponse, error) {
+	tag, err := s.repo.FindTagBySlug(ctx, slug)
+	if err != nil {
+		return nil, ErrTagNotFound
 	}
 
-	// Get first lesson to determine course
-	firstLesson, err := s.repo.FindLessonByID(ctx, updates[0].LessonID)
+	published := true
+	query.Published = &published
+	query.Tags = tag.Slug
+	query.TagMatch = TagMatchAny
+	courses, err := s.ListCourses(ctx, userID, query)
 	if err != nil {
-		return ErrLessonNotFound
+		return nil, err
 	}
 
-	// Verify user owns the course
-	course, err := s.repo.FindCourseByID(ctx, firstLesson.CourseID)
+	related, err := s.repo.FindRelatedTags(ctx, tag.ID, maxRelatedTags)
 	if err != nil {
-		return ErrCourseNotFound
+		return nil, err
 	}
-
-	// Check authorization - allow instructor or admin
-	if course.InstructorID != userID && userRole != "admin" {
-		return ErrUnauthorized
+	if related == nil {
+		related = []*TagWithCount{}
 	}
 
-	// Batch update all lesson orders
-	return s.repo.BatchUpdateLessonOrder(ctx, updates)
+	return &TagPageResponse{
+		Tag:         tag,
+		Courses:     courses.Courses,
+		Pagination:  courses.Pagination,
+		RelatedTags: related,
+	}, nil
+}
+
+// Helper: Title of a duplicated course, kept within the 200 character limit
+func copyTitle(title string) string {
+	const suffix = " (Copy)"
+	runes := []rune(title)
+	if max := 200 - len(suffix); len(runes) > max {
+		runes = runes[:max]
+	}
+	return string(runes) + suffix
+}
+
+// UniqueSlug returns base, or base with the first free numeric suffix (base-2, base-3, ...),
+// asking taken whether a candidate is in use. An empty base (a title without letters or
+// digits) is replaced by fallback.
+func UniqueSlug(base, fallback string, taken func(slug string) (bool, error)) (string, error) {
+	if base == "" {
+		base = fallback
+	}
+	slug := base
+	for i := 2; ; i++ {
+		exists, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// maxSlugAttempts bounds the retries when concurrent saves keep taking the chosen slug
+const maxSlugAttempts = 5
+
+// SaveWithUniqueSlug saves a record under the first free slug for base (see UniqueSlug).
+// The lookup races with concurrent saves, so when save reports ErrSlugTaken (the unique
+// index rejected the slug) the next free suffix is picked and the save is retried.
+func SaveWithUniqueSlug(base, fallback string, taken func(slug string) (bool, error), save func(slug string) error) error {
+	for attempt := 1; ; attempt++ {
+		slug, err := UniqueSlug(base, fallback, taken)
+		if err != nil {
+			return err
+		}
+		if err := save(slug); err != ErrSlugTaken || attempt == maxSlugAttempts {
+			return err
+		}
+	}
+}
+
+// Helper: Return a free course slug for base. courseID is the course the slug is for
+// (0 for a new course); it may reuse its old slugs.
+func (s *service) uniqueCourseSlug(ctx context.Context, base string, courseID uint) (string, error) {
+	return UniqueSlug(base, "course", func(slug string) (bool, error) {
+		return s.repo.SlugExists(ctx, slug, courseID)
+	})
+}
+
+// Helper: Save a course under a free slug for base, retrying when a concurrent save takes it
+func (s *service) saveWithUniqueSlug(ctx context.Context, course *Course, base string, save func() error) error {
+	return SaveWithUniqueSlug(base, "course", func(slug string) (bool, error) {
+		return s.repo.SlugExists(ctx, slug, course.ID)
+	}, func(slug string) error {
+		course.Slug = slug
+		return save()
+	})
+}
+
+// Lesson operations
+
+func (s *service) CreateLesson(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateLessonRequest) (*Lesson, error) {
+	// Verify course exists and user is instructor
+	course, err := s.repo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	// Check authorization - allow instructor, content collaborators or admin
+	if !s.canAccess(ctx, course, userID, userRole, PermissionEditContent) {
+		return nil, ErrUnauthorized
+	}
+
+	// Section (optional) must belong to the same course
+	if req.SectionID != nil {
+		if err := s.validateSection(ctx, courseID, *req.SectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	slug := GenerateSlug(req.Title)
+
+	lessonType := req.Type
+	if lessonType == "" {
+		lessonType = LessonTypeText
+	}
+	if lessonType == LessonTypeText && req.Content == "" {
+		return nil, ErrContentRequired
+	}
+
+	lesson := &Lesson{
+		CourseID:    courseID,
+		SectionID:   req.SectionID,
+		Title:       req.Title,
+		Slug:        slug,
+		Type:        lessonType,
+		Content:     req.Content,
+		OrderIndex:  req.OrderIndex,
+		Duration:    req.Duration,
+		IsPublished: req.IsPublished,
+		IsPreview:   req.IsPreview,
+	}
+
+	applyLessonPayload(lesson, req.Video, req.Quiz, req.Assignment)
+	if err := validateLessonPayload(lesson); err != nil {
+		return nil, err
+	}
+	if err := s.validateQuizReference(ctx, lesson); err != nil {
+		return nil, err
+	}
+	applyReleaseRule(lesson, req.Release)
+	if err := s.validateReleaseRule(ctx, lesson); err != nil {
+		return nil, err
+	}
+
+	// Video lessons default to the video length (rounded up to minutes)
+	if lesson.Type == LessonTypeVideo && lesson.Duration == 0 {
+		lesson.Duration = (lesson.VideoDuration + 59) / 60
+	}
+
+	// Initial content is the first published revision
+	now := time.Now()
+	revision := &LessonRevision{
+		Title:       lesson.Title,
+		Content:     lesson.Content,
+		Duration:    lesson.Duration,
+		AuthorID:    userID,
+		PublishedAt: &now,
+	}
+	revision.SetPayload(lesson)
+
+	if err := s.repo.CreateLessonWithRevision(ctx, lesson, revision); err != nil {
+		return nil, err
+	}
+
+	return lesson, nil
+}
+
+// CheckLessonAccess works out what the user may see of a lesson: the instructor and
+// collaborators see everything, enrolled students see released lessons and visitors see
+// published previews. Other modules (e.g. lesson discussions) gate on the same rules.
+func (s *service) CheckLessonAccess(ctx context.Context, userID uint, lessonID uint) (*LessonAccess, error) {
+	lesson, err := s.repo.FindLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+
+	// Check if user is enrolled or is the instructor
+	course, err := s.repo.FindCourseByID(ctx, lesson.CourseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	access := &LessonAccess{Lesson: lesson, Course: course, Available: true}
+	access.IsStaff = s.isCourseStaff(ctx, course, userID)
+	if userID > 0 {
+		access.IsEnrolled, _ = s.repo.IsUserEnrolled(ctx, userID, lesson.CourseID)
+	}
+
+	// Only enrolled users or instructor can see unpublished lessons
+	if !lesson.IsPublished && !access.IsStaff && !access.IsEnrolled {
+		return nil, ErrUnauthorized
+	}
+
+	// Drip release: students get metadata only until the lesson is released
+	if access.IsEnrolled && !access.IsStaff && lesson.HasReleaseRule() {
+		enrolledAt, completed, err := s.findReleaseContext(ctx, userID, lesson.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		access.Available, access.AvailableAt = lesson.ReleaseStatus(enrolledAt, completed, time.Now())
+	}
+
+	return access, nil
+}
+
+func (s *service) GetLesson(ctx context.Context, userID uint, lessonID uint) (*LessonResponse, error) {
+	access, err := s.CheckLessonAccess(ctx, userID, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	lesson := access.Lesson
+
+	resp := lesson.ToResponse(access.CanViewContent())
+	if !access.Available {
+		resp.lock(lesson, access.AvailableAt)
+	}
+
+	// Attachments are listed with the content; downloads are checked again
+	if access.CanViewContent() {
+		attachments, err := s.repo.FindLessonAttachments(ctx, lesson.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, attachment := range attachments {
+			resp.Attachments = append(resp.Attachments, attachment.ToResponse())
+		}
+	}
+
+	// Unpublished edits are visible to the instructor only
+	if access.IsStaff && lesson.DraftRevisionID != nil {
+		if draft, err := s.repo.FindLessonRevisionByID(ctx, *lesson.DraftRevisionID); err == nil {
+			resp.Draft = draft.ToResponse(lesson, true)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *service) GetCourseLessons(ctx context.Context, userID uint, courseID uint) (*CourseLessonsResponse, error) {
+	course, err := s.repo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	lessons, err := s.repo.FindLessonsByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := s.repo.FindSectionsByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user is enrolled or is the instructor
+	isInstructor := s.isCourseStaff(ctx, course, userID)
+	isEnrolled := false
+	if userID > 0 {
+		isEnrolled, _ = s.repo.IsUserEnrolled(ctx, userID, courseID)
+	}
+
+	// Completion counts and release rules only make sense for enrolled users
+	completed := make(map[uint]bool)
+	var enrolledAt time.Time
+	if isEnrolled {
+		enrolledAt, completed, err = s.findReleaseContext(ctx, userID, courseID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Show published lessons to everyone, unpublished only to instructor/enrolled
+	visible := make([]*Lesson, 0, len(lessons))
+	for _, lesson := range lessons {
+		if lesson.IsPublished || isInstructor || isEnrolled {
+			visible = append(visible, lesson)
+		}
+	}
+
+	result := buildCourseLessons(sections, visible, completed)
+
+	// Drip release: mark lessons the student cannot open yet
+	// (responses are shared between the flat list and the sections)
+	if isEnrolled && !isInstructor {
+		now := time.Now()
+		for i, lesson := range visible {
+			if available, availableAt := lesson.ReleaseStatus(enrolledAt, completed, now); !available {
+				result.Lessons[i].lock(lesson, availableAt)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Helper: Load what release rules are evaluated against: the enrollment date
+// and the lessons the student completed
+func (s *service) findReleaseContext(ctx context.Context, userID uint, courseID uint) (time.Time, map[uint]bool, error) {
+	enrollment, err := s.repo.FindEnrollment(ctx, userID, courseID)
+	if err != nil {
+		return time.Time{}, nil, ErrNotEnrolled
+	}
+
+	completedIDs, err := s.repo.FindCompletedLessonIDs(ctx, userID, courseID)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	completed := make(map[uint]bool, len(completedIDs))
+	for _, id := range completedIDs {
+		completed[id] = true
+	}
+
+	return enrollment.EnrolledAt, completed, nil
+}
+
+// buildCourseLessons groups lessons (already ordered by order_index) into their sections.
+// Content is never included in the curriculum view.
+func buildCourseLessons(sections []*Section, lessons []*Lesson, completed map[uint]bool) *CourseLessonsResponse {
+	result := &CourseLessonsResponse{
+		Sections:           make([]*SectionResponse, 0, len(sections)),
+		UnsectionedLessons: []*LessonResponse{},
+		Lessons:            make([]*LessonResponse, 0, len(lessons)),
+	}
+
+	sectionMap := make(map[uint]*SectionResponse, len(sections))
+	for _, section := range sections {
+		resp := section.ToResponse()
+		sectionMap[section.ID] = resp
+		result.Sections = append(result.Sections, resp)
+	}
+
+	for _, lesson := range lessons {
+		resp := lesson.ToResponse(false)
+		result.Lessons = append(result.Lessons, resp)
+		result.TotalLessons++
+		if completed[lesson.ID] {
+			result.CompletedLessons++
+		}
+
+		var section *SectionResponse
+		if lesson.SectionID != nil {
+			section = sectionMap[*lesson.SectionID]
+		}
+		if section == nil {
+			result.UnsectionedLessons = append(result.UnsectionedLessons, resp)
+			continue
+		}
+
+		section.Lessons = append(section.Lessons, resp)
+		section.LessonCount++
+		if completed[lesson.ID] {
+			section.CompletedCount++
+		}
+	}
+
+	return result
+}
+
+func (s *service) UpdateLesson(ctx context.Context, userID uint, userRole string, lessonID uint, req *UpdateLessonRequest) (*Lesson, error) {
+	lesson, err := s.repo.FindLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+
+	// Verify authorization
+	course, err := s.repo.FindCourseByID(ctx, lesson.CourseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	// Check authorization - allow instructor, content collaborators or admin
+	if !s.canAccess(ctx, course, userID, userRole, PermissionEditContent) {
+		return nil, ErrUnauthorized
+	}
+
+	// Update fields that are not versioned (applied immediately)
+	if req.SectionID != nil {
+		if *req.SectionID == 0 {
+			lesson.SectionID = nil
+		} else {
+			if err := s.validateSection(ctx, lesson.CourseID, *req.SectionID); err != nil {
+				return nil, err
+			}
+			lesson.SectionID = req.SectionID
+		}
+	}
+	if req.OrderIndex != nil {
+		lesson.OrderIndex = *req.OrderIndex
+	}
+	if req.IsPublished != nil {
+		lesson.IsPublished = *req.IsPublished
+	}
+	if req.IsPreview != nil {
+		lesson.IsPreview = *req.IsPreview
+	}
+	if req.Release != nil {
+		applyReleaseRule(lesson, req.Release)
+		if err := s.validateReleaseRule(ctx, lesson); err != nil {
+			return nil, err
+		}
+	}
+
+	// Content, type and payload changes are never written in place: every save creates a revision
+	payloadChanged := req.Type != nil || req.Video != nil || req.Quiz != nil || req.Assignment != nil
+	if req.Title != nil || req.Content != nil || req.Duration != nil || payloadChanged {
+		revision, err := s.newDraftRevision(ctx, lesson, userID)
+		if err != nil {
+			return nil, err
+		}
+		if req.Title != nil {
+			revision.Title = *req.Title
+		}
+		if req.Content != nil {
+			revision.Content = *req.Content
+		}
+		if req.Duration != nil {
+			revision.Duration = *req.Duration
+		}
+		if payloadChanged {
+			// Validated on a scratch lesson: the live lesson only changes when the revision is published
+			draft := &Lesson{ID: lesson.ID, CourseID: lesson.CourseID}
+			revision.applyPayload(draft)
+			if req.Type != nil {
+				draft.Type = *req.Type
+			}
+			applyLessonPayload(draft, req.Video, req.Quiz, req.Assignment)
+			if err := validateLessonPayload(draft); err != nil {
+				return nil, err
+			}
+			if err := s.validateQuizReference(ctx, draft); err != nil {
+				return nil, err
+			}
+			revision.SetPayload(draft)
+		}
+		if revision.Type == LessonTypeText && revision.Content == "" {
+			return nil, ErrContentRequired
+		}
+		if req.Publish {
+			applyRevision(lesson, revision)
+		}
+
+		if err := s.repo.SaveLessonRevision(ctx, lesson, revision); err != nil {
+			return nil, err
+		}
+		return lesson, nil
+	}
+
+	// No content changes: optionally publish the pending draft
+	if req.Publish && lesson.DraftRevisionID != nil {
+		return lesson, s.publishDraft(ctx, lesson)
+	}
+
+	if err := s.repo.UpdateLesson(ctx, lesson); err != nil {
+		return nil, err
+	}
+
+	return lesson, nil
+}
+
+func (s *service) DeleteLesson(ctx context.Context, userID uint, userRole string, lessonID uint) error {
+	lesson, err := s.repo.FindLessonByID(ctx, lessonID)
+	if err != nil {
+		return ErrLessonNotFound
+	}
+
+	// Verify authorization
+	course, err := s.repo.FindCourseByID(ctx, lesson.CourseID)
+	if err != nil {
+		return ErrCourseNotFound
+	}
+
+	// Check authorization - allow instructor, content collaborators or admin
+	if !s.canAccess(ctx, course, userID, userRole, PermissionEditContent) {
+		return ErrUnauthorized
+	}
+
+	return s.repo.DeleteLesson(ctx, lessonID)
+}
+
+// ReorderLessons updates order_index for multiple lessons
+func (s *service) ReorderLessons(ctx context.Context, userID uint, userRole string, updates []LessonOrderUpdate) error {
+	// Validate that user owns all lessons being reordered
+	// We'll check the first lesson to get the course, then verify ownership
+	if len(updates) == 0 {
+		return errors.New("no updates provided")
+	}
+
+	// Get first lesson to determine course
+	firstLesson, err := s.repo.FindLessonByID(ctx, updates[0].LessonID)
+	if err != nil {
+		return ErrLessonNotFound
+	}
+
+	// Verify user owns the course
+	course, err := s.repo.FindCourseByID(ctx, firstLesson.CourseID)
+	if err != nil {
+		return ErrCourseNotFound
+	}
+
+	// Check authorization - allow instructor, content collaborators or admin
+	if !s.canAccess(ctx, course, userID, userRole, PermissionEditContent) {
+		return ErrUnauthorized
+	}
+
+	// Batch update all lesson orders
+	return s.repo.BatchUpdateLessonOrder(ctx, updates)
+}
+
+// Helper: Copy provided typed payloads onto the lesson
+func applyLessonPayload(lesson *Lesson, video *VideoPayload, quiz *QuizPayload, assignment *AssignmentPayload) {
+	if video != nil {
+		lesson.VideoURL = video.URL
+		lesson.VideoDuration = video.DurationSeconds
+		lesson.CaptionTracks = video.Captions
+	}
+	if quiz != nil {
+		quizID := quiz.QuizID
+		lesson.QuizID = &quizID
+	}
+	if assignment != nil {
+		lesson.AssignmentBrief = assignment.Brief
+		lesson.AssignmentMaxScore = assignment.MaxScore
+	}
+}
+
+// validateLessonPayload checks that the lesson carries the payload its type needs
+// and clears payload fields that belong to other types.
+// Content of text lessons is checked by the caller (it may live in a draft).
+func validateLessonPayload(lesson *Lesson) error {
+	switch lesson.Type {
+	case LessonTypeText:
+	case LessonTypeVideo:
+		if lesson.VideoURL == "" {
+			return ErrVideoRequired
+		}
+	case LessonTypeQuiz:
+		if lesson.QuizID == nil {
+			return ErrQuizRequired
+		}
+	case LessonTypeAssignment:
+		if lesson.AssignmentBrief == "" {
+			return ErrAssignmentRequired
+		}
+	default:
+		return fmt.Errorf("invalid lesson type: %s", lesson.Type)
+	}
+
+	if lesson.Type != LessonTypeVideo {
+		lesson.VideoURL = ""
+		lesson.VideoDuration = 0
+		lesson.CaptionTracks = nil
+	}
+	if lesson.Type != LessonTypeQuiz {
+		lesson.QuizID = nil
+	}
+	if lesson.Type != LessonTypeAssignment {
+		lesson.AssignmentBrief = ""
+		lesson.AssignmentMaxScore = 0
+	}
+
+	return nil
+}
+
+// Helper: Verify a quiz lesson references a quiz of its own course
+func (s *service) validateQuizReference(ctx context.Context, lesson *Lesson) error {
+	if lesson.Type != LessonTypeQuiz || lesson.QuizID == nil {
+		return nil
+	}
+	ok, err := s.repo.QuizBelongsToCourse(ctx, *lesson.QuizID, lesson.CourseID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrQuizNotFound
+	}
+	return nil
+}
+
+// applyReleaseRule copies a release payload onto the lesson, keeping only the
+// field the rule uses (nil = leave the current rule unchanged)
+func applyReleaseRule(lesson *Lesson, release *ReleasePayload) {
+	if release == nil {
+		if lesson.ReleaseRule == "" {
+			lesson.ReleaseRule = ReleaseRuleImmediate
+		}
+		return
+	}
+
+	lesson.ReleaseRule = release.Rule
+	lesson.ReleaseAt = nil
+	lesson.ReleaseAfterDays = 0
+	lesson.ReleaseAfterLessonID = nil
+
+	switch release.Rule {
+	case ReleaseRuleFixedDate:
+		lesson.ReleaseAt = release.At
+	case ReleaseRuleDaysAfterEnrollment:
+		lesson.ReleaseAfterDays = release.AfterDays
+	case ReleaseRuleAfterLesson:
+		lesson.ReleaseAfterLessonID = release.AfterLessonID
+	}
+}
+
+// Helper: Verify the release rule is complete. An after_lesson rule must point to
+// another lesson of the same course and must not lead back to this lesson.
+func (s *service) validateReleaseRule(ctx context.Context, lesson *Lesson) error {
+	switch lesson.ReleaseRule {
+	case ReleaseRuleImmediate:
+		return nil
+	case ReleaseRuleFixedDate:
+		if lesson.ReleaseAt == nil {
+			return ErrInvalidReleaseRule
+		}
+		return nil
+	case ReleaseRuleDaysAfterEnrollment:
+		if lesson.ReleaseAfterDays < 1 {
+			return ErrInvalidReleaseRule
+		}
+		return nil
+	case ReleaseRuleAfterLesson:
+		if lesson.ReleaseAfterLessonID == nil {
+			return ErrInvalidReleaseRule
+		}
+	default:
+		return ErrInvalidReleaseRule
+	}
+
+	// Walk the after_lesson chain; it must stay in the course and never reach this lesson
+	visited := map[uint]bool{}
+	nextID := lesson.ReleaseAfterLessonID
+	for nextID != nil {
+		if (lesson.ID != 0 && *nextID == lesson.ID) || visited[*nextID] {
+			return ErrInvalidReleaseRule
+		}
+		visited[*nextID] = true
+
+		previous, err := s.repo.FindLessonByID(ctx, *nextID)
+		if err != nil || previous.CourseID != lesson.CourseID {
+			return ErrInvalidReleaseRule
+		}
+		if previous.ReleaseRule != ReleaseRuleAfterLesson {
+			break
+		}
+		nextID = previous.ReleaseAfterLessonID
+	}
+	return nil
+}
+
+// Lesson revision operations
+
+// Helper: Start a new revision from the lesson's latest state (pending draft, else published)
+func (s *service) newDraftRevision(ctx context.Context, lesson *Lesson, authorID uint) (*LessonRevision, error) {
+	latest := *lesson // Copy: the live lesson is not touched
+	if lesson.DraftRevisionID != nil {
+		draft, err := s.repo.FindLessonRevisionByID(ctx, *lesson.DraftRevisionID)
+		if err != nil {
+			return nil, err
+		}
+		latest.Title = draft.Title
+		latest.Content = draft.Content
+		latest.Duration = draft.Duration
+		draft.applyPayload(&latest)
+	}
+
+	revision := &LessonRevision{
+		Title:    latest.Title,
+		Content:  latest.Content,
+		Duration: latest.Duration,
+		AuthorID: authorID,
+	}
+	revision.SetPayload(&latest)
+	return revision, nil
+}
+
+// Helper: Copy a revision onto the lesson's live fields and mark it published
+func applyRevision(lesson *Lesson, revision *LessonRevision) {
+	now := time.Now()
+	revision.PublishedAt = &now
+
+	lesson.Title = revision.Title
+	lesson.Slug = GenerateSlug(revision.Title)
+	lesson.Content = revision.Content
+	lesson.Duration = revision.Duration
+	revision.applyPayload(lesson)
+}
+
+// Helper: Publish the lesson's pending draft revision
+func (s *service) publishDraft(ctx context.Context, lesson *Lesson) error {
+	draft, err := s.repo.FindLessonRevisionByID(ctx, *lesson.DraftRevisionID)
+	if err != nil {
+		return ErrRevisionNotFound
+	}
+
+	applyRevision(lesson, draft)
+	lesson.PublishedRevisionID = &draft.ID
+	lesson.DraftRevisionID = nil
+
+	return s.repo.PublishLessonRevision(ctx, lesson, draft)
+}
+
+// Helper: Load a lesson and verify the user may manage its course
+func (s *service) findManageableLesson(ctx context.Context, userID uint, userRole string, lessonID uint) (*Lesson, error) {
+	lesson, err := s.repo.FindLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+
+	if _, err := s.findManageableCourse(ctx, userID, userRole, lesson.CourseID, PermissionEditContent); err != nil {
+		return nil, err
+	}
+
+	return lesson, nil
+}
+
+// Helper: Load a revision and verify it belongs to the lesson
+func (s *service) findLessonRevision(ctx context.Context, lessonID uint, revisionID uint) (*LessonRevision, error) {
+	revision, err := s.repo.FindLessonRevisionByID(ctx, revisionID)
+	if err != nil || revision.LessonID != lessonID {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+// PublishLesson makes the pending draft the content students see
+func (s *service) PublishLesson(ctx context.Context, userID uint, userRole string, lessonID uint) (*Lesson, error) {
+	lesson, err := s.findManageableLesson(ctx, userID, userRole, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if lesson.DraftRevisionID == nil {
+		return nil, ErrNoDraftToPublish
+	}
+
+	if err := s.publishDraft(ctx, lesson); err != nil {
+		return nil, err
+	}
+
+	return lesson, nil
+}
+
+func (s *service) ListLessonRevisions(ctx context.Context, userID uint, userRole string, lessonID uint) ([]*LessonRevisionResponse, error) {
+	lesson, err := s.findManageableLesson(ctx, userID, userRole, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.repo.FindLessonRevisions(ctx, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*LessonRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		responses = append(responses, revision.ToResponse(lesson, false))
+	}
+
+	return responses, nil
+}
+
+func (s *service) GetLessonRevision(ctx context.Context, userID uint, userRole string, lessonID uint, revisionID uint) (*LessonRevisionResponse, error) {
+	lesson, err := s.findManageableLesson(ctx, userID, userRole, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := s.findLessonRevision(ctx, lessonID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return revision.ToResponse(lesson, true), nil
+}
+
+// DiffLessonRevisions returns a line-based diff of the content of two revisions
+func (s *service) DiffLessonRevisions(ctx context.Context, userID uint, userRole string, lessonID uint, fromID uint, toID uint) (*LessonRevisionDiffResponse, error) {
+	lesson, err := s.findManageableLesson(ctx, userID, userRole, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.findLessonRevision(ctx, lessonID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findLessonRevision(ctx, lessonID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &LessonRevisionDiffResponse{
+		From:            from.ToResponse(lesson, false),
+		To:              to.ToResponse(lesson, false),
+		TitleChanged:    from.Title != to.Title,
+		DurationChanged: from.Duration != to.Duration,
+		Lines:           diffLines(from.Content, to.Content),
+	}
+	for _, line := range diff.Lines {
+		switch line.Op {
+		case DiffOpAdd:
+			diff.Additions++
+		case DiffOpRemove:
+			diff.Deletions++
+		}
+	}
+
+	return diff, nil
+}
+
+// RestoreLessonRevision rolls a lesson back to an older revision.
+// The old content is copied into a new revision which is published right away,
+// so history stays append-only; any pending draft is kept in the history.
+func (s *service) RestoreLessonRevision(ctx context.Context, userID uint, userRole string, lessonID uint, revisionID uint) (*Lesson, error) {
+	lesson, err := s.findManageableLesson(ctx, userID, userRole, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.findLessonRevision(ctx, lessonID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := &LessonRevision{
+		Title:          source.Title,
+		Content:        source.Content,
+		Duration:       source.Duration,
+		AuthorID:       userID,
+		RestoredFromID: &source.ID,
+	}
+	restored := *lesson
+	source.applyPayload(&restored)
+	revision.SetPayload(&restored)
+	applyRevision(lesson, revision)
+
+	if err := s.repo.SaveLessonRevision(ctx, lesson, revision); err != nil {
+		return nil, err
+	}
+
+	return lesson, nil
+}
+
+// Section operations
+
+// Helper: Verify a section exists and belongs to the given course
+func (s *service) validateSection(ctx context.Context, courseID uint, sectionID uint) error {
+	section, err := s.repo.FindSectionByID(ctx, sectionID)
+	if err != nil || section.CourseID != courseID {
+		return ErrSectionNotFound
+	}
+	return nil
+}
+
+// Helper: Load a course and verify the user holds the permission on it (instructor, collaborator or admin)
+func (s *service) findManageableCourse(ctx context.Context, userID uint, userRole string, courseID uint, permission string) (*Course, error) {
+	course, err := s.repo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	if !s.canAccess(ctx, course, userID, userRole, permission) {
+		return nil, ErrUnauthorized
+	}
+
+	return course, nil
+}
+
+// Helper: Check a permission on the course. The instructor (owner) and admins hold every
+// permission, collaborators only those granted by their role.
+func (s *service) canAccess(ctx context.Context, course *Course, userID uint, userRole string, permission string) bool {
+	if course.InstructorID == userID || userRole == "admin" {
+		return true
+	}
+	if userID == 0 {
+		return false
+	}
+
+	collaborator, err := s.repo.FindCollaborator(ctx, course.ID, userID)
+	if err != nil {
+		return false
+	}
+	return RoleAllows(collaborator.Role, permission)
+}
+
+// Helper: Check whether the user is on the course team (instructor or any collaborator)
+func (s *service) isCourseStaff(ctx context.Context, course *Course, userID uint) bool {
+	if userID == 0 {
+		return false
+	}
+	if course.InstructorID == userID {
+		return true
+	}
+	_, err := s.repo.FindCollaborator(ctx, course.ID, userID)
+	return err == nil
+}
+
+func (s *service) CreateSection(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateSectionRequest) (*Section, error) {
+	if _, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionEditContent); err != nil {
+		return nil, err
+	}
+
+	section := &Section{
+		CourseID:    courseID,
+		Title:       req.Title,
+		Description: req.Description,
+		OrderIndex:  req.OrderIndex,
+	}
+
+	if err := s.repo.CreateSection(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// GetCourseSections lists sections of a course with lesson counts (without lessons)
+func (s *service) GetCourseSections(ctx context.Context, courseID uint) ([]*SectionResponse, error) {
+	if _, err := s.repo.FindCourseByID(ctx, courseID); err != nil {
+		return nil, ErrCourseNotFound
+	}
+
+	sections, err := s.repo.FindSectionsByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	lessons, err := s.repo.FindLessonsByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count published lessons only (same as CountLessonsByCourseID)
+	published := make([]*Lesson, 0, len(lessons))
+	for _, lesson := range lessons {
+		if lesson.IsPublished {
+			published = append(published, lesson)
+		}
+	}
+
+	curriculum := buildCourseLessons(sections, published, nil)
+	for _, section := range curriculum.Sections {
+		section.Lessons = nil
+	}
+
+	return curriculum.Sections, nil
+}
+
+func (s *service) UpdateSection(ctx context.Context, userID uint, userRole string, courseID uint, sectionID uint, req *UpdateSectionRequest) (*Section, error) {
+	if _, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionEditContent); err != nil {
+		return nil, err
+	}
+
+	section, err := s.repo.FindSectionByID(ctx, sectionID)
+	if err != nil || section.CourseID != courseID {
+		return nil, ErrSectionNotFound
+	}
+
+	if req.Title != nil {
+		section.Title = *req.Title
+	}
+	if req.Description != nil {
+		section.Description = *req.Description
+	}
+	if req.OrderIndex != nil {
+		section.OrderIndex = *req.OrderIndex
+	}
+
+	if err := s.repo.UpdateSection(ctx, section); err != nil {
+		return nil, err
+	}
+
+	return section, nil
+}
+
+// DeleteSection deletes a section; its lessons become unsectioned
+func (s *service) DeleteSection(ctx context.Context, userID uint, userRole string, courseID uint, sectionID uint) error {
+	if _, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionEditContent); err != nil {
+		return err
+	}
+
+	if err := s.validateSection(ctx, courseID, sectionID); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSection(ctx, sectionID)
+}
+
+// ReorderSections updates order_index for multiple sections of a course
+func (s *service) ReorderSections(ctx context.Context, userID uint, userRole string, courseID uint, updates []SectionOrderUpdate) error {
+	if len(updates) == 0 {
+		return errors.New("no updates provided")
+	}
+
+	if _, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionEditContent); err != nil {
+		return err
+	}
+
+	for _, update := range updates {
+		if err := s.validateSection(ctx, courseID, update.SectionID); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.BatchUpdateSectionOrder(ctx, courseID, updates)
+}
+
+// Prerequisite operations
+
+// GetCoursePrerequisites lists the courses required before enrolling.
+// For a logged-in user (userID > 0) each entry carries whether the user completed it.
+func (s *service) GetCoursePrerequisites(ctx context.Context, userID uint, courseID uint) ([]*PrerequisiteResponse, error) {
+	courses, err := s.repo.FindPrerequisiteCourses(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*PrerequisiteResponse, 0, len(courses))
+	for _, c := range courses {
+		resp := &PrerequisiteResponse{
+			ID:         c.ID,
+			Title:      c.Title,
+			Slug:       c.Slug,
+			Difficulty: c.Difficulty,
+		}
+		if userID > 0 {
+			completed, err := s.isCourseCompleted(ctx, userID, c.ID)
+			if err != nil {
+				return nil, err
+			}
+			resp.IsCompleted = &completed
+		}
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
+
+// CheckPrerequisites returns a *PrerequisitesNotMetError listing the prerequisite courses
+// the user has not completed yet (used for enrolling and for buying a course)
+func (s *service) CheckPrerequisites(ctx context.Context, userID uint, courseID uint) error {
+	prerequisites, err := s.GetCoursePrerequisites(ctx, userID, courseID)
+	if err != nil {
+		return err
+	}
+	var missing []*PrerequisiteResponse
+	for _, prerequisite := range prerequisites {
+		if !*prerequisite.IsCompleted {
+			missing = append(missing, prerequisite)
+		}
+	}
+	if len(missing) > 0 {
+		return &PrerequisitesNotMetError{Missing: missing}
+	}
+	return nil
+}
+
+func (s *service) SetCoursePrerequisites(ctx context.Context, userID uint, userRole string, courseID uint, req *SetPrerequisitesRequest) ([]*PrerequisiteResponse, error) {
+	if _, err := s.findManageableCourse(ctx, userID, userRole, courseID, PermissionManageCourse); err != nil {
+		return nil, err
+	}
+
+	// Deduplicate while keeping the requested order
+	seen := make(map[uint]bool, len(req.CourseIDs))
+	ids := make([]uint, 0, len(req.CourseIDs))
+	for _, id := range req.CourseIDs {
+		if seen[id] {
+			continue
+		}
+		if id == courseID {
+			return nil, ErrInvalidPrerequisite
+		}
+		if _, err := s.repo.FindCourseByID(ctx, id); err != nil {
+			return nil, ErrInvalidPrerequisite
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	cycle, err := createsPrerequisiteCycle(courseID, ids, func(id uint) ([]uint, error) {
+		return s.repo.FindPrerequisiteIDs(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if cycle {
+		return nil, ErrPrerequisiteCycle
+	}
+
+	if err := s.repo.ReplacePrerequisites(ctx, courseID, ids); err != nil {
+		return nil, err
+	}
+
+	return s.GetCoursePrerequisites(ctx, 0, courseID)
+}
+
+// Helper: Check whether the user completed every lesson of a course.
+// A course without lessons has nothing to complete and counts as done.
+func (s *service) isCourseCompleted(ctx context.Context, userID uint, courseID uint) (bool, error) {
+	lessons, err := s.repo.FindLessonsByCourseID(ctx, courseID)
+	if err != nil {
+		return false, err
+	}
+	completedIDs, err := s.repo.FindCompletedLessonIDs(ctx, userID, courseID)
+	if err != nil {
+		return false, err
+	}
+
+	completed := make(map[uint]bool, len(completedIDs))
+	for _, id := range completedIDs {
+		completed[id] = true
+	}
+	for _, lesson := range lessons {
+		if !completed[lesson.ID] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// createsPrerequisiteCycle reports whether requiring prerequisiteIDs for courseID would make
+// the course (transitively) require itself. prerequisitesOf returns a course's direct prerequisites.
+func createsPrerequisiteCycle(courseID uint, prerequisiteIDs []uint, prerequisitesOf func(uint) ([]uint, error)) (bool, error) {
+	visited := make(map[uint]bool)
+	stack := append([]uint(nil), prerequisiteIDs...)
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == courseID {
+			return true, nil
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		next, err := prerequisitesOf(id)
+		if err != nil {
+			return false, err
+		}
+		stack = append(stack, next...)
+	}
+	return false, nil
+}
+
+// Collaborator operations
+
+// GetCourseCollaborators lists the course team, instructor (owner) first.
+// Visible to the team itself and admins.
+func (s *service) GetCourseCollaborators(ctx context.Context, userID uint, userRole string, courseID uint) ([]*CollaboratorResponse, error) {
+	course, err := s.repo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+	if userRole != "admin" && !s.isCourseStaff(ctx, course, userID) {
+		return nil, ErrUnauthorized
+	}
+
+	collaborators, err := s.repo.FindCollaborators(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := &CollaboratorResponse{UserID: course.InstructorID, Role: "owner", AddedAt: course.CreatedAt}
+	if instructor, err := s.repo.FindUserByID(ctx, course.InstructorID); err == nil {
+		owner.Name = instructor.Name
+		owner.Email = instructor.Email
+	}
+	for _, share := range SplitRevenue(course.InstructorID, collaborators) {
+		if share.UserID == course.InstructorID {
+			owner.RevenueShare = share.Percent
+		}
+	}
+
+	responses := make([]*CollaboratorResponse, 0, len(collaborators)+1)
+	responses = append(responses, owner)
+	for _, collaborator := range collaborators {
+		responses = append(responses, toCollaboratorResponse(collaborator))
+	}
+	return responses, nil
+}
+
+// AddCollaborator invites an existing user to the course team (instructor or admin only)
+func (s *service) AddCollaborator(ctx context.Context, userID uint, userRole string, courseID uint, req *AddCollaboratorRequest) (*CollaboratorResponse, error) {
+	course, err := s.findOwnedCourse(ctx, userID, userRole, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if user.ID == course.InstructorID {
+		return nil, ErrInvalidCollaborator
+	}
+	if _, err := s.repo.FindCollaborator(ctx, courseID, user.ID); err == nil {
+		return nil, ErrCollaboratorExists
+	}
+
+	collaborator := &CourseCollaborator{
+		CourseID:     courseID,
+		UserID:       user.ID,
+		Role:         req.Role,
+		RevenueShare: req.RevenueShare,
+		AddedBy:      userID,
+		User:         user,
+	}
+
+	existing, err := s.repo.FindCollaborators(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRevenueShares(append(existing, collaborator)); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateCollaborator(ctx, collaborator); err != nil {
+		return nil, err
+	}
+	return toCollaboratorResponse(collaborator), nil
+}
+
+// UpdateCollaborator changes a collaborator's role or revenue share (instructor or admin only)
+func (s *service) UpdateCollaborator(ctx context.Context, userID uint, userRole string, courseID uint, collaboratorID uint, req *UpdateCollaboratorRequest) (*CollaboratorResponse, error) {
+	if _, err := s.findOwnedCourse(ctx, userID, userRole, courseID); err != nil {
+		return nil, err
+	}
+
+	collaborators, err := s.repo.FindCollaborators(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var collaborator *CourseCollaborator
+	for _, c := range collaborators {
+		if c.UserID == collaboratorID {
+			collaborator = c
+		}
+	}
+	if collaborator == nil {
+		return nil, ErrCollaboratorNotFound
+	}
+
+	if req.Role != nil {
+		collaborator.Role = *req.Role
+		// Losing the co-instructor role also drops the revenue share
+		if collaborator.Role != CollaboratorRoleCoInstructor && req.RevenueShare == nil {
+			collaborator.RevenueShare = 0
+		}
+	}
+	if req.RevenueShare != nil {
+		collaborator.RevenueShare = *req.RevenueShare
+	}
+	if err := validateRevenueShares(collaborators); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCollaborator(ctx, collaborator); err != nil {
+		return nil, err
+	}
+	return toCollaboratorResponse(collaborator), nil
+}
+
+// RemoveCollaborator removes a user from the course team.
+// The instructor and admins may remove anyone; collaborators may leave on their own.
+func (s *service) RemoveCollaborator(ctx context.Context, userID uint, userRole string, courseID uint, collaboratorID uint) error {
+	if collaboratorID != userID {
+		if _, err := s.findOwnedCourse(ctx, userID, userRole, courseID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.repo.FindCollaborator(ctx, courseID, collaboratorID); err != nil {
+		return ErrCollaboratorNotFound
+	}
+	return s.repo.DeleteCollaborator(ctx, courseID, collaboratorID)
+}
+
+// Helper: Load a course and verify the user owns it (instructor or admin, never collaborators)
+func (s *service) findOwnedCourse(ctx context.Context, userID uint, userRole string, courseID uint) (*Course, error) {
+	course, err := s.repo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+	if course.InstructorID != userID && userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+	return course, nil
+}
+
+// Helper: Only co-instructors may hold a revenue share and shares add up to at most 100%.
+// Whatever is left goes to the instructor (see SplitRevenue).
+func validateRevenueShares(collaborators []*CourseCollaborator) error {
+	total := 0
+	for _, collaborator := range collaborators {
+		if collaborator.RevenueShare < 0 {
+			return ErrInvalidRevenueShare
+		}
+		if collaborator.RevenueShare > 0 && collaborator.Role != CollaboratorRoleCoInstructor {
+			return ErrInvalidRevenueShare
+		}
+		total += collaborator.RevenueShare
+	}
+	if total > 100 {
+		return ErrInvalidRevenueShare
+	}
+	return nil
+}
+
+func toCollaboratorResponse(collaborator *CourseCollaborator) *CollaboratorResponse {
+	resp := &CollaboratorResponse{
+		UserID:       collaborator.UserID,
+		Role:         collaborator.Role,
+		RevenueShare: collaborator.RevenueShare,
+		AddedAt:      collaborator.CreatedAt,
+	}
+	if collaborator.User != nil {
+		resp.Name = collaborator.User.Name
+		resp.Email = collaborator.User.Email
+	}
+	return resp
 }
 
 // Enrollment operations
@@ -455,46 +2208,55 @@ func (s *service) EnrollCourse(ctx context.Context, userID uint, courseID uint)
 	}
 
 	// Check if course is published
-	if !course.IsPublished {
+	if course.Status != CourseStatusPublished {
 		return ErrCourseNotPublished
 	}
 
-	// Check if already enrolled
-	enrolled, err := s.repo.IsUserEnrolled(ctx, userID, courseID)
-	if err != nil {
-		return err
+	// Check if already enrolled (a lapsed enrollment is renewed instead)
+	if existing, err := s.repo.FindEnrollment(ctx, userID, courseID); err == nil {
+		if existing.IsActive(time.Now()) {
+			return ErrAlreadyEnrolled
+		}
+		return ErrEnrollmentExpired
 	}
-	if enrolled {
-		return ErrAlreadyEnrolled
+
+	// Check prerequisites are completed
+	if err := s.CheckPrerequisites(ctx, userID, courseID); err != nil {
+		return err
 	}
 
-	// Create enrollment
+	// Create enrollment (time-limited when the course sells a fixed access period) and take
+	// a seat; a full course puts the student on its waitlist instead
+	now := time.Now()
 	enrollment := &Enrollment{
 		UserID:     userID,
 		CourseID:   courseID,
 		Progress:   0,
-		EnrolledAt: time.Now(),
+		EnrolledAt: now,
+		ExpiresAt:  AccessExpiry(now, course.AccessDays),
 	}
 
-	if err := s.repo.CreateEnrollment(ctx, enrollment); err != nil {
+	enrolled, err := s.repo.EnrollWithinCapacity(ctx, enrollment)
+	if err != nil {
 		return err
 	}
-
-	// Increment enrolled count
-	if err := s.repo.IncrementEnrolledCount(ctx, courseID); err != nil {
-		return fmt.Errorf("failed to increment enrolled count: %w", err)
+	if !enrolled {
+		if err := s.repo.AddToWaitlist(ctx, &WaitlistEntry{CourseID: courseID, UserID: userID}); err != nil {
+			return err
+		}
+		position, err := s.repo.FindWaitlistPosition(ctx, courseID, userID)
+		if err != nil {
+			return err
+		}
+		return &WaitlistedError{Position: position}
 	}
 
 	return nil
 }
 
 func (s *service) UnenrollCourse(ctx context.Context, userID uint, courseID uint) error {
-	// Check if enrolled
-	enrolled, err := s.repo.IsUserEnrolled(ctx, userID, courseID)
-	if err != nil {
-		return err
-	}
-	if !enrolled {
+	// Check if enrolled (lapsed enrollments can be removed too)
+	if _, err := s.repo.FindEnrollment(ctx, userID, courseID); err != nil {
 		return ErrNotEnrolled
 	}
 
@@ -508,5 +2270,195 @@ func (s *service) UnenrollCourse(ctx context.Context, userID uint, courseID uint
 		return fmt.Errorf("failed to decrement enrolled count: %w", err)
 	}
 
+	// Hand the freed seat to the next student on the waitlist
+	if course, err := s.repo.FindCourseByID(ctx, courseID); err == nil && course.MaxEnrollments > 0 {
+		s.promoteWaitlist(ctx, course)
+	}
+
+	return nil
+}
+
+// GetWaitlistPosition returns the student's 1-based place on the course waitlist
+func (s *service) GetWaitlistPosition(ctx context.Context, userID uint, courseID uint) (int, error) {
+	position, err := s.repo.FindWaitlistPosition(ctx, courseID, userID)
+	if err != nil {
+		return 0, err
+	}
+	if position == 0 {
+		return 0, ErrNotWaitlisted
+	}
+	return position, nil
+}
+
+func (s *service) LeaveWaitlist(ctx context.Context, userID uint, courseID uint) error {
+	removed, err := s.repo.RemoveFromWaitlist(ctx, courseID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrNotWaitlisted
+	}
 	return nil
 }
+
+// Helper: Enroll waitlisted students, in join order, while the course has free seats.
+// Students who have not completed the course's prerequisites are passed over and keep
+// their place. Promotion is best effort: the change that freed the seats is already saved.
+func (s *service) promoteWaitlist(ctx context.Context, course *Course) {
+	var skipped []uint
+	for {
+		entry, err := s.repo.NextWaitlistEntry(ctx, course.ID, skipped)
+		if err != nil || entry == nil {
+			return
+		}
+		if err := s.CheckPrerequisites(ctx, entry.UserID, course.ID); err != nil {
+			skipped = append(skipped, entry.UserID)
+			continue
+		}
+
+		enrollment, err := s.repo.PromoteFromWaitlist(ctx, course, entry.UserID, time.Now())
+		if err == ErrNotWaitlisted {
+			skipped = append(skipped, entry.UserID) // Left the waitlist meanwhile
+			continue
+		}
+		if err != nil || enrollment == nil {
+			return
+		}
+
+		message := fmt.Sprintf("A seat opened up in \"%s\" and you are now enrolled.", course.Title)
+		if err := s.notifier.Notify(ctx, enrollment.UserID, NotificationWaitlistPromoted,
+			"You're in!", message, "/courses/"+course.Slug); err != nil {
+			logger.Warn("Failed to notify promoted waitlisted student",
+				zap.Error(err),
+				zap.Uint("course_id", course.ID),
+				zap.Uint("user_id", enrollment.UserID),
+			)
+		}
+	}
+}
+
+// RenewEnrollment extends a student's own enrollment of a free course by the course's access
+// duration. Paid courses are renewed by purchasing them again (see GrantAccess).
+func (s *service) RenewEnrollment(ctx context.Context, userID uint, courseID uint) (*Enrollment, error) {
+	course, err := s.repo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, ErrCourseNotFound
+	}
+	if course.Status != CourseStatusPublished {
+		return nil, ErrCourseNotPublished
+	}
+	if course.Price > 0 {
+		return nil, ErrPaymentRequired
+	}
+	if _, err := s.repo.FindEnrollment(ctx, userID, courseID); err != nil {
+		return nil, ErrNotEnrolled
+	}
+	return GrantAccess(ctx, s.repo, course, userID, 0, time.Now())
+}
+
+// ExtendEnrollment changes a student's access period (admin only), e.g. for corporate seats
+func (s *service) ExtendEnrollment(ctx context.Context, userRole string, courseID uint, studentID uint, req *ExtendEnrollmentRequest) (*Enrollment, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+
+	enrollment, err := s.repo.FindEnrollment(ctx, studentID, courseID)
+	if err != nil {
+		return nil, ErrNotEnrolled
+	}
+
+	expiresAt, err := extendedExpiry(enrollment.ExpiresAt, time.Now(), req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateEnrollmentExpiry(ctx, enrollment.ID, expiresAt); err != nil {
+		return nil, err
+	}
+	enrollment.ExpiresAt = expiresAt
+	return enrollment, nil
+}
+
+// Helper: New end of an enrollment for an admin extension
+func extendedExpiry(current *time.Time, now time.Time, req *ExtendEnrollmentRequest) (*time.Time, error) {
+	set := 0
+	for _, given := range []bool{req.Days > 0, req.ExpiresAt != nil, req.Lifetime} {
+		if given {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, ErrInvalidExtension
+	}
+
+	switch {
+	case req.Lifetime:
+		return nil, nil
+	case req.ExpiresAt != nil:
+		if !req.ExpiresAt.After(now) {
+			return nil, ErrInvalidExtension
+		}
+		return req.ExpiresAt, nil
+	default:
+		if current == nil {
+			return AccessExpiry(now, req.Days), nil // A lifetime seat becomes time-limited
+		}
+		return RenewedExpiry(current, now, req.Days), nil
+	}
+}
+
+// Helper: Show the viewer's own access period, or their place on the waitlist, on the
+// course detail
+func (s *service) setViewerAccess(ctx context.Context, resp *CourseResponse, course *Course, userID uint, enrollment *Enrollment) {
+	if enrollment == nil {
+		if userID > 0 && course.MaxEnrollments > 0 {
+			resp.WaitlistPosition, _ = s.repo.FindWaitlistPosition(ctx, course.ID, userID)
+		}
+		return
+	}
+	resp.AccessExpiresAt = enrollment.ExpiresAt
+	resp.AccessExpired = !enrollment.IsActive(time.Now())
+}
+
+// GrantAccess enrolls a user after a purchase, or extends their enrollment when they buy
+// the course again (renewal). The period comes from the course's access duration.
+// A new enrollment takes a seat like EnrollCourse: when the course filled up while the
+// payment was pending, the buyer goes to the front of the waitlist (paymentID) and a
+// *WaitlistedError is returned so the payment can be flagged for refund.
+func GrantAccess(ctx context.Context, repo Repository, course *Course, userID uint, paymentID uint, now time.Time) (*Enrollment, error) {
+	if enrollment, err := repo.FindEnrollment(ctx, userID, course.ID); err == nil {
+		if enrollment.ExpiresAt == nil {
+			return enrollment, nil // Lifetime access already
+		}
+		enrollment.ExpiresAt = RenewedExpiry(enrollment.ExpiresAt, now, course.AccessDays)
+		if err := repo.UpdateEnrollmentExpiry(ctx, enrollment.ID, enrollment.ExpiresAt); err != nil {
+			return nil, err
+		}
+		return enrollment, nil
+	}
+
+	enrollment := &Enrollment{
+		UserID:     userID,
+		CourseID:   course.ID,
+		EnrolledAt: now,
+		ExpiresAt:  AccessExpiry(now, course.AccessDays),
+	}
+	enrolled, err := repo.EnrollWithinCapacity(ctx, enrollment)
+	if err != nil {
+		return nil, err
+	}
+	if !enrolled {
+		entry := &WaitlistEntry{CourseID: course.ID, UserID: userID}
+		if paymentID > 0 {
+			entry.PaymentID = &paymentID
+		}
+		if err := repo.AddToWaitlist(ctx, entry); err != nil {
+			return nil, err
+		}
+		position, err := repo.FindWaitlistPosition(ctx, course.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, &WaitlistedError{Position: position}
+	}
+	return enrollment, nil
+}
@@ -1,9 +1,15 @@
 package course
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestGenerateSlug tests the slug generation utility
@@ -20,12 +26,553 @@ func TestGenerateSlug(t *testing.T) {
 		{"With spaces", "   Spaces   ", "spaces"},
 		{"Multiple dashes", "Multiple---Dashes", "multiple-dashes"},
 		{"Underscores", "Some_Under_Scores", "some-under-scores"},
+		{"Punctuation", "  UI & UX Design! ", "ui-ux-design"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := generateSlug(tt.input)
+			result := GenerateSlug(tt.input)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
+
+// TestBuildCourseLessons tests grouping lessons into sections with completion counts
+func TestBuildCourseLessons(t *testing.T) {
+	sectionA, sectionB := uint(1), uint(2)
+	missing := uint(99)
+	sections := []*Section{
+		{ID: sectionA, CourseID: 1, Title: "Basics", OrderIndex: 0},
+		{ID: sectionB, CourseID: 1, Title: "Advanced", OrderIndex: 1},
+	}
+	lessons := []*Lesson{
+		{ID: 10, CourseID: 1, SectionID: &sectionA, Title: "Intro", Content: "secret"},
+		{ID: 11, CourseID: 1, SectionID: &sectionA, Title: "Variables"},
+		{ID: 12, CourseID: 1, SectionID: &sectionB, Title: "Goroutines"},
+		{ID: 13, CourseID: 1, Title: "Bonus"},
+		{ID: 14, CourseID: 1, SectionID: &missing, Title: "Orphan"},
+	}
+	completed := map[uint]bool{10: true, 12: true, 13: true}
+
+	result := buildCourseLessons(sections, lessons, completed)
+
+	assert.Equal(t, 5, result.TotalLessons)
+	assert.Equal(t, 3, result.CompletedLessons)
+	assert.Len(t, result.Lessons, 5)
+	assert.Len(t, result.Sections, 2)
+
+	assert.Equal(t, 2, result.Sections[0].LessonCount)
+	assert.Equal(t, 1, result.Sections[0].CompletedCount)
+	assert.Equal(t, 1, result.Sections[1].LessonCount)
+	assert.Equal(t, 1, result.Sections[1].CompletedCount)
+
+	// Lessons without a (known) section are reported separately
+	assert.Len(t, result.UnsectionedLessons, 2)
+	assert.Equal(t, uint(13), result.UnsectionedLessons[0].ID)
+	assert.Equal(t, uint(14), result.UnsectionedLessons[1].ID)
+
+	// Content is never exposed in the curriculum view
+	assert.Empty(t, result.Sections[0].Lessons[0].Content)
+}
+
+// TestDiffLines tests the line-based revision diff
+func TestDiffLines(t *testing.T) {
+	from := "# Intro\nold line\nshared\n"
+	to := "# Intro\nnew line\nshared\nappended"
+
+	lines := diffLines(from, to)
+
+	assert.Equal(t, []DiffLine{
+		{Op: DiffOpEqual, Text: "# Intro"},
+		{Op: DiffOpRemove, Text: "old line"},
+		{Op: DiffOpAdd, Text: "new line"},
+		{Op: DiffOpEqual, Text: "shared"},
+		{Op: DiffOpAdd, Text: "appended"},
+	}, lines)
+
+	assert.Empty(t, diffLines("", ""))
+	assert.Equal(t, []DiffLine{{Op: DiffOpAdd, Text: "a"}}, diffLines("", "a"))
+	assert.Equal(t, []DiffLine{{Op: DiffOpEqual, Text: "a"}}, diffLines("a\r\n", "a\n"))
+}
+
+// TestValidateLessonPayload tests typed lesson payload validation
+func TestValidateLessonPayload(t *testing.T) {
+	quizID := uint(7)
+
+	tests := []struct {
+		name     string
+		lesson   *Lesson
+		expected error
+	}{
+		{"Text lesson", &Lesson{Type: LessonTypeText}, nil},
+		{"Video with URL", &Lesson{Type: LessonTypeVideo, VideoURL: "https://cdn.example.com/v.mp4"}, nil},
+		{"Video without URL", &Lesson{Type: LessonTypeVideo}, ErrVideoRequired},
+		{"Quiz with reference", &Lesson{Type: LessonTypeQuiz, QuizID: &quizID}, nil},
+		{"Quiz without reference", &Lesson{Type: LessonTypeQuiz}, ErrQuizRequired},
+		{"Assignment without brief", &Lesson{Type: LessonTypeAssignment}, ErrAssignmentRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateLessonPayload(tt.lesson))
+		})
+	}
+
+	// Switching type clears the payload of the previous type
+	lesson := &Lesson{Type: LessonTypeText, VideoURL: "https://cdn.example.com/v.mp4", QuizID: &quizID}
+	assert.NoError(t, validateLessonPayload(lesson))
+	assert.Empty(t, lesson.VideoURL)
+	assert.Nil(t, lesson.QuizID)
+}
+
+// TestRevisionPayload tests that the type and payload travel with a revision and that
+// legacy revisions without a type leave the lesson's type alone when published
+func TestRevisionPayload(t *testing.T) {
+	quizID := uint(7)
+	revision := &LessonRevision{}
+	revision.SetPayload(&Lesson{Type: LessonTypeQuiz, QuizID: &quizID})
+
+	lesson := &Lesson{Type: LessonTypeVideo, VideoURL: "https://cdn.example.com/v.mp4"}
+	applyRevision(lesson, revision)
+	assert.Equal(t, LessonTypeQuiz, lesson.Type)
+	assert.Equal(t, &quizID, lesson.QuizID)
+	assert.Empty(t, lesson.VideoURL)
+
+	legacy := &LessonRevision{Title: "Intro", Content: "Hello"}
+	applyRevision(lesson, legacy)
+	assert.Equal(t, LessonTypeQuiz, lesson.Type)
+	assert.Equal(t, "Hello", lesson.Content)
+}
+
+// TestCreatesPrerequisiteCycle tests cycle detection in the prerequisite graph
+func TestCreatesPrerequisiteCycle(t *testing.T) {
+	// 3 requires 2, 2 requires 1
+	graph := map[uint][]uint{3: {2}, 2: {1}}
+	lookup := func(id uint) ([]uint, error) { return graph[id], nil }
+
+	cycle, err := createsPrerequisiteCycle(1, []uint{3}, lookup)
+	assert.NoError(t, err)
+	assert.True(t, cycle, "1 -> 3 -> 2 -> 1")
+
+	cycle, err = createsPrerequisiteCycle(4, []uint{3, 1}, lookup)
+	assert.NoError(t, err)
+	assert.False(t, cycle)
+
+	cycle, err = createsPrerequisiteCycle(1, nil, lookup)
+	assert.NoError(t, err)
+	assert.False(t, cycle)
+}
+
+// TestLessonReleaseStatus tests drip release rule evaluation
+func TestLessonReleaseStatus(t *testing.T) {
+	enrolledAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	previousID := uint(3)
+
+	tests := []struct {
+		name      string
+		lesson    *Lesson
+		completed map[uint]bool
+		available bool
+		at        *time.Time
+	}{
+		{"Immediate", &Lesson{ReleaseRule: ReleaseRuleImmediate}, nil, true, nil},
+		{"Legacy empty rule", &Lesson{}, nil, true, nil},
+		{"Fixed date passed", &Lesson{ReleaseRule: ReleaseRuleFixedDate, ReleaseAt: &past}, nil, true, &past},
+		{"Fixed date ahead", &Lesson{ReleaseRule: ReleaseRuleFixedDate, ReleaseAt: &future}, nil, false, &future},
+		{"Days after enrollment reached", &Lesson{ReleaseRule: ReleaseRuleDaysAfterEnrollment, ReleaseAfterDays: 4}, nil, true, nil},
+		{"Days after enrollment ahead", &Lesson{ReleaseRule: ReleaseRuleDaysAfterEnrollment, ReleaseAfterDays: 7}, nil, false, nil},
+		{"After lesson done", &Lesson{ReleaseRule: ReleaseRuleAfterLesson, ReleaseAfterLessonID: &previousID}, map[uint]bool{3: true}, true, nil},
+		{"After lesson pending", &Lesson{ReleaseRule: ReleaseRuleAfterLesson, ReleaseAfterLessonID: &previousID}, map[uint]bool{}, false, nil},
+		{"Preview is never locked", &Lesson{ReleaseRule: ReleaseRuleFixedDate, ReleaseAt: &future, IsPreview: true}, nil, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			available, at := tt.lesson.ReleaseStatus(enrolledAt, tt.completed, now)
+			assert.Equal(t, tt.available, available)
+			if tt.at != nil {
+				assert.Equal(t, *tt.at, *at)
+			}
+		})
+	}
+
+	// Days-based rules report the release date
+	_, at := (&Lesson{ReleaseRule: ReleaseRuleDaysAfterEnrollment, ReleaseAfterDays: 7}).ReleaseStatus(enrolledAt, nil, now)
+	assert.Equal(t, enrolledAt.AddDate(0, 0, 7), *at)
+}
+
+// TestSplitRevenue tests the instructor / co-instructor revenue split
+func TestSplitRevenue(t *testing.T) {
+	// No collaborators: the instructor keeps everything
+	assert.Equal(t, []RevenueShare{{UserID: 1, Percent: 100}}, SplitRevenue(1, nil))
+
+	collaborators := []*CourseCollaborator{
+		{UserID: 2, Role: CollaboratorRoleCoInstructor, RevenueShare: 30},
+		{UserID: 3, Role: CollaboratorRoleTA, RevenueShare: 10}, // ignored: not a co-instructor
+		{UserID: 4, Role: CollaboratorRoleCoInstructor, RevenueShare: 20},
+		{UserID: 5, Role: CollaboratorRoleEditor},
+	}
+	assert.Equal(t, []RevenueShare{
+		{UserID: 1, Percent: 50},
+		{UserID: 2, Percent: 30},
+		{UserID: 4, Percent: 20},
+	}, SplitRevenue(1, collaborators))
+
+	// Shares never exceed 100% and the instructor drops out when nothing is left
+	collaborators[2].RevenueShare = 80
+	assert.Equal(t, []RevenueShare{
+		{UserID: 2, Percent: 30},
+		{UserID: 4, Percent: 70},
+	}, SplitRevenue(1, collaborators))
+}
+
+// TestValidateRevenueShares tests revenue share validation
+func TestValidateRevenueShares(t *testing.T) {
+	assert.NoError(t, validateRevenueShares([]*CourseCollaborator{
+		{Role: CollaboratorRoleCoInstructor, RevenueShare: 60},
+		{Role: CollaboratorRoleCoInstructor, RevenueShare: 40},
+		{Role: CollaboratorRoleTA},
+	}))
+	assert.Equal(t, ErrInvalidRevenueShare, validateRevenueShares([]*CourseCollaborator{
+		{Role: CollaboratorRoleCoInstructor, RevenueShare: 60},
+		{Role: CollaboratorRoleCoInstructor, RevenueShare: 41},
+	}))
+	assert.Equal(t, ErrInvalidRevenueShare, validateRevenueShares([]*CourseCollaborator{
+		{Role: CollaboratorRoleEditor, RevenueShare: 10},
+	}))
+}
+
+// TestRoleAllows tests collaborator role permissions
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, RoleAllows(CollaboratorRoleCoInstructor, PermissionManageCourse))
+	assert.True(t, RoleAllows(CollaboratorRoleEditor, PermissionEditContent))
+	assert.False(t, RoleAllows(CollaboratorRoleEditor, PermissionTeach))
+	assert.True(t, RoleAllows(CollaboratorRoleTA, PermissionTeach))
+	assert.False(t, RoleAllows(CollaboratorRoleTA, PermissionEditContent))
+	assert.False(t, RoleAllows("", PermissionTeach))
+}
+
+// TestCopyTitle tests the default title of a duplicated course
+func TestCopyTitle(t *testing.T) {
+	assert.Equal(t, "Belajar Go (Copy)", copyTitle("Belajar Go"))
+
+	long := copyTitle(strings.Repeat("a", 200))
+	assert.Equal(t, 200, len([]rune(long)))
+	assert.True(t, strings.HasSuffix(long, " (Copy)"))
+}
+
+// TestParseSearch tests turning search input into FULLTEXT queries
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		boolean string
+		natural string
+	}{
+		{"Plain words are required prefixes", "React Hooks", "+react* +hooks*", "react hooks"},
+		{"Hyphenated words stay plain", "e-commerce", "+commerce*", "commerce"},
+		{"Boolean syntax passes through", `+golang -python "web api"`, `+golang -python "web api"`, "golang web api"},
+		{"Stray characters become separators", `+golang user@example`, `+golang user example`, "golang user example"},
+		{"Unclosed group is read as plain words", "golang (", "+golang*", "golang"},
+		{"Unclosed phrase is read as plain words", `"go web`, "+go* +web*", "go web"},
+		{"Dangling operator is read as plain words", "+ golang", "+golang*", "golang"},
+		{"Misplaced wildcard is read as plain words", "*golang", "+golang*", "golang"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			search := parseSearch(tt.input)
+			assert.Equal(t, tt.boolean, search.Boolean)
+			assert.Equal(t, tt.natural, search.Natural)
+			assert.True(t, search.fulltext())
+		})
+	}
+
+	assert.Nil(t, parseSearch("   "))
+
+	// Input without an indexable word still filters, on a literal match
+	for _, input := range []string{"c++", "C#", "R", "-golang", "!!! ---"} {
+		search := parseSearch(input)
+		require.NotNil(t, search, input)
+		assert.False(t, search.fulltext(), input)
+		assert.Equal(t, input, search.Like)
+	}
+}
+
+// TestBuildSnippet tests highlighted search snippets
+func TestBuildSnippet(t *testing.T) {
+	// Short text: whole text, all matches marked, word prefixes only
+	snippet, ok := buildSnippet("Learn **React** hooks & useReact patterns", []string{"react"})
+	assert.True(t, ok)
+	assert.Equal(t, "Learn <mark>React</mark> hooks &amp; useReact patterns", snippet)
+
+	// No match
+	_, ok = buildSnippet("Nothing here", []string{"react"})
+	assert.False(t, ok)
+
+	// Long text: a window around the first match with ellipses
+	long := strings.Repeat("filler words ", 20) + "the <b>state</b> hook " + strings.Repeat("more text ", 20)
+	snippet, ok = buildSnippet(long, []string{"state"})
+	assert.True(t, ok)
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Contains(t, snippet, "the <mark>state</mark> hook")
+	assert.NotContains(t, snippet, "<b>")
+}
+
+// TestBuildCourseFacets tests zero-filled, ordered facet values
+func TestBuildCourseFacets(t *testing.T) {
+	categories := []string{"Web Development", "Data Science", "Design"}
+	facets := buildCourseFacets(&CourseFacetCounts{
+		Total:        5,
+		Categories:   map[string]int{"Data Science": 3, "Legacy": 1, "Archived": 1},
+		Difficulties: map[string]int{"advanced": 5},
+		PriceBuckets: map[string]int{PriceBucketFree: 2, PriceBucketOver500k: 3},
+	}, categories)
+
+	assert.Equal(t, 5, facets.Total)
+	assert.Len(t, facets.Categories, len(categories)+2)
+	assert.Equal(t, 0, facets.Categories[0].Count)
+	assert.Equal(t, 3, facets.Categories[1].Count)
+	assert.Equal(t, "Archived", facets.Categories[len(categories)].Value) // Unknown values sorted at the end
+
+	assert.Equal(t, []string{"beginner", "intermediate", "advanced"}, []string{
+		facets.Difficulties[0].Value, facets.Difficulties[1].Value, facets.Difficulties[2].Value,
+	})
+	assert.Equal(t, 5, facets.Difficulties[2].Count)
+
+	assert.Len(t, facets.PriceBuckets, len(PriceBuckets))
+	assert.Equal(t, 2, facets.PriceBuckets[0].Count)
+	assert.Equal(t, 0, facets.PriceBuckets[1].Count)
+	assert.Equal(t, 3, facets.PriceBuckets[3].Count)
+	assert.NotNil(t, facets.Instructors)
+}
+
+// TestNormalizeTags tests tag normalization and limits
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{"  GoLang ", "Docker", "golang", "REST   API"})
+	require.NoError(t, err)
+	require.Len(t, tags, 3) // "golang" duplicates "GoLang"
+	assert.Equal(t, "golang", tags[0].Name)
+	assert.Equal(t, "rest api", tags[2].Name)
+	assert.Equal(t, "rest-api", tags[2].Slug)
+
+	_, err = normalizeTags([]string{"!!!"})
+	assert.Equal(t, ErrInvalidTag, err)
+
+	many := make([]string, maxCourseTags+1)
+	for i := range many {
+		many[i] = fmt.Sprintf("tag %d", i)
+	}
+	_, err = normalizeTags(many)
+	assert.Equal(t, ErrInvalidTag, err)
+}
+
+// TestParseTagSlugs tests the tags query filter
+func TestParseTagSlugs(t *testing.T) {
+	assert.Equal(t, []string{"golang", "rest-api"}, parseTagSlugs("golang, REST API,,golang"))
+	assert.Nil(t, parseTagSlugs(""))
+}
+
+// slugRepo is a Repository stub that only answers SlugExists
+type slugRepo struct {
+	Repository
+	taken map[string]uint // slug => course using it (current or old slug)
+}
+
+func (r *slugRepo) SlugExists(ctx context.Context, slug string, courseID uint) (bool, error) {
+	owner, ok := r.taken[slug]
+	return ok && owner != courseID, nil
+}
+
+// TestUniqueCourseSlug tests slug collision suffixes
+func TestUniqueCourseSlug(t *testing.T) {
+	s := &service{repo: &slugRepo{taken: map[string]uint{
+		"intro-to-go":   1,
+		"intro-to-go-2": 2,
+		"old-name":      3,
+	}}}
+	ctx := context.Background()
+
+	slug, err := s.uniqueCourseSlug(ctx, "intro-to-go", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "intro-to-go-3", slug)
+
+	// A course keeps its own slug and may take back its old ones
+	slug, _ = s.uniqueCourseSlug(ctx, "intro-to-go", 1)
+	assert.Equal(t, "intro-to-go", slug)
+	slug, _ = s.uniqueCourseSlug(ctx, "old-name", 3)
+	assert.Equal(t, "old-name", slug)
+
+	slug, _ = s.uniqueCourseSlug(ctx, "", 0)
+	assert.Equal(t, "course", slug)
+}
+
+// TestSaveWithUniqueSlug tests that a slug taken between the lookup and the save is retried
+// with the next suffix
+func TestSaveWithUniqueSlug(t *testing.T) {
+	repo := &slugRepo{taken: map[string]uint{"intro-to-go": 1}}
+	s := &service{repo: repo}
+	course := &Course{Title: "Intro to Go"}
+
+	var saved []string
+	err := s.saveWithUniqueSlug(context.Background(), course, "intro-to-go", func() error {
+		saved = append(saved, course.Slug)
+		if len(saved) == 1 {
+			repo.taken[course.Slug] = 2 // A concurrent save got there first
+			return ErrSlugTaken
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"intro-to-go-2", "intro-to-go-3"}, saved)
+	assert.Equal(t, "intro-to-go-3", course.Slug)
+
+	// Gives up after maxSlugAttempts
+	attempts := 0
+	err = s.saveWithUniqueSlug(context.Background(), &Course{}, "busy", func() error {
+		attempts++
+		return ErrSlugTaken
+	})
+	assert.Equal(t, ErrSlugTaken, err)
+	assert.Equal(t, maxSlugAttempts, attempts)
+}
+
+// TestCanTransition tests the review workflow state machine
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition(CourseStatusDraft, CourseStatusSubmitted, false))
+	assert.True(t, CanTransition(CourseStatusSubmitted, CourseStatusChangesRequested, true))
+	assert.True(t, CanTransition(CourseStatusChangesRequested, CourseStatusSubmitted, false))
+	assert.True(t, CanTransition(CourseStatusPublished, CourseStatusArchived, false))
+	assert.True(t, CanTransition(CourseStatusArchived, CourseStatusDraft, false))
+
+	// Only the review may publish, but admins can skip it
+	assert.False(t, CanTransition(CourseStatusDraft, CourseStatusPublished, false))
+	assert.True(t, CanTransition(CourseStatusDraft, CourseStatusPublished, true))
+	assert.False(t, CanTransition(CourseStatusPublished, CourseStatusPublished, true))
+	assert.False(t, CanTransition(CourseStatusArchived, CourseStatusSubmitted, false))
+}
+
+// TestPublishShortcutStatus tests how is_published updates map onto the workflow
+func TestPublishShortcutStatus(t *testing.T) {
+	assert.Equal(t, CourseStatusSubmitted, publishShortcutStatus(CourseStatusDraft, true, false))
+	assert.Equal(t, CourseStatusSubmitted, publishShortcutStatus(CourseStatusChangesRequested, true, false))
+	assert.Equal(t, CourseStatusPublished, publishShortcutStatus(CourseStatusDraft, true, true))
+	assert.Equal(t, CourseStatusArchived, publishShortcutStatus(CourseStatusPublished, false, false))
+	assert.Equal(t, CourseStatusDraft, publishShortcutStatus(CourseStatusSubmitted, false, false))
+
+	// No-ops
+	assert.Equal(t, "", publishShortcutStatus(CourseStatusSubmitted, true, false))
+	assert.Equal(t, "", publishShortcutStatus(CourseStatusPublished, true, true))
+	assert.Equal(t, "", publishShortcutStatus(CourseStatusDraft, false, false))
+}
+
+// TestCourseSortKeys tests the keyset (and cursor values) of course list sorts
+func TestCourseSortKeys(t *testing.T) {
+	row := &CourseWithMeta{Course: Course{ID: 9, AverageRating: 4.5, ReviewCount: 12}}
+
+	keys, values := courseSortKeys(&CourseListQuery{SortBy: "rating", SortOrder: "desc"}, false)
+	assert.Equal(t, "courses.average_rating DESC, courses.review_count DESC, courses.id DESC", cursor.OrderClause(keys))
+	assert.Equal(t, []interface{}{4.5, 12, uint(9)}, values(row))
+
+	// Searches rank by relevance unless another sort is asked for; relevance needs a search
+	keys, _ = courseSortKeys(&CourseListQuery{}, true)
+	assert.Equal(t, "relevance", keys[0].Column)
+	keys, _ = courseSortKeys(&CourseListQuery{SortBy: "relevance", SortOrder: "asc"}, false)
+	assert.Equal(t, "courses.created_at ASC, courses.id ASC", cursor.OrderClause(keys))
+}
+
+// TestEnrollmentExpiry tests access expiry at enrollment and on renewal
+func TestEnrollmentExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	running := now.AddDate(0, 0, 10)
+	lapsed := now.AddDate(0, 0, -10)
+
+	assert.Nil(t, AccessExpiry(now, 0))
+	assert.Equal(t, now.AddDate(0, 0, 180), *AccessExpiry(now, 180))
+
+	// Running access is extended from its end, lapsed access from now
+	assert.Equal(t, running.AddDate(0, 0, 30), *RenewedExpiry(&running, now, 30))
+	assert.Equal(t, now.AddDate(0, 0, 30), *RenewedExpiry(&lapsed, now, 30))
+	assert.Nil(t, RenewedExpiry(nil, now, 30))
+	assert.Nil(t, RenewedExpiry(&lapsed, now, 0))
+
+	assert.True(t, (&Enrollment{ExpiresAt: &running}).IsActive(now))
+	assert.False(t, (&Enrollment{ExpiresAt: &lapsed}).IsActive(now))
+	assert.True(t, (&Enrollment{}).IsActive(now))
+}
+
+// TestExtendedExpiry tests the admin extension options
+func TestExtendedExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	current := now.AddDate(0, 0, 5)
+	future := now.AddDate(1, 0, 0)
+	past := now.AddDate(0, 0, -1)
+
+	expiresAt, err := extendedExpiry(&current, now, &ExtendEnrollmentRequest{Days: 30})
+	require.NoError(t, err)
+	assert.Equal(t, current.AddDate(0, 0, 30), *expiresAt)
+
+	expiresAt, err = extendedExpiry(nil, now, &ExtendEnrollmentRequest{Days: 30})
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, 30), *expiresAt)
+
+	expiresAt, err = extendedExpiry(&current, now, &ExtendEnrollmentRequest{ExpiresAt: &future})
+	require.NoError(t, err)
+	assert.Equal(t, future, *expiresAt)
+
+	expiresAt, err = extendedExpiry(&current, now, &ExtendEnrollmentRequest{Lifetime: true})
+	require.NoError(t, err)
+	assert.Nil(t, expiresAt)
+
+	// Exactly one option, and a fixed date must be in the future
+	_, err = extendedExpiry(&current, now, &ExtendEnrollmentRequest{})
+	assert.ErrorIs(t, err, ErrInvalidExtension)
+	_, err = extendedExpiry(&current, now, &ExtendEnrollmentRequest{Days: 30, Lifetime: true})
+	assert.ErrorIs(t, err, ErrInvalidExtension)
+	_, err = extendedExpiry(&current, now, &ExtendEnrollmentRequest{ExpiresAt: &past})
+	assert.ErrorIs(t, err, ErrInvalidExtension)
+}
+
+// TestCourseIsFull tests the seat cap check used before enrolling and buying
+func TestCourseIsFull(t *testing.T) {
+	assert.False(t, (&Course{EnrolledCount: 500}).IsFull(), "0 means unlimited")
+	assert.False(t, (&Course{MaxEnrollments: 30, EnrolledCount: 29}).IsFull())
+	assert.True(t, (&Course{MaxEnrollments: 30, EnrolledCount: 30}).IsFull())
+	assert.True(t, (&Course{MaxEnrollments: 20, EnrolledCount: 30}).IsFull(), "cap lowered below enrollments")
+
+	assert.Equal(t, "this course is full; you are number 3 on the waitlist", (&WaitlistedError{Position: 3}).Error())
+}
+
+// TestLessonAccess tests who sees lesson content and who takes part in its discussion
+func TestLessonAccess(t *testing.T) {
+	lesson := &Lesson{IsPublished: true}
+	preview := &Lesson{IsPublished: true, IsPreview: true}
+	published := &Course{Status: CourseStatusPublished}
+
+	staff := &LessonAccess{Lesson: lesson, IsStaff: true, Available: true}
+	assert.True(t, staff.CanViewContent())
+	assert.True(t, staff.IsParticipant())
+
+	released := &LessonAccess{Lesson: lesson, IsEnrolled: true, Available: true}
+	assert.True(t, released.CanViewContent())
+	assert.True(t, released.IsParticipant())
+
+	locked := &LessonAccess{Lesson: preview, IsEnrolled: true}
+	assert.False(t, locked.CanViewContent(), "drip lock applies to enrolled students even on previews")
+	assert.False(t, locked.IsParticipant())
+
+	visitor := &LessonAccess{Lesson: preview, Course: published, Available: true}
+	assert.True(t, visitor.CanViewContent(), "published preview")
+	assert.False(t, visitor.IsParticipant())
+	assert.False(t, (&LessonAccess{Lesson: lesson, Course: published, Available: true}).CanViewContent())
+
+	// Previews of courses outside the catalog stay hidden from visitors
+	for _, status := range []string{CourseStatusDraft, CourseStatusSubmitted, CourseStatusChangesRequested, CourseStatusArchived} {
+		hidden := &LessonAccess{Lesson: preview, Course: &Course{Status: status}, Available: true}
+		assert.False(t, hidden.CanViewContent(), status)
+	}
+}
@@ -0,0 +1,189 @@
+package coursearchive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Archive limits (protect the importer against zip bombs)
+const (
+	maxArchiveFiles     = 2000
+	maxUncompressedSize = int64(200 * 1024 * 1024) // 200MB
+)
+
+// ErrInvalidArchive is returned when the upload is not a readable course archive
+var ErrInvalidArchive = errors.New("invalid course archive")
+
+// Archive is the in-memory content of a course archive
+type Archive struct {
+	Manifest *Manifest
+	Files    map[string][]byte // lessons/*.mdx, assets/* and attachments/*, keyed by path inside the zip
+}
+
+// WriteZip writes the archive as a zip: manifest.json, lessons/*.mdx, assets/*, attachments/*
+func (a *Archive) WriteZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	manifest, err := json.MarshalIndent(a.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeZipFile(zw, manifestFile, manifest); err != nil {
+		return err
+	}
+
+	// Stable order keeps archives diff-friendly when stored in git
+	names := make([]string, 0, len(a.Files))
+	for name := range a.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeZipFile(zw, name, a.Files[name]); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// ReadZip parses a course archive. Only manifest.json, lessons/, assets/ and attachments/ entries are read;
+// anything else (e.g. a README kept next to the course in git) is ignored.
+func ReadZip(data []byte) (*Archive, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	if len(zr.File) > maxArchiveFiles {
+		return nil, fmt.Errorf("%w: more than %d files", ErrInvalidArchive, maxArchiveFiles)
+	}
+
+	archive := &Archive{Files: make(map[string][]byte)}
+	var total int64
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		name := path.Clean(strings.TrimPrefix(f.Name, "./"))
+		if name == ".." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+			return nil, fmt.Errorf("%w: illegal path %s", ErrInvalidArchive, f.Name)
+		}
+		if name != manifestFile && !strings.HasPrefix(name, lessonsDir) && !strings.HasPrefix(name, assetsDir) &&
+			!strings.HasPrefix(name, attachmentsDir) {
+			continue
+		}
+
+		total += int64(f.UncompressedSize64)
+		if total > maxUncompressedSize {
+			return nil, fmt.Errorf("%w: uncompressed content exceeds %d bytes", ErrInvalidArchive, maxUncompressedSize)
+		}
+
+		content, err := readZipFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidArchive, name, err)
+		}
+
+		if name == manifestFile {
+			var manifest Manifest
+			if err := json.Unmarshal(content, &manifest); err != nil {
+				return nil, fmt.Errorf("%w: manifest.json: %v", ErrInvalidArchive, err)
+			}
+			archive.Manifest = &manifest
+			continue
+		}
+		archive.Files[name] = content
+	}
+
+	if archive.Manifest == nil {
+		return nil, fmt.Errorf("%w: manifest.json is missing", ErrInvalidArchive)
+	}
+	return archive, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	// Never trust the header size: read at most one byte more than declared
+	content, err := io.ReadAll(io.LimitReader(rc, int64(f.UncompressedSize64)+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > int64(f.UncompressedSize64) {
+		return nil, errors.New("file is larger than declared")
+	}
+	return content, nil
+}
+
+// Asset references in MDX: Markdown images ![alt](url) and src="url" attributes
+var assetRefPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?`),
+	regexp.MustCompile(`src=["']([^"']+)["']`),
+}
+
+// findAssetRefs returns the distinct image references in MDX content, in order of appearance
+func findAssetRefs(content string) []string {
+	seen := make(map[string]bool)
+	var refs []string
+	for _, pattern := range assetRefPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+			ref := match[1]
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
+
+// rewriteAssetRefs replaces references found by findAssetRefs according to mapping
+func rewriteAssetRefs(content string, mapping map[string]string) string {
+	for _, pattern := range assetRefPatterns {
+		content = pattern.ReplaceAllStringFunc(content, func(match string) string {
+			sub := pattern.FindStringSubmatch(match)
+			if replacement, ok := mapping[sub[1]]; ok {
+				return strings.Replace(match, sub[1], replacement, 1)
+			}
+			return match
+		})
+	}
+	return content
+}
+
+// isRemoteRef reports whether a reference should be bundled on export
+func isRemoteRef(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+// resolveAssetRef resolves a reference made from a file in baseDir ("" for the manifest,
+// "lessons" for lesson content) to an assets/ path inside the archive
+func resolveAssetRef(baseDir, ref string) (string, bool) {
+	if isRemoteRef(ref) {
+		return "", false
+	}
+	resolved := path.Join(baseDir, ref)
+	if !strings.HasPrefix(resolved, assetsDir) {
+		return "", false
+	}
+	return resolved, true
+}
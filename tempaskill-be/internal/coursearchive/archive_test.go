@@ -0,0 +1,205 @@
+package coursearchive
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validManifest() *Manifest {
+	return &Manifest{
+		FormatVersion: FormatVersion,
+		Course: CourseManifest{
+			Title:       "Go Fundamentals",
+			Description: "Learn Go from scratch",
+			Category:    "Web Development",
+			Difficulty:  "beginner",
+
+			AccessDurationDays: 365,
+			MaxEnrollments:     30,
+			Tags:               []string{"golang", "backend"},
+		},
+		Sections: []SectionManifest{{Key: "section-1", Title: "Basics"}},
+		Quizzes: []QuizManifest{{
+			Key: "quiz-1", Title: "Check", PassMark: 70,
+			Questions: []QuestionManifest{{
+				Type: "single_choice", Prompt: "2 + 2?", Points: 1,
+				Options: []OptionManifest{{Text: "4", IsCorrect: true}, {Text: "5"}},
+			}},
+		}},
+		Lessons: []LessonManifest{
+			{File: "lessons/001-intro.mdx", Title: "Introduction", Type: "text", Section: "section-1",
+				Attachments: []AttachmentManifest{{File: "attachments/3f2a9c1b7d4e.pdf", Title: "Cheat sheet", FileName: "go-cheatsheet.pdf"}}},
+			{File: "lessons/002-quiz.mdx", Title: "Basics quiz", Type: "quiz", Quiz: "quiz-1",
+				Release: &ReleaseManifest{Rule: course.ReleaseRuleAfterLesson, AfterLesson: "lessons/001-intro.mdx"}},
+		},
+	}
+}
+
+// TestArchiveRoundTrip tests writing and reading back a course archive
+func TestArchiveRoundTrip(t *testing.T) {
+	archive := &Archive{
+		Manifest: validManifest(),
+		Files: map[string][]byte{
+			"lessons/001-intro.mdx":        []byte("# Intro\n\n![diagram](../assets/abc.png)"),
+			"lessons/002-quiz.mdx":         []byte("Quiz notes"),
+			"assets/abc.png":               {0x89, 'P', 'N', 'G'},
+			"attachments/3f2a9c1b7d4e.pdf": []byte("%PDF-1.4"),
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, archive.WriteZip(&buf))
+
+	read, err := ReadZip(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, archive.Manifest.Course, read.Manifest.Course)
+	assert.Equal(t, archive.Manifest.Lessons, read.Manifest.Lessons)
+	assert.Equal(t, archive.Files, read.Files)
+}
+
+func zipOf(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+// TestReadZipRejectsInvalidArchives tests the archive-level checks
+func TestReadZipRejectsInvalidArchives(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"Not a zip", []byte("hello")},
+		{"Missing manifest", zipOf(t, map[string]string{"lessons/001-a.mdx": "A"})},
+		{"Broken manifest", zipOf(t, map[string]string{"manifest.json": "{"})},
+		{"Path traversal", zipOf(t, map[string]string{"manifest.json": "{}", "../evil.mdx": "x"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadZip(tt.data)
+			assert.True(t, errors.Is(err, ErrInvalidArchive), "got %v", err)
+		})
+	}
+
+	// Unrelated files are ignored
+	archive, err := ReadZip(zipOf(t, map[string]string{"manifest.json": "{}", "README.md": "notes"}))
+	require.NoError(t, err)
+	assert.Empty(t, archive.Files)
+}
+
+// TestAssetRefs tests finding, resolving and rewriting image references in MDX
+func TestAssetRefs(t *testing.T) {
+	content := `![a](../assets/a.png) and <img src="https://cdn.example.com/b.jpg" /> ![a again](../assets/a.png)`
+
+	assert.Equal(t, []string{"../assets/a.png", "https://cdn.example.com/b.jpg"}, findAssetRefs(content))
+
+	rewritten := rewriteAssetRefs(content, map[string]string{"../assets/a.png": "https://storage/a.png"})
+	assert.Equal(t, `![a](https://storage/a.png) and <img src="https://cdn.example.com/b.jpg" /> ![a again](https://storage/a.png)`, rewritten)
+
+	resolved, ok := resolveAssetRef("lessons", "../assets/a.png")
+	assert.True(t, ok)
+	assert.Equal(t, "assets/a.png", resolved)
+
+	resolved, ok = resolveAssetRef("", "assets/cover.jpg")
+	assert.True(t, ok)
+	assert.Equal(t, "assets/cover.jpg", resolved)
+
+	_, ok = resolveAssetRef("lessons", "https://cdn.example.com/b.jpg")
+	assert.False(t, ok)
+	_, ok = resolveAssetRef("lessons", "../../etc/passwd")
+	assert.False(t, ok)
+}
+
+// TestManifestValidate tests the course-level manifest checks
+func TestManifestValidate(t *testing.T) {
+	assert.Empty(t, validManifest().Validate())
+
+	m := validManifest()
+	m.FormatVersion = 99
+	m.Course.Category = ""
+	m.Quizzes[0].Questions[0].Options[1].IsCorrect = true // Two correct answers on a single choice question
+	m.Lessons[1].File = m.Lessons[0].File
+	m.Course.MaxEnrollments = -1
+	assert.Len(t, m.Validate(), 5)
+}
+
+// TestManifestValidateLesson tests the per-lesson checks
+func TestManifestValidateLesson(t *testing.T) {
+	m := validManifest()
+	files := map[string][]byte{"lessons/001-intro.mdx": []byte("Intro")}
+
+	assert.Empty(t, m.ValidateLesson(&m.Lessons[0], files))
+
+	// Content file missing
+	assert.Len(t, m.ValidateLesson(&m.Lessons[1], files), 1)
+
+	files["lessons/002-quiz.mdx"] = []byte("Quiz")
+	broken := LessonManifest{
+		File:        "lessons/003-broken.mdx",
+		Title:       "Broken lesson",
+		Type:        "video",
+		Section:     "missing",
+		Release:     &ReleaseManifest{Rule: course.ReleaseRuleAfterLesson, AfterLesson: "lessons/404.mdx"},
+		Attachments: []AttachmentManifest{{File: "../secret.pdf", Title: "Secret", FileName: "secret.pdf"}},
+	}
+	files[broken.File] = []byte("x")
+	assert.Len(t, m.ValidateLesson(&broken, files), 4) // Unknown section, no video URL, unknown release lesson, attachment path
+}
+
+// TestSelectValidLessons tests that lessons released after a skipped lesson are skipped too
+func TestSelectValidLessons(t *testing.T) {
+	m := validManifest()
+	m.Lessons[0].Title = "" // Invalid: the quiz lesson waits for it
+	files := map[string][]byte{
+		"lessons/001-intro.mdx": []byte("Intro"),
+		"lessons/002-quiz.mdx":  []byte("Quiz"),
+	}
+
+	result := &ImportResult{}
+	valid := selectValidLessons(m, files, result)
+
+	assert.False(t, valid["lessons/001-intro.mdx"])
+	assert.False(t, valid["lessons/002-quiz.mdx"])
+	require.Len(t, result.LessonErrors, 2)
+	assert.Equal(t, "lessons/002-quiz.mdx", result.LessonErrors[1].File)
+}
+
+// TestAssetFetchRejectsNonPublicAddresses tests that export only bundles images from public hosts
+func TestAssetFetchRejectsNonPublicAddresses(t *testing.T) {
+	for _, addr := range []string{
+		"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+		"100.100.100.200", "0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1",
+	} {
+		assert.False(t, isPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+	assert.True(t, isPublicAddr(netip.MustParseAddr("142.250.4.100")))
+	assert.True(t, isPublicAddr(netip.MustParseAddr("2607:f8b0:4004:c07::64")))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("\x89PNG\r\n\x1a\n"))
+	}))
+	defer server.Close()
+
+	bundler := newAssetBundler(context.Background(), newAssetClient(), make(map[string][]byte))
+	_, _, err := bundler.fetch(server.URL + "/image.png")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "non-public address")
+}
@@ -0,0 +1,35 @@
+package coursearchive
+
+import "strings"
+
+// ImportResult reports what an import created and which lessons were skipped
+type ImportResult struct {
+	CourseID        uint          `json:"course_id"`
+	Title           string        `json:"title"`
+	Slug            string        `json:"slug"`
+	LessonsImported int           `json:"lessons_imported"`
+	LessonErrors    []LessonError `json:"lesson_errors"` // Skipped lessons
+	Warnings        []string      `json:"warnings"`      // Imported, but not exactly as exported (e.g. an asset failed to upload)
+}
+
+// LessonError lists why a lesson of the archive was not imported
+type LessonError struct {
+	File   string   `json:"file"`
+	Title  string   `json:"title"`
+	Errors []string `json:"errors"`
+}
+
+// ExportedArchive is a course archive ready to be downloaded
+type ExportedArchive struct {
+	Filename string
+	Data     []byte
+}
+
+// ManifestError rejects an archive whose manifest is invalid as a whole
+type ManifestError struct {
+	Problems []string
+}
+
+func (e *ManifestError) Error() string {
+	return "invalid course manifest: " + strings.Join(e.Problems, "; ")
+}
@@ -0,0 +1,128 @@
+package coursearchive
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize is the largest archive accepted by the import endpoint
+const maxImportSize = int64(10 * 1024 * 1024) // 10MB
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	var manifestErr *ManifestError
+	switch {
+	case errors.As(err, &manifestErr):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid course manifest", "problems": manifestErr.Problems})
+	case errors.Is(err, ErrInvalidArchive):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrCourseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ErrUnauthorized):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, course.ErrSlugTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUser returns the authenticated user's ID and role from the JWT middleware
+func getUser(c *gin.Context) (uint, string, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return userID.(uint), role, true
+}
+
+// parseID parses a numeric path parameter
+func parseID(c *gin.Context, param string, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ExportCourse handles GET /courses/:id/export
+// Downloads the course as a zip archive (manifest.json, lessons/*.mdx, assets/*)
+func (h *Handler) ExportCourse(c *gin.Context) {
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+	courseID, ok := parseID(c, "id", "course")
+	if !ok {
+		return
+	}
+
+	archive, err := h.service.ExportCourse(c.Request.Context(), userID, userRole, courseID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.Filename))
+	c.Data(http.StatusOK, "application/zip", archive.Data)
+}
+
+// ImportCourse handles POST /courses/import
+// Accepts multipart/form-data with the course archive in the "archive" field
+func (h *Handler) ImportCourse(c *gin.Context) {
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("archive")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No archive uploaded"})
+		return
+	}
+	if fileHeader.Size > maxImportSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Archive too large. Maximum: %d bytes", maxImportSize)})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read archive"})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read archive"})
+		return
+	}
+
+	result, err := h.service.ImportCourse(c.Request.Context(), userID, userRole, data)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Course imported successfully",
+		"data":    result,
+	})
+}
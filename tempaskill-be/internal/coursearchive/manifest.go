@@ -0,0 +1,324 @@
+package coursearchive
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/quiz"
+)
+
+// FormatVersion is the archive layout version written to and accepted from manifest.json
+const FormatVersion = 1
+
+// Archive layout
+const (
+	manifestFile   = "manifest.json"
+	lessonsDir     = "lessons/"
+	assetsDir      = "assets/"
+	attachmentsDir = "attachments/"
+)
+
+// Manifest describes a course archive: course metadata and settings, sections,
+// quizzes and the ordered lessons. Lesson content lives in one .mdx file per lesson,
+// referenced assets under assets/ and downloadable lesson resources under attachments/.
+type Manifest struct {
+	FormatVersion int               `json:"format_version"`
+	ExportedAt    time.Time         `json:"exported_at"`
+	Course        CourseManifest    `json:"course"`
+	Sections      []SectionManifest `json:"sections,omitempty"`
+	Quizzes       []QuizManifest    `json:"quizzes,omitempty"`
+	Lessons       []LessonManifest  `json:"lessons"`                 // In course order
+	Prerequisites []string          `json:"prerequisites,omitempty"` // Course slugs, linked on import when they exist
+}
+
+// CourseManifest holds the course metadata and settings
+type CourseManifest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Category    string `json:"category"`
+	Difficulty  string `json:"difficulty"`
+	Price       int    `json:"price"`
+	Thumbnail   string `json:"thumbnail,omitempty"` // assets/... or an external URL
+
+	AccessDurationDays int      `json:"access_duration_days,omitempty"` // 0 = lifetime access
+	MaxEnrollments     int      `json:"max_enrollments,omitempty"`      // 0 = unlimited
+	Tags               []string `json:"tags,omitempty"`                 // Tag slugs, linked on import when they exist
+}
+
+// SectionManifest is a section; lessons refer to it by Key
+type SectionManifest struct {
+	Key         string `json:"key"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// QuizManifest is a quiz with its question bank; quiz lessons refer to it by Key
+type QuizManifest struct {
+	Key                 string             `json:"key"`
+	Title               string             `json:"title"`
+	Description         string             `json:"description,omitempty"`
+	PassMark            int                `json:"pass_mark"`
+	MaxAttempts         int                `json:"max_attempts"`
+	QuestionsPerAttempt int                `json:"questions_per_attempt"`
+	ShuffleQuestions    bool               `json:"shuffle_questions"`
+	ShuffleOptions      bool               `json:"shuffle_options"`
+	Questions           []QuestionManifest `json:"questions"`
+}
+
+// QuestionManifest is a quiz question
+type QuestionManifest struct {
+	Type            string           `json:"type"`
+	Prompt          string           `json:"prompt"`
+	Explanation     string           `json:"explanation,omitempty"`
+	Points          int              `json:"points"`
+	AcceptedAnswers []string         `json:"accepted_answers,omitempty"`
+	CaseSensitive   bool             `json:"case_sensitive,omitempty"`
+	Options         []OptionManifest `json:"options,omitempty"`
+}
+
+// OptionManifest is a choice of a quiz question
+type OptionManifest struct {
+	Text      string `json:"text"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// LessonManifest is a lesson; File is its .mdx content inside the archive
+type LessonManifest struct {
+	File        string                    `json:"file"` // lessons/001-intro.mdx
+	Title       string                    `json:"title"`
+	Type        string                    `json:"type"`
+	Section     string                    `json:"section,omitempty"` // SectionManifest.Key
+	Duration    int                       `json:"duration"`
+	IsPublished bool                      `json:"is_published"`
+	IsPreview   bool                      `json:"is_preview"`
+	Video       *course.VideoPayload      `json:"video,omitempty"`
+	Quiz        string                    `json:"quiz,omitempty"` // QuizManifest.Key
+	Assignment  *course.AssignmentPayload `json:"assignment,omitempty"`
+	Release     *ReleaseManifest          `json:"release,omitempty"`
+	Attachments []AttachmentManifest      `json:"attachments,omitempty"`
+}
+
+// AttachmentManifest is a downloadable lesson resource; File is its content inside the archive
+type AttachmentManifest struct {
+	File     string `json:"file"` // attachments/3f2a9c1b7d4e.pdf
+	Title    string `json:"title"`
+	FileName string `json:"file_name"` // Name the file is downloaded as
+}
+
+// ReleaseManifest is a drip release rule; AfterLesson is the File of another lesson
+type ReleaseManifest struct {
+	Rule        string     `json:"rule"`
+	At          *time.Time `json:"at,omitempty"`
+	AfterDays   int        `json:"after_days,omitempty"`
+	AfterLesson string     `json:"after_lesson,omitempty"`
+}
+
+// Validate checks the course-level parts of the manifest. Any problem here rejects the archive.
+func (m *Manifest) Validate() []string {
+	var problems []string
+	if m.FormatVersion != FormatVersion {
+		problems = append(problems, fmt.Sprintf("unsupported format_version %d (expected %d)", m.FormatVersion, FormatVersion))
+	}
+
+	title := strings.TrimSpace(m.Course.Title)
+	if len(title) < 3 || len(title) > 200 {
+		problems = append(problems, "course.title must be 3-200 characters")
+	}
+	if len(strings.TrimSpace(m.Course.Description)) < 10 {
+		problems = append(problems, "course.description must be at least 10 characters")
+	}
+	if strings.TrimSpace(m.Course.Category) == "" {
+		problems = append(problems, "course.category is required") // Checked against the categories table on import
+	}
+	switch m.Course.Difficulty {
+	case "beginner", "intermediate", "advanced":
+	default:
+		problems = append(problems, fmt.Sprintf("course.difficulty %q must be beginner, intermediate or advanced", m.Course.Difficulty))
+	}
+	if m.Course.Price < 0 {
+		problems = append(problems, "course.price cannot be negative")
+	}
+	if m.Course.AccessDurationDays < 0 || m.Course.AccessDurationDays > 3650 {
+		problems = append(problems, "course.access_duration_days must be 0-3650")
+	}
+	if m.Course.MaxEnrollments < 0 {
+		problems = append(problems, "course.max_enrollments cannot be negative")
+	}
+	if len(m.Course.Tags) > 10 {
+		problems = append(problems, "course.tags can have at most 10 tags")
+	}
+
+	sectionKeys := make(map[string]bool, len(m.Sections))
+	for i, section := range m.Sections {
+		if section.Key == "" || sectionKeys[section.Key] {
+			problems = append(problems, fmt.Sprintf("sections[%d].key must be set and unique", i))
+		}
+		if strings.TrimSpace(section.Title) == "" {
+			problems = append(problems, fmt.Sprintf("sections[%d].title is required", i))
+		}
+		sectionKeys[section.Key] = true
+	}
+
+	quizKeys := make(map[string]bool, len(m.Quizzes))
+	for i := range m.Quizzes {
+		q := &m.Quizzes[i]
+		if q.Key == "" || quizKeys[q.Key] {
+			problems = append(problems, fmt.Sprintf("quizzes[%d].key must be set and unique", i))
+		}
+		quizKeys[q.Key] = true
+		problems = append(problems, validateQuiz(fmt.Sprintf("quizzes[%d]", i), q)...)
+	}
+
+	files := make(map[string]bool, len(m.Lessons))
+	for i, lesson := range m.Lessons {
+		if !strings.HasPrefix(lesson.File, lessonsDir) || !strings.HasSuffix(lesson.File, ".mdx") || files[lesson.File] {
+			problems = append(problems, fmt.Sprintf("lessons[%d].file must be a unique lessons/*.mdx path", i))
+		}
+		files[lesson.File] = true
+	}
+
+	return problems
+}
+
+// ValidateLesson checks one lesson against the manifest and the archive contents.
+// Problems only skip that lesson; the rest of the course is still imported.
+func (m *Manifest) ValidateLesson(lesson *LessonManifest, files map[string][]byte) []string {
+	var problems []string
+
+	title := strings.TrimSpace(lesson.Title)
+	if len(title) < 3 || len(title) > 200 {
+		problems = append(problems, "title must be 3-200 characters")
+	}
+	if _, ok := files[lesson.File]; !ok {
+		problems = append(problems, fmt.Sprintf("content file %s is missing from the archive", lesson.File))
+	}
+	if lesson.Section != "" && m.findSection(lesson.Section) == nil {
+		problems = append(problems, fmt.Sprintf("unknown section %q", lesson.Section))
+	}
+
+	switch lesson.Type {
+	case course.LessonTypeText:
+	case course.LessonTypeVideo:
+		if lesson.Video == nil || lesson.Video.URL == "" {
+			problems = append(problems, "video lessons require video.url")
+		}
+	case course.LessonTypeQuiz:
+		if lesson.Quiz == "" || m.findQuiz(lesson.Quiz) == nil {
+			problems = append(problems, fmt.Sprintf("quiz lessons require a quiz defined in the manifest (got %q)", lesson.Quiz))
+		}
+	case course.LessonTypeAssignment:
+		if lesson.Assignment == nil || len(strings.TrimSpace(lesson.Assignment.Brief)) < 10 {
+			problems = append(problems, "assignment lessons require assignment.brief (at least 10 characters)")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("invalid lesson type %q", lesson.Type))
+	}
+
+	for i, attachment := range lesson.Attachments {
+		if !strings.HasPrefix(attachment.File, attachmentsDir) {
+			problems = append(problems, fmt.Sprintf("attachments[%d].file must be an attachments/ path", i))
+		}
+		if strings.TrimSpace(attachment.Title) == "" || strings.TrimSpace(attachment.FileName) == "" {
+			problems = append(problems, fmt.Sprintf("attachments[%d] requires title and file_name", i))
+		}
+	}
+
+	if release := lesson.Release; release != nil {
+		switch release.Rule {
+		case course.ReleaseRuleImmediate:
+		case course.ReleaseRuleFixedDate:
+			if release.At == nil {
+				problems = append(problems, "release rule fixed_date requires at")
+			}
+		case course.ReleaseRuleDaysAfterEnrollment:
+			if release.AfterDays < 1 {
+				problems = append(problems, "release rule days_after_enrollment requires after_days")
+			}
+		case course.ReleaseRuleAfterLesson:
+			if release.AfterLesson == lesson.File || m.findLesson(release.AfterLesson) == nil {
+				problems = append(problems, fmt.Sprintf("release rule after_lesson refers to unknown lesson %q", release.AfterLesson))
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("invalid release rule %q", release.Rule))
+		}
+	}
+
+	return problems
+}
+
+// validateQuiz applies the rules of the quiz API to a quiz of the manifest
+func validateQuiz(label string, q *QuizManifest) []string {
+	var problems []string
+	if strings.TrimSpace(q.Title) == "" {
+		problems = append(problems, label+".title is required")
+	}
+	if q.PassMark < 1 || q.PassMark > 100 {
+		problems = append(problems, label+".pass_mark must be 1-100")
+	}
+	if q.MaxAttempts < 0 || q.QuestionsPerAttempt < 0 {
+		problems = append(problems, label+".max_attempts and questions_per_attempt cannot be negative")
+	}
+
+	for i, question := range q.Questions {
+		qLabel := fmt.Sprintf("%s.questions[%d]", label, i)
+		if strings.TrimSpace(question.Prompt) == "" {
+			problems = append(problems, qLabel+".prompt is required")
+		}
+		if question.Points < 0 {
+			problems = append(problems, qLabel+".points cannot be negative")
+		}
+
+		correct := 0
+		for _, option := range question.Options {
+			if option.IsCorrect {
+				correct++
+			}
+		}
+		switch question.Type {
+		case quiz.QuestionTypeShortAnswer:
+			if len(question.AcceptedAnswers) == 0 {
+				problems = append(problems, qLabel+" requires accepted_answers")
+			}
+		case quiz.QuestionTypeSingleChoice, quiz.QuestionTypeTrueFalse:
+			if len(question.Options) < 2 || correct != 1 {
+				problems = append(problems, qLabel+" requires at least 2 options with exactly one correct")
+			}
+		case quiz.QuestionTypeMultipleChoice:
+			if len(question.Options) < 2 || correct < 1 {
+				problems = append(problems, qLabel+" requires at least 2 options with at least one correct")
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("%s.type %q is not a valid question type", qLabel, question.Type))
+		}
+	}
+	return problems
+}
+
+func (m *Manifest) findSection(key string) *SectionManifest {
+	for i := range m.Sections {
+		if m.Sections[i].Key == key {
+			return &m.Sections[i]
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) findQuiz(key string) *QuizManifest {
+	for i := range m.Quizzes {
+		if m.Quizzes[i].Key == key {
+			return &m.Quizzes[i]
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) findLesson(file string) *LessonManifest {
+	for i := range m.Lessons {
+		if m.Lessons[i].File == file {
+			return &m.Lessons[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+package coursearchive
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/quiz"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// CourseTree is a course with everything a course archive carries
+type CourseTree struct {
+	Course            *course.Course
+	Sections          []*course.Section
+	Lessons           []*course.Lesson                    // In course order
+	Attachments       map[uint][]*course.LessonAttachment // Lesson ID => attachments in order
+	Quizzes           []*quiz.Quiz                        // With questions and options
+	PrerequisiteSlugs []string
+	TagSlugs          []string
+}
+
+// ImportPlan is a validated archive ready to be written. Entities reference each other
+// by archive keys, which CreateCourseTree resolves to the new IDs.
+type ImportPlan struct {
+	Course            *course.Course
+	Sections          []*PlannedSection
+	Quizzes           []*PlannedQuiz
+	Lessons           []*PlannedLesson
+	PrerequisiteSlugs []string
+	TagSlugs          []string
+	AuthorID          uint
+}
+
+// PlannedSection is a section to create, with its archive key
+type PlannedSection struct {
+	Key     string
+	Section *course.Section
+}
+
+// PlannedQuiz is a quiz (with questions and options) to create, with its archive key
+type PlannedQuiz struct {
+	Key  string
+	Quiz *quiz.Quiz
+}
+
+// PlannedLesson is a lesson to create with the archive keys it refers to
+type PlannedLesson struct {
+	File        string
+	SectionKey  string
+	QuizKey     string
+	AfterLesson string // File of the lesson an after_lesson rule waits for
+	Lesson      *course.Lesson
+	Attachments []*course.LessonAttachment // Already uploaded to storage
+}
+
+type Repository interface {
+	FindCourseTree(ctx context.Context, courseID uint) (*CourseTree, error)
+	CreateCourseTree(ctx context.Context, plan *ImportPlan) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// FindCourseTree loads a course with its sections, lessons, attachments, quizzes, and
+// prerequisite and tag slugs
+func (r *repository) FindCourseTree(ctx context.Context, courseID uint) (*CourseTree, error) {
+	db := r.db.WithContext(ctx)
+	tree := &CourseTree{Course: &course.Course{}, Attachments: make(map[uint][]*course.LessonAttachment)}
+
+	if err := db.First(tree.Course, courseID).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("course_id = ?", courseID).Order("order_index ASC, id ASC").Find(&tree.Sections).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("course_id = ?", courseID).Order("order_index ASC, id ASC").Find(&tree.Lessons).Error; err != nil {
+		return nil, err
+	}
+	if err := db.
+		Preload("Questions", func(db *gorm.DB) *gorm.DB { return db.Order("order_index ASC, id ASC") }).
+		Preload("Questions.Options", func(db *gorm.DB) *gorm.DB { return db.Order("order_index ASC, id ASC") }).
+		Where("course_id = ?", courseID).
+		Order("id ASC").
+		Find(&tree.Quizzes).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Table("course_prerequisites").
+		Joins("INNER JOIN courses ON courses.id = course_prerequisites.prerequisite_course_id AND courses.deleted_at IS NULL").
+		Where("course_prerequisites.course_id = ?", courseID).
+		Order("course_prerequisites.id ASC").
+		Pluck("courses.slug", &tree.PrerequisiteSlugs).Error; err != nil {
+		return nil, err
+	}
+
+	var attachments []*course.LessonAttachment
+	if err := db.Where("course_id = ?", courseID).Order("order_index ASC, id ASC").Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	for _, attachment := range attachments {
+		tree.Attachments[attachment.LessonID] = append(tree.Attachments[attachment.LessonID], attachment)
+	}
+	if err := db.Table("course_tags").
+		Joins("INNER JOIN tags ON tags.id = course_tags.tag_id").
+		Where("course_tags.course_id = ?", courseID).
+		Order("tags.name ASC").
+		Pluck("tags.slug", &tree.TagSlugs).Error; err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// CreateCourseTree writes an import plan in one transaction. Lessons get their content
+// as a published first revision, like lessons created through the API.
+func (r *repository) CreateCourseTree(ctx context.Context, plan *ImportPlan) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Instructor", "Sections", "Lessons", "Enrollments").Create(plan.Course).Error; err != nil {
+			if course.IsDuplicateKey(err) {
+				return course.ErrSlugTaken
+			}
+			return err
+		}
+		courseID := plan.Course.ID
+
+		sectionIDs := make(map[string]uint, len(plan.Sections))
+		for _, planned := range plan.Sections {
+			planned.Section.CourseID = courseID
+			if err := tx.Create(planned.Section).Error; err != nil {
+				return err
+			}
+			sectionIDs[planned.Key] = planned.Section.ID
+		}
+
+		quizIDs := make(map[string]uint, len(plan.Quizzes))
+		for _, planned := range plan.Quizzes {
+			planned.Quiz.CourseID = courseID
+			planned.Quiz.CreatedBy = plan.AuthorID
+			if err := tx.Create(planned.Quiz).Error; err != nil { // Questions and options are created with it
+				return err
+			}
+			quizIDs[planned.Key] = planned.Quiz.ID
+		}
+
+		now := time.Now()
+		lessonIDs := make(map[string]uint, len(plan.Lessons))
+		for _, planned := range plan.Lessons {
+			lesson := planned.Lesson
+			lesson.CourseID = courseID
+			if planned.SectionKey != "" {
+				sectionID := sectionIDs[planned.SectionKey]
+				lesson.SectionID = &sectionID
+			}
+			if planned.QuizKey != "" {
+				quizID := quizIDs[planned.QuizKey]
+				lesson.QuizID = &quizID
+			}
+			if err := tx.Create(lesson).Error; err != nil {
+				return err
+			}
+
+			revision := &course.LessonRevision{
+				LessonID:    lesson.ID,
+				Version:     1,
+				Title:       lesson.Title,
+				Content:     lesson.Content,
+				Duration:    lesson.Duration,
+				AuthorID:    plan.AuthorID,
+				PublishedAt: &now,
+			}
+			revision.SetPayload(lesson)
+			if err := tx.Create(revision).Error; err != nil {
+				return err
+			}
+			lesson.PublishedRevisionID = &revision.ID
+			if err := tx.Model(lesson).Update("published_revision_id", revision.ID).Error; err != nil {
+				return err
+			}
+			lessonIDs[planned.File] = lesson.ID
+
+			for i, attachment := range planned.Attachments {
+				attachment.LessonID = lesson.ID
+				attachment.CourseID = courseID
+				attachment.OrderIndex = i
+				attachment.UploadedBy = plan.AuthorID
+				if err := tx.Create(attachment).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		// after_lesson rules can point forward, so they are linked once every lesson exists
+		for _, planned := range plan.Lessons {
+			if planned.AfterLesson == "" {
+				continue
+			}
+			afterID := lessonIDs[planned.AfterLesson]
+			planned.Lesson.ReleaseAfterLessonID = &afterID
+			if err := tx.Model(planned.Lesson).Update("release_after_lesson_id", afterID).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(plan.PrerequisiteSlugs) > 0 {
+			var prerequisiteIDs []uint
+			if err := tx.Model(&course.Course{}).Where("slug IN ?", plan.PrerequisiteSlugs).
+				Pluck("id", &prerequisiteIDs).Error; err != nil {
+				return err
+			}
+			for _, id := range prerequisiteIDs {
+				if err := tx.Create(&course.CoursePrerequisite{CourseID: courseID, PrerequisiteCourseID: id}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(plan.TagSlugs) > 0 {
+			var tagIDs []uint
+			if err := tx.Model(&course.Tag{}).Where("slug IN ?", plan.TagSlugs).Pluck("id", &tagIDs).Error; err != nil {
+				return err
+			}
+			for _, id := range tagIDs {
+				if err := tx.Create(&course.CourseTag{CourseID: courseID, TagID: id}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error("Failed to import course archive",
+			zap.Error(err),
+			zap.String("title", plan.Course.Title),
+		)
+		return err
+	}
+	return nil
+}
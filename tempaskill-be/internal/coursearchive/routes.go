@@ -0,0 +1,24 @@
+package coursearchive
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, uploadService upload.Service) {
+	// Initialize layers
+	repo := NewRepository(db)
+	service := NewService(repo, course.NewRepository(db), uploadService)
+	handler := NewHandler(service)
+
+	// Protected routes (authorization checked in service layer)
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.GET("/courses/:id/export", handler.ExportCourse) // Download course archive (zip)
+		protected.POST("/courses/import", handler.ImportCourse)    // Recreate a course from an archive
+	}
+}
@@ -0,0 +1,688 @@
+package coursearchive
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/quiz"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/upload"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCourseNotFound = errors.New("course not found")
+	ErrUnauthorized   = errors.New("unauthorized to perform this action")
+)
+
+// Asset bundling limits on export (imported assets go through the upload service limits)
+const (
+	maxAssetSize       = int64(5 * 1024 * 1024) // 5MB, same as image uploads
+	assetFetchTimeout  = 15 * time.Second
+	maxAssetRedirects  = 5
+	importAssetsFolder = "courses"
+	importFilesFolder  = "lesson-attachments" // Same folder as attachments uploaded through the API
+)
+
+// Image types bundled on export, by detected content type
+var assetExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type Service interface {
+	ExportCourse(ctx context.Context, userID uint, userRole string, courseID uint) (*ExportedArchive, error)
+	ImportCourse(ctx context.Context, userID uint, userRole string, data []byte) (*ImportResult, error)
+}
+
+type service struct {
+	repo          Repository
+	courseRepo    course.Repository
+	uploadService upload.Service
+	httpClient    *http.Client
+}
+
+func NewService(repo Repository, courseRepo course.Repository, uploadService upload.Service) Service {
+	return &service{
+		repo:          repo,
+		courseRepo:    courseRepo,
+		uploadService: uploadService,
+		httpClient:    newAssetClient(),
+	}
+}
+
+// newAssetClient returns the client that downloads lesson images on export. Image URLs are
+// author content, so every connection (redirects included) must go to a public address;
+// the check runs on the resolved IP when dialing, so DNS tricks cannot get around it.
+func newAssetClient() *http.Client {
+	dialer := &net.Dialer{Timeout: assetFetchTimeout, Control: checkPublicAddress}
+	return &http.Client{
+		Timeout: assetFetchTimeout,
+		// No proxy: the dialed address must be the asset host itself
+		Transport: &http.Transport{DialContext: dialer.DialContext, TLSHandshakeTimeout: 10 * time.Second},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxAssetRedirects {
+				return errors.New("too many redirects")
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// Shared, benchmarking and NAT64 ranges that are not caught by the netip checks
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// checkPublicAddress is a net.Dialer Control func rejecting loopback, private, link-local
+// and other non-public addresses (e.g. the cloud metadata endpoint)
+func checkPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !isPublicAddr(ip) {
+		return fmt.Errorf("refusing to fetch asset from non-public address %s", ip)
+	}
+	return nil
+}
+
+func isPublicAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false // Also rules out loopback, link-local, multicast and unspecified
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Helper: Exporting needs the same access as editing lessons (owner, admin, co-instructor, editor)
+func (s *service) canExport(ctx context.Context, c *course.Course, userID uint, userRole string) bool {
+	if c.InstructorID == userID || userRole == "admin" {
+		return true
+	}
+	collaborator, err := s.courseRepo.FindCollaborator(ctx, c.ID, userID)
+	if err != nil {
+		return false
+	}
+	return course.RoleAllows(collaborator.Role, course.PermissionEditContent)
+}
+
+// ExportCourse packs the live (published revision) content of a course into an archive.
+// Remote images referenced by lessons and the thumbnail are bundled under assets/;
+// an image that cannot be downloaded keeps its original URL. Lesson attachments are
+// bundled under attachments/; one that cannot be read from storage is left out.
+func (s *service) ExportCourse(ctx context.Context, userID uint, userRole string, courseID uint) (*ExportedArchive, error) {
+	tree, err := s.repo.FindCourseTree(ctx, courseID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCourseNotFound
+		}
+		return nil, err
+	}
+	if !s.canExport(ctx, tree.Course, userID, userRole) {
+		return nil, ErrUnauthorized
+	}
+
+	archive := &Archive{Files: make(map[string][]byte)}
+	assets := newAssetBundler(ctx, s.httpClient, archive.Files)
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		ExportedAt:    time.Now(),
+		Course: CourseManifest{
+			Title:       tree.Course.Title,
+			Description: tree.Course.Description,
+			Category:    tree.Course.Category,
+			Difficulty:  tree.Course.Difficulty,
+			Price:       tree.Course.Price,
+			Thumbnail:   assets.bundle(tree.Course.ThumbnailURL, ""),
+
+			AccessDurationDays: tree.Course.AccessDays,
+			MaxEnrollments:     tree.Course.MaxEnrollments,
+			Tags:               tree.TagSlugs,
+		},
+		Prerequisites: tree.PrerequisiteSlugs,
+	}
+
+	sectionKeys := make(map[uint]string, len(tree.Sections))
+	for i, section := range tree.Sections {
+		key := fmt.Sprintf("section-%d", i+1)
+		sectionKeys[section.ID] = key
+		manifest.Sections = append(manifest.Sections, SectionManifest{
+			Key:         key,
+			Title:       section.Title,
+			Description: section.Description,
+		})
+	}
+
+	quizKeys := make(map[uint]string, len(tree.Quizzes))
+	for i, q := range tree.Quizzes {
+		key := fmt.Sprintf("quiz-%d", i+1)
+		quizKeys[q.ID] = key
+		manifest.Quizzes = append(manifest.Quizzes, toQuizManifest(key, q))
+	}
+
+	lessonFiles := make(map[uint]string, len(tree.Lessons))
+	for i, lesson := range tree.Lessons {
+		slug := lesson.Slug
+		if slug == "" {
+			slug = course.GenerateSlug(lesson.Title)
+		}
+		lessonFiles[lesson.ID] = fmt.Sprintf("%s%03d-%s.mdx", lessonsDir, i+1, slug)
+	}
+
+	for _, lesson := range tree.Lessons {
+		file := lessonFiles[lesson.ID]
+		entry := LessonManifest{
+			File:        file,
+			Title:       lesson.Title,
+			Type:        lesson.Type,
+			Duration:    lesson.Duration,
+			IsPublished: lesson.IsPublished,
+			IsPreview:   lesson.IsPreview,
+		}
+		if lesson.SectionID != nil {
+			entry.Section = sectionKeys[*lesson.SectionID]
+		}
+
+		switch lesson.Type {
+		case course.LessonTypeVideo:
+			entry.Video = &course.VideoPayload{
+				URL:             lesson.VideoURL,
+				DurationSeconds: lesson.VideoDuration,
+				Captions:        lesson.CaptionTracks,
+			}
+		case course.LessonTypeQuiz:
+			if lesson.QuizID != nil {
+				entry.Quiz = quizKeys[*lesson.QuizID]
+			}
+		case course.LessonTypeAssignment:
+			entry.Assignment = &course.AssignmentPayload{
+				Brief:    assets.bundleRefs(lesson.AssignmentBrief, ""),
+				MaxScore: lesson.AssignmentMaxScore,
+			}
+		}
+
+		if lesson.ReleaseRule != "" && lesson.ReleaseRule != course.ReleaseRuleImmediate {
+			entry.Release = &ReleaseManifest{
+				Rule:      lesson.ReleaseRule,
+				At:        lesson.ReleaseAt,
+				AfterDays: lesson.ReleaseAfterDays,
+			}
+			if lesson.ReleaseAfterLessonID != nil {
+				entry.Release.AfterLesson = lessonFiles[*lesson.ReleaseAfterLessonID]
+			}
+		}
+
+		for _, attachment := range tree.Attachments[lesson.ID] {
+			data, err := s.uploadService.ReadFile(ctx, attachment.FilePath)
+			if err != nil {
+				logger.Warn("Course export: leaving out lesson attachment",
+					zap.Error(err),
+					zap.Uint("attachment_id", attachment.ID),
+				)
+				continue
+			}
+			sum := sha1.Sum([]byte(attachment.FilePath))
+			attachmentFile := attachmentsDir + hex.EncodeToString(sum[:])[:12] + strings.ToLower(path.Ext(attachment.FileName))
+			archive.Files[attachmentFile] = data
+			entry.Attachments = append(entry.Attachments, AttachmentManifest{
+				File:     attachmentFile,
+				Title:    attachment.Title,
+				FileName: attachment.FileName,
+			})
+		}
+
+		manifest.Lessons = append(manifest.Lessons, entry)
+		archive.Files[file] = []byte(assets.bundleRefs(lesson.Content, "lessons"))
+	}
+
+	archive.Manifest = manifest
+
+	var buf bytes.Buffer
+	if err := archive.WriteZip(&buf); err != nil {
+		return nil, err
+	}
+
+	return &ExportedArchive{
+		Filename: tree.Course.Slug + ".zip",
+		Data:     buf.Bytes(),
+	}, nil
+}
+
+// Helper: Convert a quiz with its questions and options to the manifest format
+func toQuizManifest(key string, q *quiz.Quiz) QuizManifest {
+	entry := QuizManifest{
+		Key:                 key,
+		Title:               q.Title,
+		Description:         q.Description,
+		PassMark:            q.PassMark,
+		MaxAttempts:         q.MaxAttempts,
+		QuestionsPerAttempt: q.QuestionsPerAttempt,
+		ShuffleQuestions:    q.ShuffleQuestions,
+		ShuffleOptions:      q.ShuffleOptions,
+	}
+	for _, question := range q.Questions {
+		qm := QuestionManifest{
+			Type:            question.Type,
+			Prompt:          question.Prompt,
+			Explanation:     question.Explanation,
+			Points:          question.Points,
+			AcceptedAnswers: question.AcceptedAnswers,
+			CaseSensitive:   question.CaseSensitive,
+		}
+		for _, option := range question.Options {
+			qm.Options = append(qm.Options, OptionManifest{Text: option.Text, IsCorrect: option.IsCorrect})
+		}
+		entry.Questions = append(entry.Questions, qm)
+	}
+	return entry
+}
+
+// assetBundler downloads remote images into the archive, once per URL
+type assetBundler struct {
+	ctx    context.Context
+	client *http.Client
+	files  map[string][]byte
+	paths  map[string]string // URL -> assets/ path ("" when it could not be bundled)
+}
+
+func newAssetBundler(ctx context.Context, client *http.Client, files map[string][]byte) *assetBundler {
+	return &assetBundler{ctx: ctx, client: client, files: files, paths: make(map[string]string)}
+}
+
+// bundle returns the reference to use for url from a file in baseDir
+func (b *assetBundler) bundle(url, baseDir string) string {
+	if !isRemoteRef(url) {
+		return url
+	}
+
+	assetPath, done := b.paths[url]
+	if !done {
+		data, ext, err := b.fetch(url)
+		if err != nil {
+			logger.Warn("Course export: keeping remote asset URL",
+				zap.Error(err),
+				zap.String("url", url),
+			)
+		} else {
+			sum := sha1.Sum([]byte(url))
+			assetPath = assetsDir + hex.EncodeToString(sum[:])[:12] + ext
+			b.files[assetPath] = data
+		}
+		b.paths[url] = assetPath
+	}
+
+	if assetPath == "" {
+		return url
+	}
+	if baseDir == "" {
+		return assetPath
+	}
+	return "../" + assetPath
+}
+
+// bundleRefs bundles every image referenced in MDX content written to baseDir
+func (b *assetBundler) bundleRefs(content, baseDir string) string {
+	mapping := make(map[string]string)
+	for _, ref := range findAssetRefs(content) {
+		if local := b.bundle(ref, baseDir); local != ref {
+			mapping[ref] = local
+		}
+	}
+	if len(mapping) == 0 {
+		return content
+	}
+	return rewriteAssetRefs(content, mapping)
+}
+
+func (b *assetBundler) fetch(url string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(b.ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAssetSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxAssetSize {
+		return nil, "", fmt.Errorf("asset larger than %d bytes", maxAssetSize)
+	}
+	ext, ok := assetExtensions[http.DetectContentType(data)]
+	if !ok {
+		return nil, "", errors.New("not a supported image type")
+	}
+	return data, ext, nil
+}
+
+// ImportCourse recreates a course from an archive as a new unpublished course owned by the caller.
+// The manifest must be valid as a whole; invalid lessons are skipped and reported in the result.
+func (s *service) ImportCourse(ctx context.Context, userID uint, userRole string, data []byte) (*ImportResult, error) {
+	if userRole != "instructor" && userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+
+	archive, err := ReadZip(data)
+	if err != nil {
+		return nil, err
+	}
+	manifest := archive.Manifest
+	problems := manifest.Validate()
+	if manifest.Course.Category != "" {
+		exists, err := s.courseRepo.CategoryExists(ctx, manifest.Course.Category)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			problems = append(problems, fmt.Sprintf("course.category %q is not a valid category", manifest.Course.Category))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ManifestError{Problems: problems}
+	}
+
+	result := &ImportResult{
+		LessonErrors: []LessonError{},
+		Warnings:     []string{},
+	}
+	valid := selectValidLessons(manifest, archive.Files, result)
+
+	assets := newAssetUploader(ctx, s.uploadService, archive.Files, result)
+	plan := &ImportPlan{
+		Course: &course.Course{
+			Title:          strings.TrimSpace(manifest.Course.Title),
+			Description:    manifest.Course.Description,
+			ThumbnailURL:   assets.upload(manifest.Course.Thumbnail, ""),
+			Category:       manifest.Course.Category,
+			Difficulty:     manifest.Course.Difficulty,
+			InstructorID:   userID,
+			Price:          manifest.Course.Price,
+			AccessDays:     manifest.Course.AccessDurationDays,
+			MaxEnrollments: manifest.Course.MaxEnrollments,
+			IsPublished:    false,
+			Status:         course.CourseStatusDraft,
+		},
+		PrerequisiteSlugs: manifest.Prerequisites,
+		TagSlugs:          manifest.Course.Tags,
+		AuthorID:          userID,
+	}
+
+	for i, section := range manifest.Sections {
+		plan.Sections = append(plan.Sections, &PlannedSection{
+			Key: section.Key,
+			Section: &course.Section{
+				Title:       section.Title,
+				Description: section.Description,
+				OrderIndex:  i + 1,
+			},
+		})
+	}
+
+	for _, qm := range manifest.Quizzes {
+		plan.Quizzes = append(plan.Quizzes, &PlannedQuiz{Key: qm.Key, Quiz: toQuiz(&qm)})
+	}
+
+	for i := range manifest.Lessons {
+		entry := &manifest.Lessons[i]
+		if !valid[entry.File] {
+			continue
+		}
+
+		lesson := &course.Lesson{
+			Title:       strings.TrimSpace(entry.Title),
+			Slug:        course.GenerateSlug(entry.Title),
+			Type:        entry.Type,
+			Content:     assets.uploadRefs(string(archive.Files[entry.File]), "lessons"),
+			OrderIndex:  len(plan.Lessons) + 1,
+			Duration:    entry.Duration,
+			IsPublished: entry.IsPublished,
+			IsPreview:   entry.IsPreview,
+			ReleaseRule: course.ReleaseRuleImmediate,
+		}
+		planned := &PlannedLesson{File: entry.File, SectionKey: entry.Section, Lesson: lesson}
+
+		switch entry.Type {
+		case course.LessonTypeVideo:
+			lesson.VideoURL = entry.Video.URL
+			lesson.VideoDuration = entry.Video.DurationSeconds
+			lesson.CaptionTracks = entry.Video.Captions
+		case course.LessonTypeQuiz:
+			planned.QuizKey = entry.Quiz
+		case course.LessonTypeAssignment:
+			lesson.AssignmentBrief = assets.uploadRefs(entry.Assignment.Brief, "")
+			lesson.AssignmentMaxScore = entry.Assignment.MaxScore
+		}
+
+		if release := entry.Release; release != nil {
+			lesson.ReleaseRule = release.Rule
+			switch release.Rule {
+			case course.ReleaseRuleFixedDate:
+				lesson.ReleaseAt = release.At
+			case course.ReleaseRuleDaysAfterEnrollment:
+				lesson.ReleaseAfterDays = release.AfterDays
+			case course.ReleaseRuleAfterLesson:
+				planned.AfterLesson = release.AfterLesson
+			}
+		}
+
+		for _, am := range entry.Attachments {
+			if attachment := s.uploadAttachment(ctx, &am, archive.Files, result); attachment != nil {
+				planned.Attachments = append(planned.Attachments, attachment)
+			}
+		}
+
+		plan.Lessons = append(plan.Lessons, planned)
+	}
+
+	// The course slug is picked at save time and retried when a concurrent save takes it
+	if err := course.SaveWithUniqueSlug(course.GenerateSlug(manifest.Course.Title), "course", func(slug string) (bool, error) {
+		return s.courseRepo.SlugExists(ctx, slug, 0)
+	}, func(slug string) error {
+		plan.Course.Slug = slug
+		return s.repo.CreateCourseTree(ctx, plan)
+	}); err != nil {
+		return nil, err
+	}
+
+	result.CourseID = plan.Course.ID
+	result.Title = plan.Course.Title
+	result.Slug = plan.Course.Slug
+	result.LessonsImported = len(plan.Lessons)
+	return result, nil
+}
+
+// uploadAttachment stores an archive attachment as a private file. A missing file or a
+// failed upload leaves the attachment out and is reported as a warning.
+func (s *service) uploadAttachment(ctx context.Context, am *AttachmentManifest, files map[string][]byte, result *ImportResult) *course.LessonAttachment {
+	data, ok := files[am.File]
+	if !ok {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("attachment %s is missing from the archive", am.File))
+		return nil
+	}
+	uploaded, err := s.uploadService.UploadFileData(ctx, am.FileName, data, importFilesFolder)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("attachment %s could not be uploaded: %v", am.File, err))
+		return nil
+	}
+	return &course.LessonAttachment{
+		Title:    strings.TrimSpace(am.Title),
+		FileName: path.Base(am.FileName),
+		FilePath: uploaded.Path,
+		FileSize: uploaded.Size,
+		MimeType: uploaded.MimeType,
+	}
+}
+
+// selectValidLessons validates each lesson and records the failures in result.
+// A lesson released after a skipped lesson is skipped too, so this repeats until stable.
+func selectValidLessons(manifest *Manifest, files map[string][]byte, result *ImportResult) map[string]bool {
+	valid := make(map[string]bool, len(manifest.Lessons))
+	problems := make(map[string][]string, len(manifest.Lessons))
+	for i := range manifest.Lessons {
+		entry := &manifest.Lessons[i]
+		if errs := manifest.ValidateLesson(entry, files); len(errs) > 0 {
+			problems[entry.File] = errs
+		} else {
+			valid[entry.File] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for i := range manifest.Lessons {
+			entry := &manifest.Lessons[i]
+			if !valid[entry.File] || entry.Release == nil || entry.Release.Rule != course.ReleaseRuleAfterLesson {
+				continue
+			}
+			if !valid[entry.Release.AfterLesson] {
+				valid[entry.File] = false
+				problems[entry.File] = append(problems[entry.File],
+					fmt.Sprintf("release rule waits for lesson %q, which was not imported", entry.Release.AfterLesson))
+				changed = true
+			}
+		}
+	}
+
+	for _, entry := range manifest.Lessons {
+		if errs, ok := problems[entry.File]; ok {
+			result.LessonErrors = append(result.LessonErrors, LessonError{File: entry.File, Title: entry.Title, Errors: errs})
+		}
+	}
+	return valid
+}
+
+// Helper: Convert a manifest quiz to a quiz with questions and options, ready to be created
+func toQuiz(qm *QuizManifest) *quiz.Quiz {
+	q := &quiz.Quiz{
+		Title:               strings.TrimSpace(qm.Title),
+		Description:         qm.Description,
+		PassMark:            qm.PassMark,
+		MaxAttempts:         qm.MaxAttempts,
+		QuestionsPerAttempt: qm.QuestionsPerAttempt,
+		ShuffleQuestions:    qm.ShuffleQuestions,
+		ShuffleOptions:      qm.ShuffleOptions,
+	}
+	for i, question := range qm.Questions {
+		points := question.Points
+		if points == 0 {
+			points = 1
+		}
+		entry := quiz.Question{
+			Type:            question.Type,
+			Prompt:          question.Prompt,
+			Explanation:     question.Explanation,
+			Points:          points,
+			OrderIndex:      i,
+			AcceptedAnswers: question.AcceptedAnswers,
+			CaseSensitive:   question.CaseSensitive,
+		}
+		for j, option := range question.Options {
+			entry.Options = append(entry.Options, quiz.QuestionOption{
+				Text:       option.Text,
+				IsCorrect:  option.IsCorrect,
+				OrderIndex: j,
+			})
+		}
+		q.Questions = append(q.Questions, entry)
+	}
+	return q
+}
+
+// assetUploader uploads archive assets to storage, once per file
+type assetUploader struct {
+	ctx           context.Context
+	uploadService upload.Service
+	files         map[string][]byte
+	result        *ImportResult
+	urls          map[string]string // assets/ path -> public URL ("" when the upload failed)
+}
+
+func newAssetUploader(ctx context.Context, uploadService upload.Service, files map[string][]byte, result *ImportResult) *assetUploader {
+	return &assetUploader{ctx: ctx, uploadService: uploadService, files: files, result: result, urls: make(map[string]string)}
+}
+
+// upload returns the public URL for ref made from a file in baseDir, or ref unchanged
+// when it is not an archive asset or could not be uploaded (reported as a warning)
+func (u *assetUploader) upload(ref, baseDir string) string {
+	assetPath, ok := resolveAssetRef(baseDir, ref)
+	if !ok {
+		return ref
+	}
+
+	url, done := u.urls[assetPath]
+	if !done {
+		data, exists := u.files[assetPath]
+		switch {
+		case !exists:
+			u.result.Warnings = append(u.result.Warnings, fmt.Sprintf("asset %s is missing from the archive", assetPath))
+		default:
+			uploaded, err := u.uploadService.UploadImageData(u.ctx, path.Base(assetPath), data, importAssetsFolder)
+			if err != nil {
+				u.result.Warnings = append(u.result.Warnings, fmt.Sprintf("asset %s could not be uploaded: %v", assetPath, err))
+			} else {
+				url = uploaded.URL
+			}
+		}
+		u.urls[assetPath] = url
+	}
+
+	if url == "" {
+		return ref
+	}
+	return url
+}
+
+// uploadRefs uploads every archive asset referenced in MDX content from baseDir
+func (u *assetUploader) uploadRefs(content, baseDir string) string {
+	mapping := make(map[string]string)
+	for _, ref := range findAssetRefs(content) {
+		if url := u.upload(ref, baseDir); url != ref {
+			mapping[ref] = url
+		}
+	}
+	if len(mapping) == 0 {
+		return content
+	}
+	return rewriteAssetRefs(content, mapping)
+}
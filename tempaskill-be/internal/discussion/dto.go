@@ -0,0 +1,63 @@
+package discussion
+
+import "time"
+
+// CreateCommentRequest represents a new thread (no parent) or a reply
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required,min=1,max=5000"`
+	Type     string `json:"type" binding:"omitempty,oneof=comment question"` // Threads only, default comment
+	ParentID *uint  `json:"parent_id"`
+}
+
+// UpdateCommentRequest represents an author's edit; Type can only change on threads
+type UpdateCommentRequest struct {
+	Body *string `json:"body" binding:"omitempty,min=1,max=5000"`
+	Type *string `json:"type" binding:"omitempty,oneof=comment question"`
+}
+
+// CommentListQuery represents query parameters for listing a lesson's threads
+type CommentListQuery struct {
+	Page       int    `form:"page" binding:"omitempty,min=1"`
+	Limit      int    `form:"limit" binding:"omitempty,min=1,max=50"`
+	Sort       string `form:"sort" binding:"omitempty,oneof=newest oldest top"` // default newest
+	Type       string `form:"type" binding:"omitempty,oneof=comment question"`
+	Unanswered bool   `form:"unanswered"` // Questions without an accepted answer
+}
+
+// CommentResponse is a comment as seen by the requesting user. Threads carry their replies,
+// the accepted answer first, then oldest first.
+type CommentResponse struct {
+	ID               uint               `json:"id"`
+	LessonID         uint               `json:"lesson_id"`
+	ParentID         *uint              `json:"parent_id"`
+	UserID           uint               `json:"user_id"`
+	AuthorName       string             `json:"author_name"`
+	AuthorIsStaff    bool               `json:"author_is_staff"` // Instructor or collaborator badge
+	Type             string             `json:"type"`
+	Body             string             `json:"body"`
+	AcceptedAnswerID *uint              `json:"accepted_answer_id,omitempty"`
+	IsAcceptedAnswer bool               `json:"is_accepted_answer"`
+	UpvoteCount      int                `json:"upvote_count"`
+	HasUpvoted       bool               `json:"has_upvoted"`
+	ReplyCount       int                `json:"reply_count"`
+	IsHidden         bool               `json:"is_hidden"` // Only moderators see hidden comments
+	EditedAt         *time.Time         `json:"edited_at"`
+	CanEdit          bool               `json:"can_edit"`
+	CanDelete        bool               `json:"can_delete"`
+	CreatedAt        time.Time          `json:"created_at"`
+	Replies          []*CommentResponse `json:"replies,omitempty"`
+}
+
+// CommentListResponse represents a page of a lesson's threads
+type CommentListResponse struct {
+	Threads    []*CommentResponse `json:"threads"`
+	Pagination PaginationMeta     `json:"pagination"`
+}
+
+// PaginationMeta represents pagination information
+type PaginationMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
@@ -0,0 +1,295 @@
+package discussion
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	switch err {
+	case ErrLessonNotFound, ErrCommentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrNotParticipant, ErrUnauthorized, ErrEditWindowClosed, ErrDeleteWindowClosed:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case ErrNotQuestion, ErrOwnComment:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUser returns the authenticated user's ID and role from the JWT middleware
+func getUser(c *gin.Context) (uint, string, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return userID.(uint), role, true
+}
+
+// parseID parses the :id path parameter (a lesson or comment ID)
+func parseID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ListComments handles GET /lessons/:id/discussions
+func (h *Handler) ListComments(c *gin.Context) {
+	lessonID, ok := parseID(c, "lesson")
+	if !ok {
+		return
+	}
+
+	var query CommentListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListComments(c.Request.Context(), userID, userRole, lessonID, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// CreateComment handles POST /lessons/:id/discussions
+func (h *Handler) CreateComment(c *gin.Context) {
+	lessonID, ok := parseID(c, "lesson")
+	if !ok {
+		return
+	}
+
+	var req CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.service.CreateComment(c.Request.Context(), userID, userRole, lessonID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Comment posted successfully",
+		"data":    comment,
+	})
+}
+
+// UpdateComment handles PATCH /discussions/:id
+func (h *Handler) UpdateComment(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	var req UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	comment, err := h.service.UpdateComment(c.Request.Context(), userID, userRole, commentID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment updated successfully",
+		"data":    comment,
+	})
+}
+
+// DeleteComment handles DELETE /discussions/:id
+func (h *Handler) DeleteComment(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteComment(c.Request.Context(), userID, userRole, commentID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment deleted successfully",
+	})
+}
+
+// Upvote handles POST /discussions/:id/upvote
+func (h *Handler) Upvote(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Upvote(c.Request.Context(), userID, userRole, commentID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment upvoted",
+	})
+}
+
+// RemoveUpvote handles DELETE /discussions/:id/upvote
+func (h *Handler) RemoveUpvote(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveUpvote(c.Request.Context(), userID, userRole, commentID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Upvote removed",
+	})
+}
+
+// AcceptAnswer handles POST /discussions/:id/accept
+func (h *Handler) AcceptAnswer(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.AcceptAnswer(c.Request.Context(), userID, userRole, commentID, true); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Answer accepted",
+	})
+}
+
+// UnacceptAnswer handles DELETE /discussions/:id/accept
+func (h *Handler) UnacceptAnswer(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.AcceptAnswer(c.Request.Context(), userID, userRole, commentID, false); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Accepted answer removed",
+	})
+}
+
+// HideComment handles POST /discussions/:id/hide
+func (h *Handler) HideComment(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.SetHidden(c.Request.Context(), userID, userRole, commentID, true); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment hidden",
+	})
+}
+
+// UnhideComment handles DELETE /discussions/:id/hide
+func (h *Handler) UnhideComment(c *gin.Context) {
+	commentID, ok := parseID(c, "comment")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.SetHidden(c.Request.Context(), userID, userRole, commentID, false); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Comment restored",
+	})
+}
@@ -0,0 +1,78 @@
+package discussion
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Comment types (threads only; replies are always comments)
+const (
+	CommentTypeComment  = "comment"
+	CommentTypeQuestion = "question"
+)
+
+// Notification types sent to students
+const (
+	NotificationDiscussionReply = "discussion_reply" // Someone replied to your thread
+	NotificationAnswerAccepted  = "answer_accepted"  // The course team accepted your answer
+)
+
+// Edit and delete windows for authors; moderators (course team, admins) are not limited
+const (
+	editWindow   = 30 * time.Minute
+	deleteWindow = 24 * time.Hour
+)
+
+// Comment is a post in a lesson's discussion: a thread (comment or question) or a reply
+// to one. Replies are one level deep; replying to a reply adds to the same thread.
+type Comment struct {
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	LessonID         uint           `gorm:"not null;index:idx_lesson_comments_lesson_parent" json:"lesson_id"`
+	CourseID         uint           `gorm:"not null;index" json:"course_id"`
+	UserID           uint           `gorm:"not null;index" json:"user_id"`
+	ParentID         *uint          `gorm:"index:idx_lesson_comments_lesson_parent" json:"parent_id"` // nil = thread
+	Type             string         `gorm:"type:varchar(20);not null;default:'comment'" json:"type"`  // comment, question
+	Body             string         `gorm:"type:text;not null" json:"body"`
+	AcceptedAnswerID *uint          `json:"accepted_answer_id"` // Questions: reply accepted by the course team
+	UpvoteCount      int            `gorm:"not null;default:0" json:"upvote_count"`
+	ReplyCount       int            `gorm:"not null;default:0" json:"reply_count"`   // Threads only
+	IsHidden         bool           `gorm:"not null;default:false" json:"is_hidden"` // Hidden by a moderator
+	EditedAt         *time.Time     `json:"edited_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// CommentUpvote records one user's upvote of a comment
+type CommentUpvote struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CommentID uint      `gorm:"not null;uniqueIndex:idx_lesson_comment_upvotes_pair" json:"comment_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_lesson_comment_upvotes_pair" json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsThread reports whether the comment starts a thread (rather than replying to one)
+func (c *Comment) IsThread() bool {
+	return c.ParentID == nil
+}
+
+// CanEdit reports whether the author may still edit the comment
+func (c *Comment) CanEdit(userID uint, now time.Time) bool {
+	return c.UserID == userID && now.Sub(c.CreatedAt) <= editWindow
+}
+
+// CanDelete reports whether the author may still delete the comment
+func (c *Comment) CanDelete(userID uint, now time.Time) bool {
+	return c.UserID == userID && now.Sub(c.CreatedAt) <= deleteWindow
+}
+
+// TableName specifies the table name for Comment model
+func (Comment) TableName() string {
+	return "lesson_comments"
+}
+
+// TableName specifies the table name for CommentUpvote model
+func (CommentUpvote) TableName() string {
+	return "lesson_comment_upvotes"
+}
@@ -0,0 +1,225 @@
+package discussion
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateComment(ctx context.Context, comment *Comment) error
+	FindCommentByID(ctx context.Context, id uint) (*Comment, error)
+	FindThreads(ctx context.Context, lessonID uint, query *CommentListQuery, includeHidden bool, limit, offset int) ([]*Comment, int, error)
+	FindReplies(ctx context.Context, threadIDs []uint, includeHidden bool) ([]*Comment, error)
+	UpdateComment(ctx context.Context, comment *Comment) error
+	DeleteComment(ctx context.Context, comment *Comment) error
+	SetAcceptedAnswer(ctx context.Context, threadID uint, answerID *uint) error
+	SetHidden(ctx context.Context, id uint, hidden bool) error
+
+	// Upvotes
+	AddUpvote(ctx context.Context, commentID, userID uint) error
+	RemoveUpvote(ctx context.Context, commentID, userID uint) error
+	FindUpvotedIDs(ctx context.Context, userID uint, commentIDs []uint) (map[uint]bool, error)
+
+	// Authors (users is owned by the auth module)
+	FindUserNames(ctx context.Context, userIDs []uint) (map[uint]string, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// CreateComment saves a comment; a reply also bumps its thread's reply count
+func (r *repository) CreateComment(ctx context.Context, comment *Comment) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(comment).Error; err != nil {
+			return err
+		}
+		if comment.ParentID == nil {
+			return nil
+		}
+		return tx.Model(&Comment{}).Where("id = ?", *comment.ParentID).
+			UpdateColumn("reply_count", gorm.Expr("reply_count + ?", 1)).Error
+	})
+	if err != nil {
+		logger.Error("Failed to create lesson comment",
+			zap.Error(err),
+			zap.Uint("lesson_id", comment.LessonID),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) FindCommentByID(ctx context.Context, id uint) (*Comment, error) {
+	var comment Comment
+	if err := r.db.WithContext(ctx).First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// FindThreads returns a page of a lesson's threads with the total count
+func (r *repository) FindThreads(ctx context.Context, lessonID uint, query *CommentListQuery, includeHidden bool, limit, offset int) ([]*Comment, int, error) {
+	db := r.db.WithContext(ctx).Model(&Comment{}).Where("lesson_id = ? AND parent_id IS NULL", lessonID)
+	if !includeHidden {
+		db = db.Where("is_hidden = ?", false)
+	}
+	if query.Type != "" {
+		db = db.Where("type = ?", query.Type)
+	}
+	if query.Unanswered {
+		db = db.Where("type = ? AND accepted_answer_id IS NULL", CommentTypeQuestion)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "created_at DESC, id DESC"
+	switch query.Sort {
+	case "oldest":
+		order = "created_at ASC, id ASC"
+	case "top":
+		order = "upvote_count DESC, created_at DESC, id DESC"
+	}
+
+	var threads []*Comment
+	if err := db.Order(order).Limit(limit).Offset(offset).Find(&threads).Error; err != nil {
+		logger.Error("Database error finding lesson threads",
+			zap.Error(err),
+			zap.Uint("lesson_id", lessonID),
+		)
+		return nil, 0, err
+	}
+	return threads, int(total), nil
+}
+
+// FindReplies returns the replies of the given threads, oldest first
+func (r *repository) FindReplies(ctx context.Context, threadIDs []uint, includeHidden bool) ([]*Comment, error) {
+	var replies []*Comment
+	if len(threadIDs) == 0 {
+		return replies, nil
+	}
+	db := r.db.WithContext(ctx).Where("parent_id IN ?", threadIDs)
+	if !includeHidden {
+		db = db.Where("is_hidden = ?", false)
+	}
+	err := db.Order("created_at ASC, id ASC").Find(&replies).Error
+	return replies, err
+}
+
+func (r *repository) UpdateComment(ctx context.Context, comment *Comment) error {
+	return r.db.WithContext(ctx).Model(comment).
+		Select("body", "type", "accepted_answer_id", "edited_at").
+		Updates(comment).Error
+}
+
+// DeleteComment soft-deletes a comment. A thread takes its replies with it; a reply is
+// taken off its thread's count and, if it was the accepted answer, unaccepted.
+func (r *repository) DeleteComment(ctx context.Context, comment *Comment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(comment).Error; err != nil {
+			return err
+		}
+		if comment.ParentID == nil {
+			return tx.Where("parent_id = ?", comment.ID).Delete(&Comment{}).Error
+		}
+
+		if err := tx.Model(&Comment{}).Where("id = ? AND reply_count > 0", *comment.ParentID).
+			UpdateColumn("reply_count", gorm.Expr("reply_count - ?", 1)).Error; err != nil {
+			return err
+		}
+		return tx.Model(&Comment{}).Where("id = ? AND accepted_answer_id = ?", *comment.ParentID, comment.ID).
+			UpdateColumn("accepted_answer_id", nil).Error
+	})
+}
+
+// SetAcceptedAnswer sets (or with nil clears) the accepted answer of a question
+func (r *repository) SetAcceptedAnswer(ctx context.Context, threadID uint, answerID *uint) error {
+	return r.db.WithContext(ctx).Model(&Comment{}).Where("id = ?", threadID).
+		UpdateColumn("accepted_answer_id", answerID).Error
+}
+
+func (r *repository) SetHidden(ctx context.Context, id uint, hidden bool) error {
+	return r.db.WithContext(ctx).Model(&Comment{}).Where("id = ?", id).
+		UpdateColumn("is_hidden", hidden).Error
+}
+
+// Upvotes
+
+// AddUpvote records the user's upvote; upvoting twice counts once
+func (r *repository) AddUpvote(ctx context.Context, commentID, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&CommentUpvote{CommentID: commentID, UserID: userID, CreatedAt: time.Now()})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&Comment{}).Where("id = ?", commentID).
+			UpdateColumn("upvote_count", gorm.Expr("upvote_count + ?", 1)).Error
+	})
+}
+
+func (r *repository) RemoveUpvote(ctx context.Context, commentID, userID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("comment_id = ? AND user_id = ?", commentID, userID).Delete(&CommentUpvote{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Model(&Comment{}).Where("id = ? AND upvote_count > 0", commentID).
+			UpdateColumn("upvote_count", gorm.Expr("upvote_count - ?", 1)).Error
+	})
+}
+
+// FindUpvotedIDs returns which of the comments the user has upvoted
+func (r *repository) FindUpvotedIDs(ctx context.Context, userID uint, commentIDs []uint) (map[uint]bool, error) {
+	upvoted := make(map[uint]bool)
+	if userID == 0 || len(commentIDs) == 0 {
+		return upvoted, nil
+	}
+
+	var ids []uint
+	if err := r.db.WithContext(ctx).Model(&CommentUpvote{}).
+		Where("user_id = ? AND comment_id IN ?", userID, commentIDs).
+		Pluck("comment_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		upvoted[id] = true
+	}
+	return upvoted, nil
+}
+
+// Authors
+
+func (r *repository) FindUserNames(ctx context.Context, userIDs []uint) (map[uint]string, error) {
+	names := make(map[uint]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return names, nil
+	}
+
+	var rows []struct {
+		ID   uint
+		Name string
+	}
+	if err := r.db.WithContext(ctx).Table("users").
+		Select("id, name").
+		Where("id IN ?", userIDs).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		names[row.ID] = row.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,35 @@
+package discussion
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, notifier course.Notifier) {
+	// Initialize layers
+	repo := NewRepository(db)
+	courseRepo := course.NewRepository(db)
+	service := NewService(repo, courseRepo, course.NewService(courseRepo, notifier), notifier)
+	handler := NewHandler(service)
+
+	// All discussion routes require authentication
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// Threads and replies (enrolled students and the course team, same access as the lesson)
+		protected.GET("/lessons/:id/discussions", handler.ListComments)   // Threads with replies (?sort=newest|oldest|top&type=&unanswered=)
+		protected.POST("/lessons/:id/discussions", handler.CreateComment) // Start a thread or reply (parent_id)
+		protected.PATCH("/discussions/:id", handler.UpdateComment)        // Author edit (30 minute window)
+		protected.DELETE("/discussions/:id", handler.DeleteComment)       // Author delete (24 hour window) or moderator
+		protected.POST("/discussions/:id/upvote", handler.Upvote)         // Upvote
+		protected.DELETE("/discussions/:id/upvote", handler.RemoveUpvote) // Remove upvote
+
+		// Moderation (instructor, co-instructors/TAs or admin - authorization checked in service layer)
+		protected.POST("/discussions/:id/accept", handler.AcceptAnswer)     // Accept reply as the question's answer
+		protected.DELETE("/discussions/:id/accept", handler.UnacceptAnswer) // Remove accepted answer
+		protected.POST("/discussions/:id/hide", handler.HideComment)        // Hide from students
+		protected.DELETE("/discussions/:id/hide", handler.UnhideComment)    // Show again
+	}
+}
@@ -0,0 +1,407 @@
+package discussion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrLessonNotFound     = errors.New("lesson not found")
+	ErrCommentNotFound    = errors.New("comment not found")
+	ErrNotParticipant     = errors.New("only enrolled students and the course team can join the discussion")
+	ErrUnauthorized       = errors.New("unauthorized access")
+	ErrEditWindowClosed   = errors.New("comments can only be edited within 30 minutes of posting")
+	ErrDeleteWindowClosed = errors.New("comments can only be deleted within 24 hours of posting")
+	ErrNotQuestion        = errors.New("only replies to a question can be accepted as its answer")
+	ErrOwnComment         = errors.New("you cannot upvote your own comment")
+)
+
+type Service interface {
+	// Enrolled students and the course team (same access rules as course.Service.GetLesson)
+	ListComments(ctx context.Context, userID uint, userRole string, lessonID uint, query *CommentListQuery) (*CommentListResponse, error)
+	CreateComment(ctx context.Context, userID uint, userRole string, lessonID uint, req *CreateCommentRequest) (*CommentResponse, error)
+	UpdateComment(ctx context.Context, userID uint, userRole string, commentID uint, req *UpdateCommentRequest) (*CommentResponse, error)
+	DeleteComment(ctx context.Context, userID uint, userRole string, commentID uint) error
+	Upvote(ctx context.Context, userID uint, userRole string, commentID uint) error
+	RemoveUpvote(ctx context.Context, userID uint, userRole string, commentID uint) error
+
+	// Moderation (course instructor, co-instructors/TAs or admin)
+	AcceptAnswer(ctx context.Context, userID uint, userRole string, commentID uint, accepted bool) error
+	SetHidden(ctx context.Context, userID uint, userRole string, commentID uint, hidden bool) error
+}
+
+type service struct {
+	repo          Repository
+	courseRepo    course.Repository
+	courseService course.Service
+	notifier      course.Notifier
+}
+
+func NewService(repo Repository, courseRepo course.Repository, courseService course.Service, notifier course.Notifier) Service {
+	return &service{
+		repo:          repo,
+		courseRepo:    courseRepo,
+		courseService: courseService,
+		notifier:      notifier,
+	}
+}
+
+// viewer is the requesting user's standing in a lesson's discussion
+type viewer struct {
+	userID    uint
+	moderator bool          // Course instructor, co-instructors/TAs or admin
+	staffIDs  map[uint]bool // Course team, for author badges
+	course    *course.Course
+	lessonID  uint
+}
+
+// Helper: Check the user takes part in the lesson's discussion. Access follows
+// course.Service.GetLesson: the course team and enrolled students the lesson is released to.
+// Admins may always read and moderate.
+func (s *service) viewerFor(ctx context.Context, userID uint, userRole string, lessonID uint) (*viewer, error) {
+	access, err := s.courseService.CheckLessonAccess(ctx, userID, lessonID)
+	if err != nil {
+		switch err {
+		case course.ErrLessonNotFound, course.ErrCourseNotFound:
+			return nil, ErrLessonNotFound
+		case course.ErrUnauthorized:
+			return nil, ErrNotParticipant
+		}
+		return nil, err
+	}
+	if userRole != "admin" && !access.IsParticipant() {
+		return nil, ErrNotParticipant
+	}
+
+	v := &viewer{
+		userID:    userID,
+		moderator: userRole == "admin" || access.Course.InstructorID == userID,
+		staffIDs:  map[uint]bool{access.Course.InstructorID: true},
+		course:    access.Course,
+		lessonID:  lessonID,
+	}
+	collaborators, err := s.courseRepo.FindCollaborators(ctx, access.Course.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, collaborator := range collaborators {
+		v.staffIDs[collaborator.UserID] = true
+		if collaborator.UserID == userID && course.RoleAllows(collaborator.Role, course.PermissionTeach) {
+			v.moderator = true
+		}
+	}
+	return v, nil
+}
+
+// Helper: Load a comment the user can see, with their standing in its lesson
+func (s *service) loadComment(ctx context.Context, userID uint, userRole string, commentID uint) (*Comment, *viewer, error) {
+	comment, err := s.repo.FindCommentByID(ctx, commentID)
+	if err != nil {
+		return nil, nil, ErrCommentNotFound
+	}
+	v, err := s.viewerFor(ctx, userID, userRole, comment.LessonID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if comment.IsHidden && !v.moderator {
+		return nil, nil, ErrCommentNotFound
+	}
+	return comment, v, nil
+}
+
+// Helper: Convert a comment for the viewer
+func toResponse(comment *Comment, v *viewer, names map[uint]string, upvoted map[uint]bool, now time.Time) *CommentResponse {
+	return &CommentResponse{
+		ID:               comment.ID,
+		LessonID:         comment.LessonID,
+		ParentID:         comment.ParentID,
+		UserID:           comment.UserID,
+		AuthorName:       names[comment.UserID],
+		AuthorIsStaff:    v.staffIDs[comment.UserID],
+		Type:             comment.Type,
+		Body:             comment.Body,
+		AcceptedAnswerID: comment.AcceptedAnswerID,
+		UpvoteCount:      comment.UpvoteCount,
+		HasUpvoted:       upvoted[comment.ID],
+		ReplyCount:       comment.ReplyCount,
+		IsHidden:         comment.IsHidden,
+		EditedAt:         comment.EditedAt,
+		CanEdit:          comment.CanEdit(v.userID, now),
+		CanDelete:        v.moderator || comment.CanDelete(v.userID, now),
+		CreatedAt:        comment.CreatedAt,
+	}
+}
+
+// buildThreads nests replies (oldest first) under their threads, with the accepted answer
+// of a question moved to the top
+func buildThreads(threads, replies []*Comment, v *viewer, names map[uint]string, upvoted map[uint]bool, now time.Time) []*CommentResponse {
+	result := make([]*CommentResponse, 0, len(threads))
+	byID := make(map[uint]*CommentResponse, len(threads))
+	for _, thread := range threads {
+		resp := toResponse(thread, v, names, upvoted, now)
+		resp.Replies = []*CommentResponse{}
+		byID[thread.ID] = resp
+		result = append(result, resp)
+	}
+
+	for _, reply := range replies {
+		thread, ok := byID[*reply.ParentID]
+		if !ok {
+			continue
+		}
+		resp := toResponse(reply, v, names, upvoted, now)
+		resp.IsAcceptedAnswer = thread.AcceptedAnswerID != nil && *thread.AcceptedAnswerID == reply.ID
+		thread.Replies = append(thread.Replies, resp)
+	}
+
+	for _, thread := range result {
+		sort.SliceStable(thread.Replies, func(i, j int) bool {
+			return thread.Replies[i].IsAcceptedAnswer && !thread.Replies[j].IsAcceptedAnswer
+		})
+	}
+	return result
+}
+
+// Helper: Tell a user about activity on their post. Notifications are best effort:
+// the post is already saved.
+func (s *service) notify(ctx context.Context, v *viewer, userID uint, notificationType, title, message string, threadID uint) {
+	if userID == v.userID {
+		return
+	}
+	link := fmt.Sprintf("/courses/%s/lessons/%d#comment-%d", v.course.Slug, v.lessonID, threadID)
+	if err := s.notifier.Notify(ctx, userID, notificationType, title, message, link); err != nil {
+		logger.Warn("Failed to send discussion notification",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+			zap.String("type", notificationType),
+		)
+	}
+}
+
+// Enrolled students and the course team
+
+func (s *service) ListComments(ctx context.Context, userID uint, userRole string, lessonID uint, query *CommentListQuery) (*CommentListResponse, error) {
+	v, err := s.viewerFor(ctx, userID, userRole, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 {
+		limit = 20
+	}
+
+	threads, total, err := s.repo.FindThreads(ctx, lessonID, query, v.moderator, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	threadIDs := make([]uint, len(threads))
+	for i, thread := range threads {
+		threadIDs[i] = thread.ID
+	}
+	replies, err := s.repo.FindReplies(ctx, threadIDs, v.moderator)
+	if err != nil {
+		return nil, err
+	}
+
+	commentIDs := threadIDs
+	userIDs := make([]uint, 0, len(threads)+len(replies))
+	for _, thread := range threads {
+		userIDs = append(userIDs, thread.UserID)
+	}
+	for _, reply := range replies {
+		commentIDs = append(commentIDs, reply.ID)
+		userIDs = append(userIDs, reply.UserID)
+	}
+	names, err := s.repo.FindUserNames(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	upvoted, err := s.repo.FindUpvotedIDs(ctx, userID, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommentListResponse{
+		Threads: buildThreads(threads, replies, v, names, upvoted, time.Now()),
+		Pagination: PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+func (s *service) CreateComment(ctx context.Context, userID uint, userRole string, lessonID uint, req *CreateCommentRequest) (*CommentResponse, error) {
+	v, err := s.viewerFor(ctx, userID, userRole, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &Comment{
+		LessonID: lessonID,
+		CourseID: v.course.ID,
+		UserID:   userID,
+		Type:     CommentTypeComment,
+		Body:     req.Body,
+	}
+
+	// Replies join the parent's thread; only threads can be questions
+	var thread *Comment
+	if req.ParentID != nil {
+		if thread, err = s.repo.FindCommentByID(ctx, *req.ParentID); err == nil && !thread.IsThread() {
+			thread, err = s.repo.FindCommentByID(ctx, *thread.ParentID)
+		}
+		if err != nil || thread.LessonID != lessonID || (thread.IsHidden && !v.moderator) {
+			return nil, ErrCommentNotFound
+		}
+		comment.ParentID = &thread.ID
+	} else if req.Type != "" {
+		comment.Type = req.Type
+	}
+
+	if err := s.repo.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	if thread != nil {
+		s.notify(ctx, v, thread.UserID, NotificationDiscussionReply, "New reply",
+			fmt.Sprintf("Someone replied to your post in \"%s\".", v.course.Title), thread.ID)
+	}
+
+	names, _ := s.repo.FindUserNames(ctx, []uint{userID})
+	return toResponse(comment, v, names, nil, time.Now()), nil
+}
+
+// UpdateComment lets the author edit a comment within the edit window. Turning a question
+// back into a comment drops its accepted answer.
+func (s *service) UpdateComment(ctx context.Context, userID uint, userRole string, commentID uint, req *UpdateCommentRequest) (*CommentResponse, error) {
+	comment, v, err := s.loadComment(ctx, userID, userRole, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != userID {
+		return nil, ErrUnauthorized
+	}
+	now := time.Now()
+	if !comment.CanEdit(userID, now) {
+		return nil, ErrEditWindowClosed
+	}
+
+	if req.Body != nil {
+		comment.Body = *req.Body
+	}
+	if req.Type != nil && comment.IsThread() {
+		comment.Type = *req.Type
+		if comment.Type != CommentTypeQuestion {
+			comment.AcceptedAnswerID = nil
+		}
+	}
+	comment.EditedAt = &now
+
+	if err := s.repo.UpdateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+
+	names, _ := s.repo.FindUserNames(ctx, []uint{userID})
+	return toResponse(comment, v, names, nil, now), nil
+}
+
+// DeleteComment lets the author delete a comment within the delete window; moderators
+// may delete any comment
+func (s *service) DeleteComment(ctx context.Context, userID uint, userRole string, commentID uint) error {
+	comment, v, err := s.loadComment(ctx, userID, userRole, commentID)
+	if err != nil {
+		return err
+	}
+	if !v.moderator {
+		if comment.UserID != userID {
+			return ErrUnauthorized
+		}
+		if !comment.CanDelete(userID, time.Now()) {
+			return ErrDeleteWindowClosed
+		}
+	}
+	return s.repo.DeleteComment(ctx, comment)
+}
+
+func (s *service) Upvote(ctx context.Context, userID uint, userRole string, commentID uint) error {
+	comment, _, err := s.loadComment(ctx, userID, userRole, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.UserID == userID {
+		return ErrOwnComment
+	}
+	return s.repo.AddUpvote(ctx, commentID, userID)
+}
+
+func (s *service) RemoveUpvote(ctx context.Context, userID uint, userRole string, commentID uint) error {
+	if _, _, err := s.loadComment(ctx, userID, userRole, commentID); err != nil {
+		return err
+	}
+	return s.repo.RemoveUpvote(ctx, commentID, userID)
+}
+
+// Moderation
+
+// AcceptAnswer marks a reply as the accepted answer of its question (replacing any earlier
+// one), or with accepted=false takes the mark off again
+func (s *service) AcceptAnswer(ctx context.Context, userID uint, userRole string, commentID uint, accepted bool) error {
+	reply, v, err := s.loadComment(ctx, userID, userRole, commentID)
+	if err != nil {
+		return err
+	}
+	if !v.moderator {
+		return ErrUnauthorized
+	}
+	if reply.IsThread() {
+		return ErrNotQuestion
+	}
+	thread, err := s.repo.FindCommentByID(ctx, *reply.ParentID)
+	if err != nil {
+		return ErrCommentNotFound
+	}
+	if thread.Type != CommentTypeQuestion {
+		return ErrNotQuestion
+	}
+
+	if !accepted {
+		if thread.AcceptedAnswerID == nil || *thread.AcceptedAnswerID != reply.ID {
+			return nil
+		}
+		return s.repo.SetAcceptedAnswer(ctx, thread.ID, nil)
+	}
+
+	if err := s.repo.SetAcceptedAnswer(ctx, thread.ID, &reply.ID); err != nil {
+		return err
+	}
+	s.notify(ctx, v, reply.UserID, NotificationAnswerAccepted, "Answer accepted",
+		fmt.Sprintf("Your answer in \"%s\" was accepted by the course team.", v.course.Title), thread.ID)
+	return nil
+}
+
+// SetHidden hides a comment from students (or shows it again). Hidden threads hide their
+// replies too.
+func (s *service) SetHidden(ctx context.Context, userID uint, userRole string, commentID uint, hidden bool) error {
+	comment, v, err := s.loadComment(ctx, userID, userRole, commentID)
+	if err != nil {
+		return err
+	}
+	if !v.moderator {
+		return ErrUnauthorized
+	}
+	return s.repo.SetHidden(ctx, comment.ID, hidden)
+}
@@ -0,0 +1,56 @@
+package discussion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCommentWindows tests the author edit and delete windows
+func TestCommentWindows(t *testing.T) {
+	posted := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	comment := &Comment{UserID: 7, CreatedAt: posted}
+
+	assert.True(t, comment.CanEdit(7, posted.Add(30*time.Minute)))
+	assert.False(t, comment.CanEdit(7, posted.Add(31*time.Minute)))
+	assert.False(t, comment.CanEdit(8, posted), "not the author")
+
+	assert.True(t, comment.CanDelete(7, posted.Add(24*time.Hour)))
+	assert.False(t, comment.CanDelete(7, posted.Add(25*time.Hour)))
+	assert.False(t, comment.CanDelete(8, posted), "not the author")
+}
+
+// TestBuildThreads tests nesting replies under their threads with the accepted answer first
+func TestBuildThreads(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	threadID, acceptedID := uint(1), uint(12)
+	threads := []*Comment{
+		{ID: threadID, UserID: 2, Type: CommentTypeQuestion, AcceptedAnswerID: &acceptedID, CreatedAt: now},
+		{ID: 2, UserID: 3, Type: CommentTypeComment, CreatedAt: now},
+	}
+	replies := []*Comment{
+		{ID: 11, UserID: 3, ParentID: &threadID, CreatedAt: now},
+		{ID: acceptedID, UserID: 9, ParentID: &threadID, CreatedAt: now},
+		{ID: 13, UserID: 2, ParentID: &threadID, CreatedAt: now},
+	}
+	v := &viewer{userID: 2, staffIDs: map[uint]bool{9: true}}
+
+	result := buildThreads(threads, replies, v, map[uint]string{9: "Instructor"}, map[uint]bool{12: true}, now)
+	require.Len(t, result, 2)
+
+	question := result[0]
+	require.Len(t, question.Replies, 3)
+	assert.Equal(t, []uint{12, 11, 13}, []uint{question.Replies[0].ID, question.Replies[1].ID, question.Replies[2].ID})
+	assert.True(t, question.Replies[0].IsAcceptedAnswer)
+	assert.True(t, question.Replies[0].AuthorIsStaff)
+	assert.True(t, question.Replies[0].HasUpvoted)
+	assert.Equal(t, "Instructor", question.Replies[0].AuthorName)
+	assert.False(t, question.Replies[1].IsAcceptedAnswer)
+	assert.True(t, question.CanEdit, "viewer wrote the question")
+	assert.False(t, question.Replies[1].CanDelete, "someone else's reply")
+
+	assert.Empty(t, result[1].Replies)
+	assert.NotNil(t, result[1].Replies)
+}
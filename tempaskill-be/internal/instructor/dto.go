@@ -35,14 +35,15 @@ type StudentListQuery struct {
 
 // CourseListQuery represents query parameters for listing instructor's courses
 type CourseListQuery struct {
-	Page       int     `form:"page" binding:"min=0"`
-	Limit      int     `form:"limit" binding:"min=0,max=100"`
-	Search     string  `form:"search"`     // Search by title or description
-	Category   string  `form:"category"`   // Filter by category
-	Difficulty string  `form:"difficulty"` // Filter by difficulty level
-	Published  *bool   `form:"published"`  // Filter by published status
-	SortBy     string  `form:"sort_by"`    // Sort field (created_at, title, etc)
-	SortOrder  string  `form:"sort_order"` // Sort order (asc/desc)
+	Page       int    `form:"page" binding:"min=0"`
+	Limit      int    `form:"limit" binding:"min=0,max=100"`
+	Search     string `form:"search"`                                                                                // Search by title or description
+	Category   string `form:"category"`                                                                              // Filter by category
+	Difficulty string `form:"difficulty"`                                                                            // Filter by difficulty level
+	Published  *bool  `form:"published"`                                                                             // Filter by published status
+	Status     string `form:"status" binding:"omitempty,oneof=draft submitted changes_requested published archived"` // Filter by review workflow status
+	SortBy     string `form:"sort_by"`                                                                               // Sort field (created_at, title, etc)
+	SortOrder  string `form:"sort_order"`                                                                            // Sort order (asc/desc)
 }
 
 // InstructorCourseResponse represents a course in instructor's list
@@ -56,6 +57,7 @@ type InstructorCourseResponse struct {
 	Price            float64 `json:"price"`
 	ThumbnailURL     string  `json:"thumbnail_url,omitempty"`
 	IsPublished      bool    `json:"is_published"`
+	Status           string  `json:"status"` // Review workflow status (draft, submitted, changes_requested, published, archived)
 	TotalLessons     int     `json:"total_lessons"`
 	TotalStudents    int     `json:"total_students"`
 	TotalEnrollments int     `json:"total_enrollments"`
@@ -74,3 +76,40 @@ type InstructorCourseListResult struct {
 	Limit      int                        `json:"limit"`
 	TotalPages int                        `json:"total_pages"`
 }
+
+// SubmissionQueueQuery represents query parameters for the assignment grading queue
+type SubmissionQueueQuery struct {
+	Page     int    `form:"page" binding:"min=0"`
+	Limit    int    `form:"limit" binding:"min=0,max=100"`
+	Status   string `form:"status" binding:"omitempty,oneof=submitted needs_revision accepted all"` // Default: submitted
+	CourseID uint   `form:"course_id"`                                                              // Filter by specific course
+}
+
+// SubmissionQueueItem represents an assignment submission awaiting (or after) grading
+type SubmissionQueueItem struct {
+	ID           uint       `json:"id"`
+	CourseID     uint       `json:"course_id"`
+	CourseTitle  string     `json:"course_title"`
+	LessonID     uint       `json:"lesson_id"`
+	LessonTitle  string     `json:"lesson_title"`
+	StudentID    uint       `json:"student_id"`
+	StudentName  string     `json:"student_name"`
+	StudentEmail string     `json:"student_email"`
+	Attempt      int        `json:"attempt"`
+	Status       string     `json:"status"`
+	FileName     string     `json:"file_name,omitempty"`
+	LinkURL      string     `json:"link_url,omitempty"`
+	Score        *int       `json:"score"`
+	MaxScore     int        `json:"max_score"`
+	SubmittedAt  time.Time  `json:"submitted_at"`
+	GradedAt     *time.Time `json:"graded_at,omitempty"`
+}
+
+// SubmissionQueueResult represents the paginated grading queue
+type SubmissionQueueResult struct {
+	Submissions []SubmissionQueueItem `json:"submissions"`
+	Total       int64                 `json:"total"`
+	Page        int                   `json:"page"`
+	Limit       int                   `json:"limit"`
+	TotalPages  int                   `json:"total_pages"`
+}
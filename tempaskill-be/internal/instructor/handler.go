@@ -106,3 +106,47 @@ func (h *Handler) GetMyCourses(c *gin.Context) {
 
 	response.Success(c, http.StatusOK, "Courses retrieved successfully", result)
 }
+
+// GetSubmissionQueue godoc
+// @Summary Get assignment grading queue
+// @Description Get assignment submissions for the instructor's courses, oldest pending first
+// @Tags instructor
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "submitted, needs_revision, accepted or all" default(submitted)
+// @Param course_id query int false "Filter by specific course"
+// @Success 200 {object} response.Response{data=SubmissionQueueResult}
+// @Failure 401 {object} response.Response
+// @Failure 403 {object} response.Response
+// @Router /instructor/submissions [get]
+func (h *Handler) GetSubmissionQueue(c *gin.Context) {
+	// Get instructor ID from context
+	userID, exists := c.Get("userID")
+	if !exists {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
+
+	userRole, exists := c.Get("userRole")
+	if !exists || userRole != "instructor" {
+		response.Error(c, http.StatusForbidden, "Instructor access required", nil)
+		return
+	}
+
+	var query SubmissionQueueQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	result, err := h.service.GetSubmissionQueue(c.Request.Context(), userID.(uint), &query)
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	response.Success(c, http.StatusOK, "Submissions retrieved successfully", result)
+}
@@ -2,6 +2,7 @@ package instructor
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
 	"go.uber.org/zap"
@@ -9,10 +10,24 @@ import (
 )
 
 type Repository interface {
-	// GetMyStudents returns all students enrolled in instructor's courses
+	// GetMyStudents returns all students enrolled in courses the instructor teaches (owner, co-instructor or TA)
 	GetMyStudents(ctx context.Context, instructorID uint, query *StudentListQuery) ([]InstructorStudentResponse, int64, error)
-	// GetMyCourses returns all courses created by the instructor
+	// GetMyCourses returns all courses created by the instructor or shared with them as collaborator
 	GetMyCourses(ctx context.Context, instructorID uint, query *CourseListQuery) ([]InstructorCourseResponse, int64, error)
+	// GetSubmissionQueue returns assignment submissions for the courses the instructor grades
+	GetSubmissionQueue(ctx context.Context, instructorID uint, query *SubmissionQueueQuery) ([]SubmissionQueueItem, int64, error)
+}
+
+// Collaborator roles (course_collaborators is owned by the course module)
+var (
+	teachingRoles = []string{"co_instructor", "ta"}           // see students and grade
+	allTeamRoles  = []string{"co_instructor", "editor", "ta"} // see the course itself
+)
+
+// courseTeamCondition matches courses (by table alias) the instructor owns or collaborates on.
+// Arguments: instructor ID, instructor ID, collaborator roles.
+func courseTeamCondition(alias string) string {
+	return fmt.Sprintf("(%[1]s.instructor_id = ? OR EXISTS (SELECT 1 FROM course_collaborators cc WHERE cc.course_id = %[1]s.id AND cc.user_id = ? AND cc.role IN ?))", alias)
 }
 
 type repository struct {
@@ -42,7 +57,7 @@ func (r *repository) GetMyStudents(ctx context.Context, instructorID uint, query
 				FROM enrollments e2
 				INNER JOIN courses c2 ON c2.id = e2.course_id AND c2.deleted_at IS NULL
 				WHERE e2.user_id = users.id 
-				AND c2.instructor_id = ?
+				AND `+courseTeamCondition("c2")+`
 				AND NOT EXISTS (
 					SELECT 1 FROM lessons l 
 					WHERE l.course_id = e2.course_id 
@@ -55,10 +70,10 @@ func (r *repository) GetMyStudents(ctx context.Context, instructorID uint, query
 				)
 			), 0) as completed_courses,
 			MIN(enrollments.enrolled_at) as joined_at
-		`, instructorID).
+		`, instructorID, instructorID, teachingRoles).
 		Joins("INNER JOIN enrollments ON enrollments.user_id = users.id").
 		Joins("INNER JOIN courses ON courses.id = enrollments.course_id AND courses.deleted_at IS NULL").
-		Where("courses.instructor_id = ?", instructorID).
+		Where(courseTeamCondition("courses"), instructorID, instructorID, teachingRoles).
 		Where("users.role = ?", "student"). // Only students
 		Where("users.deleted_at IS NULL").
 		Group("users.id, users.name, users.email, users.avatar_url")
@@ -79,7 +94,7 @@ func (r *repository) GetMyStudents(ctx context.Context, instructorID uint, query
 		Select("COUNT(DISTINCT users.id)").
 		Joins("INNER JOIN enrollments ON enrollments.user_id = users.id").
 		Joins("INNER JOIN courses ON courses.id = enrollments.course_id AND courses.deleted_at IS NULL").
-		Where("courses.instructor_id = ?", instructorID).
+		Where(courseTeamCondition("courses"), instructorID, instructorID, teachingRoles).
 		Where("users.role = ?", "student").
 		Where("users.deleted_at IS NULL")
 
@@ -116,7 +131,7 @@ func (r *repository) GetMyStudents(ctx context.Context, instructorID uint, query
 			Select("courses.title").
 			Joins("INNER JOIN enrollments ON enrollments.course_id = courses.id").
 			Where("enrollments.user_id = ?", students[i].ID).
-			Where("courses.instructor_id = ?", instructorID).
+			Where(courseTeamCondition("courses"), instructorID, instructorID, teachingRoles).
 			Where("courses.deleted_at IS NULL").
 			Where("enrollments.deleted_at IS NULL").
 			Pluck("title", &courseNames).Error
@@ -157,6 +172,7 @@ func (r *repository) GetMyCourses(ctx context.Context, instructorID uint, query
 			courses.price,
 			courses.thumbnail_url,
 			courses.is_published,
+			courses.status,
 			courses.created_at,
 			courses.updated_at,
 			COUNT(DISTINCT lessons.id) as total_lessons,
@@ -170,9 +186,9 @@ func (r *repository) GetMyCourses(ctx context.Context, instructorID uint, query
 		Joins("LEFT JOIN enrollments ON enrollments.course_id = courses.id AND enrollments.deleted_at IS NULL").
 		Joins("LEFT JOIN payment_transactions ON payment_transactions.course_id = courses.id").
 		Joins("LEFT JOIN course_reviews ON course_reviews.course_id = courses.id").
-		Where("courses.instructor_id = ?", instructorID).
+		Where(courseTeamCondition("courses"), instructorID, instructorID, allTeamRoles).
 		Where("courses.deleted_at IS NULL").
-		Group("courses.id, courses.title, courses.slug, courses.description, courses.category, courses.difficulty, courses.price, courses.thumbnail_url, courses.is_published, courses.created_at, courses.updated_at")
+		Group("courses.id, courses.title, courses.slug, courses.description, courses.category, courses.difficulty, courses.price, courses.thumbnail_url, courses.is_published, courses.status, courses.created_at, courses.updated_at")
 
 	// Apply filters
 	if query.Search != "" {
@@ -189,13 +205,21 @@ func (r *repository) GetMyCourses(ctx context.Context, instructorID uint, query
 	}
 
 	if query.Published != nil {
-		baseQuery = baseQuery.Where("courses.is_published = ?", *query.Published)
+		if *query.Published {
+			baseQuery = baseQuery.Where("courses.status = ?", "published")
+		} else {
+			baseQuery = baseQuery.Where("courses.status <> ?", "published")
+		}
+	}
+
+	if query.Status != "" {
+		baseQuery = baseQuery.Where("courses.status = ?", query.Status)
 	}
 
 	// Count total (without aggregates)
 	countQuery := r.db.WithContext(ctx).
 		Table("courses").
-		Where("courses.instructor_id = ?", instructorID).
+		Where(courseTeamCondition("courses"), instructorID, instructorID, allTeamRoles).
 		Where("courses.deleted_at IS NULL")
 
 	if query.Search != "" {
@@ -212,7 +236,15 @@ func (r *repository) GetMyCourses(ctx context.Context, instructorID uint, query
 	}
 
 	if query.Published != nil {
-		countQuery = countQuery.Where("courses.is_published = ?", *query.Published)
+		if *query.Published {
+			countQuery = countQuery.Where("courses.status = ?", "published")
+		} else {
+			countQuery = countQuery.Where("courses.status <> ?", "published")
+		}
+	}
+
+	if query.Status != "" {
+		countQuery = countQuery.Where("courses.status = ?", query.Status)
 	}
 
 	if err := countQuery.Count(&total).Error; err != nil {
@@ -236,3 +268,68 @@ func (r *repository) GetMyCourses(ctx context.Context, instructorID uint, query
 
 	return courses, total, nil
 }
+
+func (r *repository) GetSubmissionQueue(ctx context.Context, instructorID uint, query *SubmissionQueueQuery) ([]SubmissionQueueItem, int64, error) {
+	var items []SubmissionQueueItem
+	var total int64
+
+	// assignment_submissions is owned by the assignment module; read it as a table
+	// to keep the instructor module free of domain imports
+	baseQuery := r.db.WithContext(ctx).
+		Table("assignment_submissions").
+		Joins("INNER JOIN courses ON courses.id = assignment_submissions.course_id AND courses.deleted_at IS NULL").
+		Joins("INNER JOIN lessons ON lessons.id = assignment_submissions.lesson_id AND lessons.deleted_at IS NULL").
+		Joins("INNER JOIN users ON users.id = assignment_submissions.user_id").
+		Where(courseTeamCondition("courses"), instructorID, instructorID, teachingRoles)
+
+	if query.Status != "all" {
+		baseQuery = baseQuery.Where("assignment_submissions.status = ?", query.Status)
+	} else {
+		baseQuery = baseQuery.Where("assignment_submissions.status <> ?", "superseded")
+	}
+
+	if query.CourseID > 0 {
+		baseQuery = baseQuery.Where("assignment_submissions.course_id = ?", query.CourseID)
+	}
+
+	if err := baseQuery.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		logger.Error("Failed to count instructor's submissions", zap.Error(err), zap.Uint("instructor_id", instructorID))
+		return nil, 0, err
+	}
+
+	// Pending work is graded first-in first-out; history is shown newest first
+	order := "assignment_submissions.submitted_at DESC"
+	if query.Status == "submitted" {
+		order = "assignment_submissions.submitted_at ASC"
+	}
+
+	offset := (query.Page - 1) * query.Limit
+	if err := baseQuery.
+		Select(`
+			assignment_submissions.id,
+			assignment_submissions.course_id,
+			courses.title as course_title,
+			assignment_submissions.lesson_id,
+			lessons.title as lesson_title,
+			users.id as student_id,
+			users.name as student_name,
+			users.email as student_email,
+			assignment_submissions.attempt,
+			assignment_submissions.status,
+			assignment_submissions.file_name,
+			assignment_submissions.link_url,
+			assignment_submissions.score,
+			lessons.assignment_max_score as max_score,
+			assignment_submissions.submitted_at,
+			assignment_submissions.graded_at
+		`).
+		Order(order).
+		Offset(offset).
+		Limit(query.Limit).
+		Scan(&items).Error; err != nil {
+		logger.Error("Failed to list instructor's submissions", zap.Error(err), zap.Uint("instructor_id", instructorID))
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
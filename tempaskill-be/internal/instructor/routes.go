@@ -18,7 +18,8 @@ func RegisterRoutes(rg *gin.RouterGroup, handler *Handler, authMiddleware *middl
 		c.Next()
 	})
 	{
-		instructor.GET("/students", handler.GetMyStudents) // GET /instructor/students
-		instructor.GET("/courses", handler.GetMyCourses)   // GET /instructor/courses
+		instructor.GET("/students", handler.GetMyStudents)         // GET /instructor/students
+		instructor.GET("/courses", handler.GetMyCourses)           // GET /instructor/courses
+		instructor.GET("/submissions", handler.GetSubmissionQueue) // GET /instructor/submissions (grading queue)
 	}
 }
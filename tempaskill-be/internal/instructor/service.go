@@ -7,6 +7,7 @@ import (
 type Service interface {
 	GetMyStudents(ctx context.Context, instructorID uint, query *StudentListQuery) (*InstructorStudentListResult, error)
 	GetMyCourses(ctx context.Context, instructorID uint, query *CourseListQuery) (*InstructorCourseListResult, error)
+	GetSubmissionQueue(ctx context.Context, instructorID uint, query *SubmissionQueueQuery) (*SubmissionQueueResult, error)
 }
 
 type service struct {
@@ -78,3 +79,34 @@ func (s *service) GetMyCourses(ctx context.Context, instructorID uint, query *Co
 		TotalPages: totalPages,
 	}, nil
 }
+
+func (s *service) GetSubmissionQueue(ctx context.Context, instructorID uint, query *SubmissionQueueQuery) (*SubmissionQueueResult, error) {
+	// Set defaults
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = 10
+	}
+	if query.Status == "" {
+		query.Status = "submitted"
+	}
+
+	submissions, total, err := s.repo.GetSubmissionQueue(ctx, instructorID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	totalPages := int(total) / query.Limit
+	if int(total)%query.Limit > 0 {
+		totalPages++
+	}
+
+	return &SubmissionQueueResult{
+		Submissions: submissions,
+		Total:       total,
+		Page:        query.Page,
+		Limit:       query.Limit,
+		TotalPages:  totalPages,
+	}, nil
+}
@@ -0,0 +1,82 @@
+package learningpath
+
+import "time"
+
+// CreatePathRequest represents learning path creation payload
+type CreatePathRequest struct {
+	Title        string `json:"title" binding:"required,min=3,max=200"`
+	Description  string `json:"description" binding:"omitempty,max=2000"`
+	ThumbnailURL string `json:"thumbnail_url" binding:"omitempty,url,max=255"`
+	CourseIDs    []uint `json:"course_ids" binding:"omitempty,max=50,dive,min=1"` // In path order
+}
+
+// UpdatePathRequest represents learning path update payload
+type UpdatePathRequest struct {
+	Title        *string `json:"title" binding:"omitempty,min=3,max=200"`
+	Description  *string `json:"description" binding:"omitempty,max=2000"`
+	ThumbnailURL *string `json:"thumbnail_url" binding:"omitempty,url,max=255"`
+	IsPublished  *bool   `json:"is_published"`
+}
+
+// SetPathCoursesRequest replaces the ordered course list of a path
+type SetPathCoursesRequest struct {
+	CourseIDs []uint `json:"course_ids" binding:"required,max=50,dive,min=1"`
+}
+
+// PathCourseResponse is a course within a learning path
+type PathCourseResponse struct {
+	ID           uint   `json:"id"`
+	Title        string `json:"title"`
+	Slug         string `json:"slug"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Difficulty   string `json:"difficulty"`
+	OrderIndex   int    `json:"order_index"`
+	IsPublished  bool   `json:"is_published"`
+}
+
+// PathResponse is the API representation of a learning path
+type PathResponse struct {
+	ID           uint                  `json:"id"`
+	Title        string                `json:"title"`
+	Slug         string                `json:"slug"`
+	Description  string                `json:"description"`
+	ThumbnailURL string                `json:"thumbnail_url"`
+	IsPublished  bool                  `json:"is_published"`
+	CreatedBy    uint                  `json:"created_by"`
+	CourseCount  int                   `json:"course_count"`
+	Courses      []*PathCourseResponse `json:"courses"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+// PathCourseProgress is the user's progress in one course of a path
+type PathCourseProgress struct {
+	CourseID    uint    `json:"course_id"`
+	Title       string  `json:"title"`
+	Slug        string  `json:"slug"`
+	OrderIndex  int     `json:"order_index"`
+	IsEnrolled  bool    `json:"is_enrolled"`
+	IsCompleted bool    `json:"is_completed"`
+	Percentage  float64 `json:"percentage"`
+}
+
+// PathProgressResponse aggregates a user's progress over all courses of a path
+type PathProgressResponse struct {
+	PathID           uint                  `json:"path_id"`
+	TotalCourses     int                   `json:"total_courses"`
+	EnrolledCourses  int                   `json:"enrolled_courses"`
+	CompletedCourses int                   `json:"completed_courses"`
+	Percentage       float64               `json:"percentage"` // Average of course percentages
+	Courses          []*PathCourseProgress `json:"courses"`
+	NextCourse       *PathCourseProgress   `json:"next_course,omitempty"` // First course not completed yet
+}
+
+// PathCertificateEligibilityResponse tells whether the user earned the path certificate
+type PathCertificateEligibilityResponse struct {
+	Eligible         bool                  `json:"eligible"`
+	Progress         float64               `json:"progress"`
+	CompletedCourses int                   `json:"completed_courses"`
+	TotalCourses     int                   `json:"total_courses"`
+	MissingCourses   []*PathCourseProgress `json:"missing_courses"`
+	Message          string                `json:"message"`
+}
@@ -0,0 +1,258 @@
+package learningpath
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	switch err {
+	case ErrPathNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrUnauthorized:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case ErrSlugExists:
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case ErrCourseNotFound, ErrDuplicateCourse:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUser returns the authenticated user's ID and role from the JWT middleware
+func getUser(c *gin.Context) (uint, string, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return userID.(uint), role, true
+}
+
+// optionalRole returns the caller's role when OptionalAuth found a valid token
+func optionalRole(c *gin.Context) string {
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return role
+}
+
+// parseID parses a numeric path parameter
+func parseID(c *gin.Context, param string, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ListPaths handles GET /learning-paths
+func (h *Handler) ListPaths(c *gin.Context) {
+	paths, err := h.service.ListPaths(c.Request.Context(), optionalRole(c))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": paths,
+	})
+}
+
+// GetPath handles GET /learning-paths/:id
+func (h *Handler) GetPath(c *gin.Context) {
+	pathID, ok := parseID(c, "id", "learning path")
+	if !ok {
+		return
+	}
+
+	path, err := h.service.GetPathByID(c.Request.Context(), optionalRole(c), pathID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": path,
+	})
+}
+
+// GetPathBySlug handles GET /learning-paths/slug/:slug
+func (h *Handler) GetPathBySlug(c *gin.Context) {
+	path, err := h.service.GetPathBySlug(c.Request.Context(), optionalRole(c), c.Param("slug"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": path,
+	})
+}
+
+// CreatePath handles POST /learning-paths
+func (h *Handler) CreatePath(c *gin.Context) {
+	var req CreatePathRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	path, err := h.service.CreatePath(c.Request.Context(), userID, userRole, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Learning path created successfully",
+		"data":    path,
+	})
+}
+
+// UpdatePath handles PATCH /learning-paths/:id
+func (h *Handler) UpdatePath(c *gin.Context) {
+	pathID, ok := parseID(c, "id", "learning path")
+	if !ok {
+		return
+	}
+
+	var req UpdatePathRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	path, err := h.service.UpdatePath(c.Request.Context(), userRole, pathID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Learning path updated successfully",
+		"data":    path,
+	})
+}
+
+// DeletePath handles DELETE /learning-paths/:id
+func (h *Handler) DeletePath(c *gin.Context) {
+	pathID, ok := parseID(c, "id", "learning path")
+	if !ok {
+		return
+	}
+
+	_, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePath(c.Request.Context(), userRole, pathID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Learning path deleted successfully",
+	})
+}
+
+// SetPathCourses handles PUT /learning-paths/:id/courses
+func (h *Handler) SetPathCourses(c *gin.Context) {
+	pathID, ok := parseID(c, "id", "learning path")
+	if !ok {
+		return
+	}
+
+	var req SetPathCoursesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	path, err := h.service.SetPathCourses(c.Request.Context(), userRole, pathID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Learning path courses updated successfully",
+		"data":    path,
+	})
+}
+
+// GetPathProgress handles GET /learning-paths/:id/progress
+func (h *Handler) GetPathProgress(c *gin.Context) {
+	pathID, ok := parseID(c, "id", "learning path")
+	if !ok {
+		return
+	}
+
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	progress, err := h.service.GetPathProgress(c.Request.Context(), userID, pathID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": progress,
+	})
+}
+
+// GetCertificateEligibility handles GET /learning-paths/:id/certificate
+func (h *Handler) GetCertificateEligibility(c *gin.Context) {
+	pathID, ok := parseID(c, "id", "learning path")
+	if !ok {
+		return
+	}
+
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	eligibility, err := h.service.GetCertificateEligibility(c.Request.Context(), userID, pathID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": eligibility,
+	})
+}
@@ -0,0 +1,47 @@
+package learningpath
+
+import (
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"gorm.io/gorm"
+)
+
+// LearningPath groups courses into an ordered track (e.g. "Backend Engineer with Go")
+type LearningPath struct {
+	ID           uint           `gorm:"primaryKey" json:"id"`
+	Title        string         `gorm:"type:varchar(200);not null" json:"title"`
+	Slug         string         `gorm:"type:varchar(250);uniqueIndex;not null" json:"slug"`
+	Description  string         `gorm:"type:text" json:"description"`
+	ThumbnailURL string         `gorm:"type:varchar(255)" json:"thumbnail_url"`
+	IsPublished  bool           `gorm:"default:false" json:"is_published"`
+	CreatedBy    uint           `gorm:"not null" json:"created_by"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Courses []PathCourse `gorm:"foreignKey:PathID;constraint:OnDelete:CASCADE" json:"courses,omitempty"`
+}
+
+// PathCourse places a course at a position within a learning path
+type PathCourse struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	PathID     uint      `gorm:"not null;uniqueIndex:idx_learning_path_courses_pair" json:"path_id"`
+	CourseID   uint      `gorm:"not null;uniqueIndex:idx_learning_path_courses_pair;index" json:"course_id"`
+	OrderIndex int       `gorm:"not null;default:0" json:"order_index"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// Relations
+	Course *course.Course `gorm:"foreignKey:CourseID" json:"course,omitempty"`
+}
+
+// TableName specifies the table name for LearningPath model
+func (LearningPath) TableName() string {
+	return "learning_paths"
+}
+
+// TableName specifies the table name for PathCourse model
+func (PathCourse) TableName() string {
+	return "learning_path_courses"
+}
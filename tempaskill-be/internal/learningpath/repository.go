@@ -0,0 +1,158 @@
+package learningpath
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreatePath(ctx context.Context, path *LearningPath, courseIDs []uint) error
+	FindPathByID(ctx context.Context, id uint) (*LearningPath, error)
+	FindPathBySlug(ctx context.Context, slug string) (*LearningPath, error)
+	SlugExists(ctx context.Context, slug string, pathID uint) (bool, error)
+	FindPaths(ctx context.Context, includeUnpublished bool) ([]*LearningPath, error)
+	UpdatePath(ctx context.Context, path *LearningPath) error
+	DeletePath(ctx context.Context, id uint) error
+	ReplacePathCourses(ctx context.Context, pathID uint, courseIDs []uint) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// withCourses preloads the path's courses in path order
+func withCourses(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Courses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC, id ASC")
+		}).
+		Preload("Courses.Course")
+}
+
+// CreatePath inserts the path with its ordered course list in one transaction.
+// course.ErrSlugTaken means a concurrent save took its slug.
+func (r *repository) CreatePath(ctx context.Context, path *LearningPath, courseIDs []uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Courses").Create(path).Error; err != nil {
+			return err
+		}
+		return replacePathCourses(tx, path.ID, courseIDs)
+	})
+	if course.IsDuplicateKey(err) {
+		return course.ErrSlugTaken
+	}
+	if err != nil {
+		logger.Error("Failed to create learning path in database",
+			zap.Error(err),
+			zap.String("title", path.Title),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) FindPathByID(ctx context.Context, id uint) (*LearningPath, error) {
+	var path LearningPath
+	if err := withCourses(r.db.WithContext(ctx)).First(&path, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding learning path by ID",
+				zap.Error(err),
+				zap.Uint("path_id", id),
+			)
+		}
+		return nil, err
+	}
+	return &path, nil
+}
+
+func (r *repository) FindPathBySlug(ctx context.Context, slug string) (*LearningPath, error) {
+	var path LearningPath
+	if err := withCourses(r.db.WithContext(ctx)).Where("slug = ?", slug).First(&path).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding learning path by slug",
+				zap.Error(err),
+				zap.String("slug", slug),
+			)
+		}
+		return nil, err
+	}
+	return &path, nil
+}
+
+// SlugExists reports whether a slug is used by a path other than pathID (0 for a new path),
+// deleted paths included: they keep their row, and so their slug, in the unique index
+func (r *repository) SlugExists(ctx context.Context, slug string, pathID uint) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Unscoped().Model(&LearningPath{}).
+		Where("slug = ? AND id <> ?", slug, pathID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *repository) FindPaths(ctx context.Context, includeUnpublished bool) ([]*LearningPath, error) {
+	var paths []*LearningPath
+	db := withCourses(r.db.WithContext(ctx))
+	if !includeUnpublished {
+		db = db.Where("is_published = ?", true)
+	}
+	if err := db.Order("created_at DESC").Find(&paths).Error; err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// UpdatePath saves the path's details; course.ErrSlugTaken means a concurrent save took its slug
+func (r *repository) UpdatePath(ctx context.Context, path *LearningPath) error {
+	err := r.db.WithContext(ctx).Model(path).
+		Select("title", "slug", "description", "thumbnail_url", "is_published").
+		Updates(path).Error
+	if course.IsDuplicateKey(err) {
+		return course.ErrSlugTaken
+	}
+	return err
+}
+
+func (r *repository) DeletePath(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&LearningPath{}, id).Error
+}
+
+// ReplacePathCourses swaps the ordered course list of a path in one transaction
+func (r *repository) ReplacePathCourses(ctx context.Context, pathID uint, courseIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replacePathCourses(tx, pathID, courseIDs)
+	})
+}
+
+// replacePathCourses swaps the ordered course list of a path inside the caller's transaction
+func replacePathCourses(tx *gorm.DB, pathID uint, courseIDs []uint) error {
+	if err := tx.Where("path_id = ?", pathID).Delete(&PathCourse{}).Error; err != nil {
+		return err
+	}
+
+	if len(courseIDs) == 0 {
+		return nil
+	}
+	entries := make([]PathCourse, 0, len(courseIDs))
+	for i, courseID := range courseIDs {
+		entries = append(entries, PathCourse{PathID: pathID, CourseID: courseID, OrderIndex: i})
+	}
+	if err := tx.Create(&entries).Error; err != nil {
+		logger.Error("Failed to save learning path courses",
+			zap.Error(err),
+			zap.Uint("path_id", pathID),
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package learningpath
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/progress"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, notifier course.Notifier) {
+	// Initialize layers
+	repo := NewRepository(db)
+	courseRepo := course.NewRepository(db)
+	progressService := progress.NewService(progress.NewRepository(db), courseRepo, course.NewService(courseRepo, notifier))
+	service := NewService(repo, courseRepo, progressService)
+	handler := NewHandler(service)
+
+	// Public routes (OptionalAuth lets admins see unpublished paths)
+	public := router.Group("")
+	public.Use(authMiddleware.OptionalAuth())
+	{
+		public.GET("/learning-paths", handler.ListPaths)                // List published paths
+		public.GET("/learning-paths/slug/:slug", handler.GetPathBySlug) // Get path by slug (must be before :id)
+		public.GET("/learning-paths/:id", handler.GetPath)              // Get path with ordered courses
+	}
+
+	// Protected routes (authentication required)
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// Path management (admin only - authorization checked in service layer)
+		protected.POST("/learning-paths", handler.CreatePath)                // Create path
+		protected.PATCH("/learning-paths/:id", handler.UpdatePath)           // Update path details / publish
+		protected.DELETE("/learning-paths/:id", handler.DeletePath)          // Delete path
+		protected.PUT("/learning-paths/:id/courses", handler.SetPathCourses) // Replace ordered course list
+
+		// Learner progress
+		protected.GET("/learning-paths/:id/progress", handler.GetPathProgress)              // Aggregate progress over the path
+		protected.GET("/learning-paths/:id/certificate", handler.GetCertificateEligibility) // Path certificate eligibility
+	}
+}
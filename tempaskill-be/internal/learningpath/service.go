@@ -0,0 +1,340 @@
+package learningpath
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/progress"
+)
+
+var (
+	ErrPathNotFound    = errors.New("learning path not found")
+	ErrCourseNotFound  = errors.New("course not found")
+	ErrUnauthorized    = errors.New("unauthorized access")
+	ErrSlugExists      = errors.New("a learning path with this title was just created, please try again")
+	ErrDuplicateCourse = errors.New("a course can only appear once in a learning path")
+)
+
+type Service interface {
+	// Path management (admin only)
+	CreatePath(ctx context.Context, userID uint, userRole string, req *CreatePathRequest) (*PathResponse, error)
+	UpdatePath(ctx context.Context, userRole string, pathID uint, req *UpdatePathRequest) (*PathResponse, error)
+	DeletePath(ctx context.Context, userRole string, pathID uint) error
+	SetPathCourses(ctx context.Context, userRole string, pathID uint, req *SetPathCoursesRequest) (*PathResponse, error)
+
+	// Browsing (unpublished paths are visible to admins only)
+	ListPaths(ctx context.Context, userRole string) ([]*PathResponse, error)
+	GetPathByID(ctx context.Context, userRole string, pathID uint) (*PathResponse, error)
+	GetPathBySlug(ctx context.Context, userRole string, slug string) (*PathResponse, error)
+
+	// Learner progress
+	GetPathProgress(ctx context.Context, userID uint, pathID uint) (*PathProgressResponse, error)
+	GetCertificateEligibility(ctx context.Context, userID uint, pathID uint) (*PathCertificateEligibilityResponse, error)
+}
+
+type service struct {
+	repo            Repository
+	courseRepo      course.Repository
+	progressService progress.Service
+}
+
+func NewService(repo Repository, courseRepo course.Repository, progressService progress.Service) Service {
+	return &service{
+		repo:            repo,
+		courseRepo:      courseRepo,
+		progressService: progressService,
+	}
+}
+
+// Helper: Save a path under a free slug for its title (base-2, base-3, ... once taken),
+// retrying when a concurrent save takes it
+func (s *service) saveWithUniqueSlug(ctx context.Context, path *LearningPath, save func() error) error {
+	err := course.SaveWithUniqueSlug(course.GenerateSlug(path.Title), "path", func(slug string) (bool, error) {
+		return s.repo.SlugExists(ctx, slug, path.ID)
+	}, func(slug string) error {
+		path.Slug = slug
+		return save()
+	})
+	if err == course.ErrSlugTaken {
+		return ErrSlugExists
+	}
+	return err
+}
+
+// Helper: Validate the course list (existing courses, no duplicates)
+func (s *service) validateCourseIDs(ctx context.Context, courseIDs []uint) error {
+	seen := make(map[uint]bool, len(courseIDs))
+	for _, id := range courseIDs {
+		if seen[id] {
+			return ErrDuplicateCourse
+		}
+		seen[id] = true
+		if _, err := s.courseRepo.FindCourseByID(ctx, id); err != nil {
+			return ErrCourseNotFound
+		}
+	}
+	return nil
+}
+
+// Helper: Load a published path (admins also see drafts)
+func (s *service) findVisiblePath(ctx context.Context, userRole string, pathID uint) (*LearningPath, error) {
+	path, err := s.repo.FindPathByID(ctx, pathID)
+	if err != nil || (!path.IsPublished && userRole != "admin") {
+		return nil, ErrPathNotFound
+	}
+	return path, nil
+}
+
+// Path management
+
+func (s *service) CreatePath(ctx context.Context, userID uint, userRole string, req *CreatePathRequest) (*PathResponse, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+
+	if err := s.validateCourseIDs(ctx, req.CourseIDs); err != nil {
+		return nil, err
+	}
+
+	path := &LearningPath{
+		Title:        req.Title,
+		Description:  req.Description,
+		ThumbnailURL: req.ThumbnailURL,
+		IsPublished:  false,
+		CreatedBy:    userID,
+	}
+	if err := s.saveWithUniqueSlug(ctx, path, func() error {
+		return s.repo.CreatePath(ctx, path, req.CourseIDs)
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.GetPathByID(ctx, userRole, path.ID)
+}
+
+func (s *service) UpdatePath(ctx context.Context, userRole string, pathID uint, req *UpdatePathRequest) (*PathResponse, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+
+	path, err := s.repo.FindPathByID(ctx, pathID)
+	if err != nil {
+		return nil, ErrPathNotFound
+	}
+
+	if req.Title != nil {
+		path.Title = *req.Title
+	}
+	if req.Description != nil {
+		path.Description = *req.Description
+	}
+	if req.ThumbnailURL != nil {
+		path.ThumbnailURL = *req.ThumbnailURL
+	}
+	if req.IsPublished != nil {
+		path.IsPublished = *req.IsPublished
+	}
+
+	save := func() error { return s.repo.UpdatePath(ctx, path) }
+	if req.Title != nil {
+		err = s.saveWithUniqueSlug(ctx, path, save)
+	} else {
+		err = save()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toPathResponse(path), nil
+}
+
+func (s *service) DeletePath(ctx context.Context, userRole string, pathID uint) error {
+	if userRole != "admin" {
+		return ErrUnauthorized
+	}
+	if _, err := s.repo.FindPathByID(ctx, pathID); err != nil {
+		return ErrPathNotFound
+	}
+	return s.repo.DeletePath(ctx, pathID)
+}
+
+func (s *service) SetPathCourses(ctx context.Context, userRole string, pathID uint, req *SetPathCoursesRequest) (*PathResponse, error) {
+	if userRole != "admin" {
+		return nil, ErrUnauthorized
+	}
+	if _, err := s.repo.FindPathByID(ctx, pathID); err != nil {
+		return nil, ErrPathNotFound
+	}
+	if err := s.validateCourseIDs(ctx, req.CourseIDs); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplacePathCourses(ctx, pathID, req.CourseIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetPathByID(ctx, userRole, pathID)
+}
+
+// Browsing
+
+func (s *service) ListPaths(ctx context.Context, userRole string) ([]*PathResponse, error) {
+	paths, err := s.repo.FindPaths(ctx, userRole == "admin")
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*PathResponse, 0, len(paths))
+	for _, path := range paths {
+		responses = append(responses, toPathResponse(path))
+	}
+	return responses, nil
+}
+
+func (s *service) GetPathByID(ctx context.Context, userRole string, pathID uint) (*PathResponse, error) {
+	path, err := s.findVisiblePath(ctx, userRole, pathID)
+	if err != nil {
+		return nil, err
+	}
+	return toPathResponse(path), nil
+}
+
+func (s *service) GetPathBySlug(ctx context.Context, userRole string, slug string) (*PathResponse, error) {
+	path, err := s.repo.FindPathBySlug(ctx, slug)
+	if err != nil || (!path.IsPublished && userRole != "admin") {
+		return nil, ErrPathNotFound
+	}
+	return toPathResponse(path), nil
+}
+
+// Learner progress
+
+func (s *service) GetPathProgress(ctx context.Context, userID uint, pathID uint) (*PathProgressResponse, error) {
+	path, err := s.findVisiblePath(ctx, "", pathID)
+	if err != nil {
+		return nil, err
+	}
+
+	courses := make([]*PathCourseProgress, 0, len(path.Courses))
+	for _, entry := range path.Courses {
+		if entry.Course == nil {
+			continue // Course was deleted
+		}
+
+		item := &PathCourseProgress{
+			CourseID:   entry.CourseID,
+			Title:      entry.Course.Title,
+			Slug:       entry.Course.Slug,
+			OrderIndex: entry.OrderIndex,
+		}
+
+		courseProgress, err := s.progressService.GetCourseProgress(ctx, userID, entry.CourseID)
+		switch {
+		case err == nil:
+			item.IsEnrolled = true
+			item.Percentage = courseProgress.Percentage
+			item.IsCompleted = courseProgress.Percentage >= 100
+		case errors.Is(err, progress.ErrNotEnrolled):
+			// Not started yet
+		default:
+			return nil, err
+		}
+		courses = append(courses, item)
+	}
+
+	return summarizeProgress(path.ID, courses), nil
+}
+
+func (s *service) GetCertificateEligibility(ctx context.Context, userID uint, pathID uint) (*PathCertificateEligibilityResponse, error) {
+	summary, err := s.GetPathProgress(ctx, userID, pathID)
+	if err != nil {
+		return nil, err
+	}
+	return checkEligibility(summary), nil
+}
+
+// summarizeProgress aggregates per-course progress into the path progress
+func summarizeProgress(pathID uint, courses []*PathCourseProgress) *PathProgressResponse {
+	resp := &PathProgressResponse{
+		PathID:       pathID,
+		TotalCourses: len(courses),
+		Courses:      courses,
+	}
+
+	total := 0.0
+	for _, item := range courses {
+		if item.IsEnrolled {
+			resp.EnrolledCourses++
+		}
+		if item.IsCompleted {
+			resp.CompletedCourses++
+		} else if resp.NextCourse == nil {
+			resp.NextCourse = item
+		}
+		total += item.Percentage
+	}
+	if len(courses) > 0 {
+		resp.Percentage = total / float64(len(courses))
+	}
+	return resp
+}
+
+// checkEligibility grants the path certificate once every course of the path is completed
+func checkEligibility(summary *PathProgressResponse) *PathCertificateEligibilityResponse {
+	resp := &PathCertificateEligibilityResponse{
+		Progress:         summary.Percentage,
+		CompletedCourses: summary.CompletedCourses,
+		TotalCourses:     summary.TotalCourses,
+		MissingCourses:   []*PathCourseProgress{},
+	}
+	for _, item := range summary.Courses {
+		if !item.IsCompleted {
+			resp.MissingCourses = append(resp.MissingCourses, item)
+		}
+	}
+
+	switch {
+	case summary.TotalCourses == 0:
+		resp.Message = "Learning path belum memiliki kursus"
+	case len(resp.MissingCourses) > 0:
+		resp.Message = fmt.Sprintf("Learning path belum selesai - %d dari %d kursus selesai", summary.CompletedCourses, summary.TotalCourses)
+	default:
+		resp.Eligible = true
+		resp.Message = "Berhak mendapatkan sertifikat learning path"
+	}
+	return resp
+}
+
+func toPathResponse(path *LearningPath) *PathResponse {
+	courses := make([]*PathCourseResponse, 0, len(path.Courses))
+	for _, entry := range path.Courses {
+		if entry.Course == nil {
+			continue // Course was deleted
+		}
+		courses = append(courses, &PathCourseResponse{
+			ID:           entry.Course.ID,
+			Title:        entry.Course.Title,
+			Slug:         entry.Course.Slug,
+			ThumbnailURL: entry.Course.ThumbnailURL,
+			Difficulty:   entry.Course.Difficulty,
+			OrderIndex:   entry.OrderIndex,
+			IsPublished:  entry.Course.IsPublished,
+		})
+	}
+
+	return &PathResponse{
+		ID:           path.ID,
+		Title:        path.Title,
+		Slug:         path.Slug,
+		Description:  path.Description,
+		ThumbnailURL: path.ThumbnailURL,
+		IsPublished:  path.IsPublished,
+		CreatedBy:    path.CreatedBy,
+		CourseCount:  len(courses),
+		Courses:      courses,
+		CreatedAt:    path.CreatedAt,
+		UpdatedAt:    path.UpdatedAt,
+	}
+}
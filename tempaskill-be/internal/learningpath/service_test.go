@@ -0,0 +1,96 @@
+package learningpath
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSummarizeProgress tests the path progress aggregate
+func TestSummarizeProgress(t *testing.T) {
+	courses := []*PathCourseProgress{
+		{CourseID: 1, IsEnrolled: true, IsCompleted: true, Percentage: 100},
+		{CourseID: 2, IsEnrolled: true, Percentage: 50},
+		{CourseID: 3},
+	}
+
+	summary := summarizeProgress(9, courses)
+
+	assert.Equal(t, uint(9), summary.PathID)
+	assert.Equal(t, 3, summary.TotalCourses)
+	assert.Equal(t, 2, summary.EnrolledCourses)
+	assert.Equal(t, 1, summary.CompletedCourses)
+	assert.Equal(t, 50.0, summary.Percentage)
+	assert.Equal(t, uint(2), summary.NextCourse.CourseID)
+}
+
+// TestCheckEligibility tests path certificate eligibility
+func TestCheckEligibility(t *testing.T) {
+	// Empty path is never eligible
+	empty := checkEligibility(summarizeProgress(1, nil))
+	assert.False(t, empty.Eligible)
+
+	// Missing courses are listed
+	partial := checkEligibility(summarizeProgress(1, []*PathCourseProgress{
+		{CourseID: 1, IsCompleted: true, Percentage: 100},
+		{CourseID: 2, Percentage: 20},
+	}))
+	assert.False(t, partial.Eligible)
+	assert.Len(t, partial.MissingCourses, 1)
+	assert.Equal(t, uint(2), partial.MissingCourses[0].CourseID)
+
+	// All courses completed
+	done := checkEligibility(summarizeProgress(1, []*PathCourseProgress{
+		{CourseID: 1, IsCompleted: true, Percentage: 100},
+		{CourseID: 2, IsCompleted: true, Percentage: 100},
+	}))
+	assert.True(t, done.Eligible)
+	assert.Empty(t, done.MissingCourses)
+	assert.Nil(t, summarizeProgress(1, []*PathCourseProgress{{IsCompleted: true}}).NextCourse)
+}
+
+// slugRepo is a Repository stub that only answers SlugExists
+type slugRepo struct {
+	Repository
+	taken map[string]uint // slug => path using it (deleted paths included)
+}
+
+func (r *slugRepo) SlugExists(ctx context.Context, slug string, pathID uint) (bool, error) {
+	owner, ok := r.taken[slug]
+	return ok && owner != pathID, nil
+}
+
+// TestSaveWithUniqueSlug tests slug suffixes for paths, including slugs of deleted paths,
+// renames and a slug taken by a concurrent save
+func TestSaveWithUniqueSlug(t *testing.T) {
+	repo := &slugRepo{taken: map[string]uint{"go-backend": 1}}
+	s := &service{repo: repo}
+	ctx := context.Background()
+
+	path := &LearningPath{Title: "Go Backend"}
+	assert.NoError(t, s.saveWithUniqueSlug(ctx, path, func() error { return nil }))
+	assert.Equal(t, "go-backend-2", path.Slug)
+
+	// A path keeps its own slug when renamed to the same title
+	own := &LearningPath{ID: 1, Title: "Go Backend"}
+	assert.NoError(t, s.saveWithUniqueSlug(ctx, own, func() error { return nil }))
+	assert.Equal(t, "go-backend", own.Slug)
+
+	// Taken between the lookup and the insert: retried with the next suffix
+	path = &LearningPath{Title: "Go Backend"}
+	saves := 0
+	assert.NoError(t, s.saveWithUniqueSlug(ctx, path, func() error {
+		if saves++; saves == 1 {
+			repo.taken[path.Slug] = 2
+			return course.ErrSlugTaken
+		}
+		return nil
+	}))
+	assert.Equal(t, "go-backend-3", path.Slug)
+
+	// Repeated races end in a conflict instead of a raw database error
+	err := s.saveWithUniqueSlug(ctx, &LearningPath{Title: "Busy"}, func() error { return course.ErrSlugTaken })
+	assert.Equal(t, ErrSlugExists, err)
+}
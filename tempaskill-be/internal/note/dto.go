@@ -0,0 +1,60 @@
+package note
+
+import "time"
+
+// NoteRequest represents a new note, or the full replacement of an existing one
+type NoteRequest struct {
+	Body           string `json:"body" binding:"required,min=1,max=10000"`
+	Heading        string `json:"heading" binding:"omitempty,max=255"`
+	VideoTimestamp *int   `json:"video_timestamp" binding:"omitempty,min=0"` // Seconds
+}
+
+// BookmarkListQuery represents query parameters for listing bookmarks
+type BookmarkListQuery struct {
+	CourseID uint `form:"course_id"` // Optional: one course only
+}
+
+// NoteResponse is a note as returned to its owner
+type NoteResponse struct {
+	ID             uint      `json:"id"`
+	CourseID       uint      `json:"course_id"`
+	LessonID       uint      `json:"lesson_id"`
+	Body           string    `json:"body"`
+	Heading        string    `json:"heading,omitempty"`
+	VideoTimestamp *int      `json:"video_timestamp,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// LessonNotesResponse represents the user's notes and bookmark on one lesson
+type LessonNotesResponse struct {
+	LessonID     uint            `json:"lesson_id"`
+	LessonTitle  string          `json:"lesson_title"`
+	IsBookmarked bool            `json:"is_bookmarked"`
+	Notes        []*NoteResponse `json:"notes"` // Oldest first
+}
+
+// CourseNotesResponse represents the user's notes across a course, grouped by lesson in
+// course order. Lessons without notes are only listed when bookmarked.
+type CourseNotesResponse struct {
+	CourseID    uint                   `json:"course_id"`
+	CourseTitle string                 `json:"course_title"`
+	Lessons     []*LessonNotesResponse `json:"lessons"`
+	TotalNotes  int                    `json:"total_notes"`
+}
+
+// BookmarkResponse represents a bookmarked lesson with what is needed to link to it
+type BookmarkResponse struct {
+	LessonID    uint      `json:"lesson_id"`
+	LessonTitle string    `json:"lesson_title"`
+	CourseID    uint      `json:"course_id"`
+	CourseTitle string    `json:"course_title"`
+	CourseSlug  string    `json:"course_slug"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NotesExport is a rendered Markdown export of a course's notes
+type NotesExport struct {
+	Filename string
+	Content  []byte
+}
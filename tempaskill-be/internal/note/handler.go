@@ -0,0 +1,268 @@
+package note
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	switch err {
+	case ErrCourseNotFound, ErrLessonNotFound, ErrNoteNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrNoAccess:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUserID returns the authenticated user's ID from the JWT middleware
+func getUserID(c *gin.Context) (uint, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, false
+	}
+	return userID.(uint), true
+}
+
+// parseID parses the :id path parameter (a lesson, note or course ID)
+func parseID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// ListLessonNotes handles GET /lessons/:id/notes
+func (h *Handler) ListLessonNotes(c *gin.Context) {
+	lessonID, ok := parseID(c, "lesson")
+	if !ok {
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListLessonNotes(c.Request.Context(), userID, lessonID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// CreateNote handles POST /lessons/:id/notes
+func (h *Handler) CreateNote(c *gin.Context) {
+	lessonID, ok := parseID(c, "lesson")
+	if !ok {
+		return
+	}
+
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	note, err := h.service.CreateNote(c.Request.Context(), userID, lessonID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Note saved successfully",
+		"data":    note,
+	})
+}
+
+// UpdateNote handles PUT /notes/:id
+func (h *Handler) UpdateNote(c *gin.Context) {
+	noteID, ok := parseID(c, "note")
+	if !ok {
+		return
+	}
+
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	note, err := h.service.UpdateNote(c.Request.Context(), userID, noteID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note updated successfully",
+		"data":    note,
+	})
+}
+
+// DeleteNote handles DELETE /notes/:id
+func (h *Handler) DeleteNote(c *gin.Context) {
+	noteID, ok := parseID(c, "note")
+	if !ok {
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteNote(c.Request.Context(), userID, noteID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Note deleted successfully",
+	})
+}
+
+// AddBookmark handles POST /lessons/:id/bookmark
+func (h *Handler) AddBookmark(c *gin.Context) {
+	lessonID, ok := parseID(c, "lesson")
+	if !ok {
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.AddBookmark(c.Request.Context(), userID, lessonID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Lesson bookmarked",
+	})
+}
+
+// RemoveBookmark handles DELETE /lessons/:id/bookmark
+func (h *Handler) RemoveBookmark(c *gin.Context) {
+	lessonID, ok := parseID(c, "lesson")
+	if !ok {
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveBookmark(c.Request.Context(), userID, lessonID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Bookmark removed",
+	})
+}
+
+// ListBookmarks handles GET /bookmarks
+func (h *Handler) ListBookmarks(c *gin.Context) {
+	var query BookmarkListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	bookmarks, err := h.service.ListBookmarks(c.Request.Context(), userID, &query)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": bookmarks,
+	})
+}
+
+// ListCourseNotes handles GET /courses/:id/notes
+func (h *Handler) ListCourseNotes(c *gin.Context) {
+	courseID, ok := parseID(c, "course")
+	if !ok {
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.service.ListCourseNotes(c.Request.Context(), userID, courseID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": result,
+	})
+}
+
+// ExportCourseNotes handles GET /courses/:id/notes/export
+// Responds with a Markdown file download
+func (h *Handler) ExportCourseNotes(c *gin.Context) {
+	courseID, ok := parseID(c, "course")
+	if !ok {
+		return
+	}
+
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	export, err := h.service.ExportCourseNotes(c.Request.Context(), userID, courseID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename))
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", export.Content)
+}
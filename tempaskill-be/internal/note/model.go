@@ -0,0 +1,36 @@
+package note
+
+import "time"
+
+// Note is a learner's private note on a lesson, optionally anchored to a heading in the
+// lesson content or a moment in the lesson video
+type Note struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	UserID         uint      `gorm:"not null;index:idx_lesson_notes_user_course" json:"user_id"`
+	CourseID       uint      `gorm:"not null;index:idx_lesson_notes_user_course" json:"course_id"`
+	LessonID       uint      `gorm:"not null;index" json:"lesson_id"`
+	Body           string    `gorm:"type:text;not null" json:"body"`   // Markdown
+	Heading        string    `gorm:"type:varchar(255)" json:"heading"` // Anchor: heading text in the lesson content
+	VideoTimestamp *int      `json:"video_timestamp"`                  // Anchor: seconds into the lesson video
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Bookmark marks a lesson the learner wants to come back to
+type Bookmark struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_lesson_bookmarks_pair" json:"user_id"`
+	LessonID  uint      `gorm:"not null;uniqueIndex:idx_lesson_bookmarks_pair;index" json:"lesson_id"`
+	CourseID  uint      `gorm:"not null;index" json:"course_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for Note model
+func (Note) TableName() string {
+	return "lesson_notes"
+}
+
+// TableName specifies the table name for Bookmark model
+func (Bookmark) TableName() string {
+	return "lesson_bookmarks"
+}
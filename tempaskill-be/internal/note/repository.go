@@ -0,0 +1,146 @@
+package note
+
+import (
+	"context"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateNote(ctx context.Context, note *Note) error
+	FindNote(ctx context.Context, id, userID uint) (*Note, error)
+	FindLessonNotes(ctx context.Context, userID, lessonID uint) ([]*Note, error)
+	FindCourseNotes(ctx context.Context, userID, courseID uint) ([]*Note, error)
+	UpdateNote(ctx context.Context, note *Note) error
+	DeleteNote(ctx context.Context, id, userID uint) error
+
+	AddBookmark(ctx context.Context, bookmark *Bookmark) error
+	RemoveBookmark(ctx context.Context, userID, lessonID uint) error
+	IsBookmarked(ctx context.Context, userID, lessonID uint) (bool, error)
+	FindBookmarkedLessonIDs(ctx context.Context, userID, courseID uint) (map[uint]bool, error)
+	FindBookmarks(ctx context.Context, userID, courseID uint) ([]*BookmarkResponse, error)
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateNote(ctx context.Context, note *Note) error {
+	if err := r.db.WithContext(ctx).Create(note).Error; err != nil {
+		logger.Error("Failed to create note",
+			zap.Error(err),
+			zap.Uint("user_id", note.UserID),
+			zap.Uint("lesson_id", note.LessonID),
+		)
+		return err
+	}
+	return nil
+}
+
+// FindNote returns a note only when it belongs to the user
+func (r *repository) FindNote(ctx context.Context, id, userID uint) (*Note, error) {
+	var note Note
+	if err := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&note).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (r *repository) FindLessonNotes(ctx context.Context, userID, lessonID uint) ([]*Note, error) {
+	var notes []*Note
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND lesson_id = ?", userID, lessonID).
+		Order("created_at ASC, id ASC").
+		Find(&notes).Error
+	return notes, err
+}
+
+func (r *repository) FindCourseNotes(ctx context.Context, userID, courseID uint) ([]*Note, error) {
+	var notes []*Note
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND course_id = ?", userID, courseID).
+		Order("created_at ASC, id ASC").
+		Find(&notes).Error
+	return notes, err
+}
+
+// UpdateNote saves the body and anchors (cleared anchors included)
+func (r *repository) UpdateNote(ctx context.Context, note *Note) error {
+	return r.db.WithContext(ctx).Model(note).
+		Select("body", "heading", "video_timestamp").
+		Updates(note).Error
+}
+
+func (r *repository) DeleteNote(ctx context.Context, id, userID uint) error {
+	return r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&Note{}).Error
+}
+
+// AddBookmark bookmarks a lesson; bookmarking it again is a no-op
+func (r *repository) AddBookmark(ctx context.Context, bookmark *Bookmark) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(bookmark).Error
+}
+
+func (r *repository) RemoveBookmark(ctx context.Context, userID, lessonID uint) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND lesson_id = ?", userID, lessonID).
+		Delete(&Bookmark{}).Error
+}
+
+func (r *repository) IsBookmarked(ctx context.Context, userID, lessonID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Bookmark{}).
+		Where("user_id = ? AND lesson_id = ?", userID, lessonID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) FindBookmarkedLessonIDs(ctx context.Context, userID, courseID uint) (map[uint]bool, error) {
+	var lessonIDs []uint
+	if err := r.db.WithContext(ctx).Model(&Bookmark{}).
+		Where("user_id = ? AND course_id = ?", userID, courseID).
+		Pluck("lesson_id", &lessonIDs).Error; err != nil {
+		return nil, err
+	}
+
+	bookmarked := make(map[uint]bool, len(lessonIDs))
+	for _, id := range lessonIDs {
+		bookmarked[id] = true
+	}
+	return bookmarked, nil
+}
+
+// FindBookmarks returns the user's bookmarks, newest first, with lesson and course titles.
+// Bookmarks on deleted lessons or courses are left out. courseID 0 means all courses.
+func (r *repository) FindBookmarks(ctx context.Context, userID, courseID uint) ([]*BookmarkResponse, error) {
+	query := r.db.WithContext(ctx).Table("lesson_bookmarks AS b").
+		Select("b.lesson_id, lessons.title AS lesson_title, b.course_id, courses.title AS course_title, courses.slug AS course_slug, b.created_at").
+		Joins("JOIN lessons ON lessons.id = b.lesson_id AND lessons.deleted_at IS NULL").
+		Joins("JOIN courses ON courses.id = b.course_id AND courses.deleted_at IS NULL").
+		Where("b.user_id = ?", userID)
+	if courseID > 0 {
+		query = query.Where("b.course_id = ?", courseID)
+	}
+
+	var bookmarks []*BookmarkResponse
+	if err := query.Order("b.created_at DESC, b.id DESC").Scan(&bookmarks).Error; err != nil {
+		logger.Error("Database error finding bookmarks",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+		return nil, err
+	}
+	return bookmarks, nil
+}
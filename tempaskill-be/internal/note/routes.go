@@ -0,0 +1,34 @@
+package note
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, notifier course.Notifier) {
+	// Initialize layers
+	repo := NewRepository(db)
+	courseRepo := course.NewRepository(db)
+	service := NewService(repo, courseRepo, course.NewService(courseRepo, notifier))
+	handler := NewHandler(service)
+
+	// All note and bookmark routes require authentication and only touch the user's own data
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// Notes
+		protected.GET("/lessons/:id/notes", handler.ListLessonNotes)          // My notes and bookmark on a lesson
+		protected.POST("/lessons/:id/notes", handler.CreateNote)              // Add note (optional heading/video anchor)
+		protected.PUT("/notes/:id", handler.UpdateNote)                       // Replace note
+		protected.DELETE("/notes/:id", handler.DeleteNote)                    // Delete note
+		protected.GET("/courses/:id/notes", handler.ListCourseNotes)          // My notes across a course, by lesson
+		protected.GET("/courses/:id/notes/export", handler.ExportCourseNotes) // Download as Markdown
+
+		// Bookmarks
+		protected.GET("/bookmarks", handler.ListBookmarks)                // My bookmarks (?course_id=)
+		protected.POST("/lessons/:id/bookmark", handler.AddBookmark)      // Bookmark lesson
+		protected.DELETE("/lessons/:id/bookmark", handler.RemoveBookmark) // Remove bookmark
+	}
+}
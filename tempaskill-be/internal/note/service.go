@@ -0,0 +1,295 @@
+package note
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+)
+
+var (
+	ErrCourseNotFound = errors.New("course not found")
+	ErrLessonNotFound = errors.New("lesson not found")
+	ErrNoteNotFound   = errors.New("note not found")
+	ErrNoAccess       = errors.New("enroll in the course to take notes on this lesson")
+)
+
+// Notes and bookmarks are private: every operation is scoped to the authenticated user
+type Service interface {
+	ListLessonNotes(ctx context.Context, userID uint, lessonID uint) (*LessonNotesResponse, error)
+	CreateNote(ctx context.Context, userID uint, lessonID uint, req *NoteRequest) (*NoteResponse, error)
+	UpdateNote(ctx context.Context, userID uint, noteID uint, req *NoteRequest) (*NoteResponse, error)
+	DeleteNote(ctx context.Context, userID uint, noteID uint) error
+
+	AddBookmark(ctx context.Context, userID uint, lessonID uint) error
+	RemoveBookmark(ctx context.Context, userID uint, lessonID uint) error
+	ListBookmarks(ctx context.Context, userID uint, query *BookmarkListQuery) ([]*BookmarkResponse, error)
+
+	ListCourseNotes(ctx context.Context, userID uint, courseID uint) (*CourseNotesResponse, error)
+	ExportCourseNotes(ctx context.Context, userID uint, courseID uint) (*NotesExport, error)
+}
+
+type service struct {
+	repo          Repository
+	courseRepo    course.Repository
+	courseService course.Service
+}
+
+func NewService(repo Repository, courseRepo course.Repository, courseService course.Service) Service {
+	return &service{
+		repo:          repo,
+		courseRepo:    courseRepo,
+		courseService: courseService,
+	}
+}
+
+// Helper: Check the user can read the lesson (same rules as course.Service.GetLesson);
+// notes and bookmarks can only be added to lessons the user can open
+func (s *service) checkAccess(ctx context.Context, userID uint, lessonID uint) (*course.LessonAccess, error) {
+	access, err := s.courseService.CheckLessonAccess(ctx, userID, lessonID)
+	if err != nil {
+		switch err {
+		case course.ErrLessonNotFound, course.ErrCourseNotFound:
+			return nil, ErrLessonNotFound
+		case course.ErrUnauthorized:
+			return nil, ErrNoAccess
+		}
+		return nil, err
+	}
+	if !access.CanViewContent() {
+		return nil, ErrNoAccess
+	}
+	return access, nil
+}
+
+// Helper: Convert a note for its owner
+func toResponse(note *Note) *NoteResponse {
+	return &NoteResponse{
+		ID:             note.ID,
+		CourseID:       note.CourseID,
+		LessonID:       note.LessonID,
+		Body:           note.Body,
+		Heading:        note.Heading,
+		VideoTimestamp: note.VideoTimestamp,
+		CreatedAt:      note.CreatedAt,
+		UpdatedAt:      note.UpdatedAt,
+	}
+}
+
+// Notes
+
+// ListLessonNotes returns the user's notes on a lesson. Reading your own notes needs no
+// enrollment, so they stay available after access expires.
+func (s *service) ListLessonNotes(ctx context.Context, userID uint, lessonID uint) (*LessonNotesResponse, error) {
+	lesson, err := s.courseRepo.FindLessonByID(ctx, lessonID)
+	if err != nil {
+		return nil, ErrLessonNotFound
+	}
+
+	notes, err := s.repo.FindLessonNotes(ctx, userID, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	bookmarked, err := s.repo.IsBookmarked(ctx, userID, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &LessonNotesResponse{
+		LessonID:     lesson.ID,
+		LessonTitle:  lesson.Title,
+		IsBookmarked: bookmarked,
+		Notes:        make([]*NoteResponse, len(notes)),
+	}
+	for i, note := range notes {
+		resp.Notes[i] = toResponse(note)
+	}
+	return resp, nil
+}
+
+func (s *service) CreateNote(ctx context.Context, userID uint, lessonID uint, req *NoteRequest) (*NoteResponse, error) {
+	access, err := s.checkAccess(ctx, userID, lessonID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &Note{
+		UserID:         userID,
+		CourseID:       access.Lesson.CourseID,
+		LessonID:       lessonID,
+		Body:           req.Body,
+		Heading:        strings.TrimSpace(req.Heading),
+		VideoTimestamp: req.VideoTimestamp,
+	}
+	if err := s.repo.CreateNote(ctx, note); err != nil {
+		return nil, err
+	}
+	return toResponse(note), nil
+}
+
+// UpdateNote replaces a note's body and anchors (omitted anchors are cleared)
+func (s *service) UpdateNote(ctx context.Context, userID uint, noteID uint, req *NoteRequest) (*NoteResponse, error) {
+	note, err := s.repo.FindNote(ctx, noteID, userID)
+	if err != nil {
+		return nil, ErrNoteNotFound
+	}
+
+	note.Body = req.Body
+	note.Heading = strings.TrimSpace(req.Heading)
+	note.VideoTimestamp = req.VideoTimestamp
+	if err := s.repo.UpdateNote(ctx, note); err != nil {
+		return nil, err
+	}
+	return toResponse(note), nil
+}
+
+func (s *service) DeleteNote(ctx context.Context, userID uint, noteID uint) error {
+	if _, err := s.repo.FindNote(ctx, noteID, userID); err != nil {
+		return ErrNoteNotFound
+	}
+	return s.repo.DeleteNote(ctx, noteID, userID)
+}
+
+// Bookmarks
+
+func (s *service) AddBookmark(ctx context.Context, userID uint, lessonID uint) error {
+	access, err := s.checkAccess(ctx, userID, lessonID)
+	if err != nil {
+		return err
+	}
+	return s.repo.AddBookmark(ctx, &Bookmark{
+		UserID:   userID,
+		LessonID: lessonID,
+		CourseID: access.Lesson.CourseID,
+	})
+}
+
+func (s *service) RemoveBookmark(ctx context.Context, userID uint, lessonID uint) error {
+	return s.repo.RemoveBookmark(ctx, userID, lessonID)
+}
+
+func (s *service) ListBookmarks(ctx context.Context, userID uint, query *BookmarkListQuery) ([]*BookmarkResponse, error) {
+	return s.repo.FindBookmarks(ctx, userID, query.CourseID)
+}
+
+// Course notes and export
+
+func (s *service) ListCourseNotes(ctx context.Context, userID uint, courseID uint) (*CourseNotesResponse, error) {
+	_, resp, err := s.findCourseNotes(ctx, userID, courseID)
+	return resp, err
+}
+
+// ExportCourseNotes renders the user's notes on a course as a Markdown document
+func (s *service) ExportCourseNotes(ctx context.Context, userID uint, courseID uint) (*NotesExport, error) {
+	c, resp, err := s.findCourseNotes(ctx, userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	return &NotesExport{
+		Filename: c.Slug + "-notes.md",
+		Content:  []byte(renderMarkdown(resp, time.Now())),
+	}, nil
+}
+
+// Helper: Load the user's notes and bookmarks on a course, grouped by lesson
+func (s *service) findCourseNotes(ctx context.Context, userID uint, courseID uint) (*course.Course, *CourseNotesResponse, error) {
+	c, err := s.courseRepo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return nil, nil, ErrCourseNotFound
+	}
+
+	lessons, err := s.courseRepo.FindLessonsByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	notes, err := s.repo.FindCourseNotes(ctx, userID, courseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	bookmarked, err := s.repo.FindBookmarkedLessonIDs(ctx, userID, courseID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp := &CourseNotesResponse{
+		CourseID:    c.ID,
+		CourseTitle: c.Title,
+		Lessons:     groupByLesson(lessons, notes, bookmarked),
+	}
+	for _, lesson := range resp.Lessons {
+		resp.TotalNotes += len(lesson.Notes)
+	}
+	return c, resp, nil
+}
+
+// groupByLesson groups notes under their lessons in course order, keeping lessons that have
+// notes or are bookmarked. Notes on deleted lessons are left out.
+func groupByLesson(lessons []*course.Lesson, notes []*Note, bookmarked map[uint]bool) []*LessonNotesResponse {
+	byLesson := make(map[uint][]*NoteResponse)
+	for _, note := range notes {
+		byLesson[note.LessonID] = append(byLesson[note.LessonID], toResponse(note))
+	}
+
+	groups := make([]*LessonNotesResponse, 0)
+	for _, lesson := range lessons {
+		lessonNotes := byLesson[lesson.ID]
+		if len(lessonNotes) == 0 && !bookmarked[lesson.ID] {
+			continue
+		}
+		if lessonNotes == nil {
+			lessonNotes = []*NoteResponse{}
+		}
+		groups = append(groups, &LessonNotesResponse{
+			LessonID:     lesson.ID,
+			LessonTitle:  lesson.Title,
+			IsBookmarked: bookmarked[lesson.ID],
+			Notes:        lessonNotes,
+		})
+	}
+	return groups
+}
+
+// formatTimestamp formats a video position as m:ss, or h:mm:ss from an hour on
+func formatTimestamp(seconds int) string {
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds%3600/60, seconds%60)
+	}
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// renderMarkdown renders course notes as a Markdown document: one section per lesson, one
+// subsection per note titled by its anchor
+func renderMarkdown(notes *CourseNotesResponse, exportedAt time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", notes.CourseTitle)
+	fmt.Fprintf(&b, "My notes, exported %s.\n", exportedAt.Format("2006-01-02"))
+	if len(notes.Lessons) == 0 {
+		b.WriteString("\nNo notes yet.\n")
+		return b.String()
+	}
+
+	for _, lesson := range notes.Lessons {
+		fmt.Fprintf(&b, "\n## %s\n", lesson.LessonTitle)
+		if lesson.IsBookmarked {
+			b.WriteString("\n_Bookmarked_\n")
+		}
+		for _, note := range lesson.Notes {
+			anchors := make([]string, 0, 2)
+			if note.VideoTimestamp != nil {
+				anchors = append(anchors, formatTimestamp(*note.VideoTimestamp))
+			}
+			if note.Heading != "" {
+				anchors = append(anchors, note.Heading)
+			}
+			title := "Note"
+			if len(anchors) > 0 {
+				title = strings.Join(anchors, " · ")
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n%s\n", title, strings.TrimSpace(note.Body))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package note
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFormatTimestamp tests video anchor formatting
+func TestFormatTimestamp(t *testing.T) {
+	assert.Equal(t, "0:07", formatTimestamp(7))
+	assert.Equal(t, "2:15", formatTimestamp(135))
+	assert.Equal(t, "59:59", formatTimestamp(3599))
+	assert.Equal(t, "1:02:03", formatTimestamp(3723))
+}
+
+// TestGroupByLesson tests grouping notes in course order, keeping bookmarked lessons
+func TestGroupByLesson(t *testing.T) {
+	lessons := []*course.Lesson{{ID: 1, Title: "Intro"}, {ID: 2, Title: "Setup"}, {ID: 3, Title: "Deploy"}}
+	notes := []*Note{
+		{ID: 10, LessonID: 3, Body: "ship it"},
+		{ID: 11, LessonID: 1, Body: "first"},
+		{ID: 12, LessonID: 99, Body: "on a deleted lesson"},
+		{ID: 13, LessonID: 3, Body: "rollback"},
+	}
+
+	groups := groupByLesson(lessons, notes, map[uint]bool{2: true})
+	require.Len(t, groups, 3)
+	assert.Equal(t, uint(1), groups[0].LessonID)
+	assert.Equal(t, uint(2), groups[1].LessonID)
+	assert.True(t, groups[1].IsBookmarked)
+	assert.Empty(t, groups[1].Notes)
+	assert.NotNil(t, groups[1].Notes)
+	assert.Equal(t, uint(3), groups[2].LessonID)
+	assert.Equal(t, []uint{10, 13}, []uint{groups[2].Notes[0].ID, groups[2].Notes[1].ID})
+
+	assert.Empty(t, groupByLesson(lessons, nil, nil))
+}
+
+// TestRenderMarkdown tests the notes export layout
+func TestRenderMarkdown(t *testing.T) {
+	timestamp := 135
+	notes := &CourseNotesResponse{
+		CourseTitle: "Go Basics",
+		Lessons: []*LessonNotesResponse{
+			{LessonTitle: "Intro", IsBookmarked: true, Notes: []*NoteResponse{}},
+			{LessonTitle: "Goroutines", Notes: []*NoteResponse{
+				{Body: "Use a WaitGroup\n", Heading: "Sync", VideoTimestamp: &timestamp},
+				{Body: "Channels close once."},
+			}},
+		},
+	}
+	exportedAt := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+
+	expected := "# Go Basics\n\n" +
+		"My notes, exported 2026-10-16.\n" +
+		"\n## Intro\n\n_Bookmarked_\n" +
+		"\n## Goroutines\n" +
+		"\n### 2:15 · Sync\n\nUse a WaitGroup\n" +
+		"\n### Note\n\nChannels close once.\n"
+	assert.Equal(t, expected, renderMarkdown(notes, exportedAt))
+
+	empty := renderMarkdown(&CourseNotesResponse{CourseTitle: "Go Basics"}, exportedAt)
+	assert.Contains(t, empty, "No notes yet.")
+}
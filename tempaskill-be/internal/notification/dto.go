@@ -0,0 +1,23 @@
+package notification
+
+// NotificationListQuery represents query parameters for listing notifications
+type NotificationListQuery struct {
+	Page       int  `form:"page" binding:"omitempty,min=1"`
+	Limit      int  `form:"limit" binding:"omitempty,min=1,max=100"`
+	UnreadOnly bool `form:"unread_only"`
+}
+
+// NotificationListResponse represents a page of notifications, newest first
+type NotificationListResponse struct {
+	Notifications []*Notification `json:"notifications"`
+	UnreadCount   int             `json:"unread_count"`
+	Pagination    PaginationMeta  `json:"pagination"`
+}
+
+// PaginationMeta represents pagination information
+type PaginationMeta struct {
+	Page       int `json:"page"`
+	Limit      int `json:"limit"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
@@ -0,0 +1,20 @@
+package notification
+
+import "time"
+
+// Notification is an in-app message for one user (e.g. a course review decision)
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_read" json:"user_id"`
+	Type      string     `gorm:"type:varchar(50);not null" json:"type"` // e.g. course_approved
+	Title     string     `gorm:"type:varchar(200);not null" json:"title"`
+	Message   string     `gorm:"type:text" json:"message"`
+	Link      string     `gorm:"type:varchar(255)" json:"link"` // Frontend path to open, may be empty
+	ReadAt    *time.Time `gorm:"index:idx_notifications_user_read" json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for Notification model
+func (Notification) TableName() string {
+	return "notifications"
+}
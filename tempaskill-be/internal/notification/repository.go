@@ -0,0 +1,90 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	CreateNotification(ctx context.Context, notification *Notification) error
+	FindNotifications(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]*Notification, int, error)
+	CountUnread(ctx context.Context, userID uint) (int, error)
+	MarkRead(ctx context.Context, userID, id uint) (bool, error)
+	MarkAllRead(ctx context.Context, userID uint) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+func (r *repository) CreateNotification(ctx context.Context, notification *Notification) error {
+	if err := r.db.WithContext(ctx).Create(notification).Error; err != nil {
+		logger.Error("Failed to create notification",
+			zap.Error(err),
+			zap.Uint("user_id", notification.UserID),
+			zap.String("type", notification.Type),
+		)
+		return err
+	}
+	return nil
+}
+
+// FindNotifications returns a page of a user's notifications, newest first, with the total count
+func (r *repository) FindNotifications(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]*Notification, int, error) {
+	db := r.db.WithContext(ctx).Model(&Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		db = db.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var notifications []*Notification
+	if err := db.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		logger.Error("Database error finding notifications",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+		return nil, 0, err
+	}
+	return notifications, int(total), nil
+}
+
+func (r *repository) CountUnread(ctx context.Context, userID uint) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return int(count), err
+}
+
+// MarkRead marks one of the user's notifications as read; false if it is not theirs
+func (r *repository) MarkRead(ctx context.Context, userID, id uint) (bool, error) {
+	var notification Notification
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&notification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	if notification.ReadAt != nil {
+		return true, nil
+	}
+	return true, r.db.WithContext(ctx).Model(&notification).Update("read_at", time.Now()).Error
+}
+
+func (r *repository) MarkAllRead(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Model(&Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now()).Error
+}
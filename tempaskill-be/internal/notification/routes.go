@@ -0,0 +1,19 @@
+package notification
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, service Service, authMiddleware *middleware.AuthMiddleware) {
+	handler := NewHandler(service)
+
+	// All notification routes are the authenticated user's own inbox
+	protected := router.Group("/notifications")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		protected.GET("", handler.ListNotifications)     // Newest first, with unread count
+		protected.POST("/read-all", handler.MarkAllRead) // Mark every notification read
+		protected.POST("/:id/read", handler.MarkRead)    // Mark one notification read
+	}
+}
@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+type Service interface {
+	// Notify stores a notification for a user (used by other modules; they define the types)
+	Notify(ctx context.Context, userID uint, notificationType, title, message, link string) error
+
+	// Inbox of the authenticated user
+	ListNotifications(ctx context.Context, userID uint, query *NotificationListQuery) (*NotificationListResponse, error)
+	MarkRead(ctx context.Context, userID uint, notificationID uint) error
+	MarkAllRead(ctx context.Context, userID uint) error
+}
+
+type service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) Service {
+	return &service{repo: repo}
+}
+
+func (s *service) Notify(ctx context.Context, userID uint, notificationType, title, message, link string) error {
+	return s.repo.CreateNotification(ctx, &Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		Link:    link,
+	})
+}
+
+func (s *service) ListNotifications(ctx context.Context, userID uint, query *NotificationListQuery) (*NotificationListResponse, error) {
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 {
+		limit = 20
+	}
+
+	notifications, total, err := s.repo.FindNotifications(ctx, userID, query.UnreadOnly, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if notifications == nil {
+		notifications = []*Notification{}
+	}
+
+	return &NotificationListResponse{
+		Notifications: notifications,
+		UnreadCount:   unread,
+		Pagination: PaginationMeta{
+			Page:       page,
+			Limit:      limit,
+			Total:      total,
+			TotalPages: (total + limit - 1) / limit,
+		},
+	}, nil
+}
+
+func (s *service) MarkRead(ctx context.Context, userID uint, notificationID uint) error {
+	found, err := s.repo.MarkRead(ctx, userID, notificationID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (s *service) MarkAllRead(ctx context.Context, userID uint) error {
+	return s.repo.MarkAllRead(ctx, userID)
+}
@@ -18,6 +18,7 @@ type PaymentListQuery struct {
 	InstructorID      uint   `form:"instructor_id"`     // Admin only: filter by instructor
 	SortBy            string `form:"sort_by"`           // created_at, gross_amount
 	SortOrder         string `form:"sort_order"`        // asc, desc
+	Cursor            string `form:"cursor"`            // next_cursor of the previous page; replaces page
 }
 
 // Response DTOs
@@ -35,6 +36,7 @@ type PaymentResponse struct {
 	TransactionTime   time.Time  `json:"transaction_time"`
 	SettlementTime    *time.Time `json:"settlement_time,omitempty"`
 	PaymentURL        string     `json:"payment_url,omitempty"`
+	NeedsRefund       bool       `json:"needs_refund"` // Settled while the course was full
 	CreatedAt         time.Time  `json:"created_at"`
 	UpdatedAt         time.Time  `json:"updated_at"`
 }
@@ -50,6 +52,7 @@ type PaymentWithDetails struct {
 	TransactionTime   time.Time  `json:"transaction_time"`
 	SettlementTime    *time.Time `json:"settlement_time,omitempty"`
 	PaymentURL        string     `json:"payment_url,omitempty"` // For repayment
+	NeedsRefund       bool       `json:"needs_refund"`         // Settled while the course was full
 	
 	// User details
 	UserID    uint   `json:"user_id"`
@@ -1,9 +1,12 @@
 package payment
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"github.com/gin-gonic/gin"
 )
 
@@ -55,10 +58,17 @@ func (h *PaymentHandler) GetPayments(c *gin.Context) {
 		CourseID: uint(courseID),
 		SortBy:   c.DefaultQuery("sort_by", "date"),
 		SortOrder: c.DefaultQuery("sort_order", "DESC"),
+		Cursor:   c.Query("cursor"),
 	}
 
 	// Get payments with role-based filtering
-	payments, total, err := h.service.GetPayments(userID.(uint), userRole.(string), query)
+	payments, total, nextCursor, err := h.service.GetPayments(userID.(uint), userRole.(string), query)
+	if err == cursor.ErrInvalidCursor {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": err.Error(),
@@ -79,6 +89,8 @@ func (h *PaymentHandler) GetPayments(c *gin.Context) {
 				"limit":       limit,
 				"total":       total,
 				"total_pages": totalPages,
+				"next_cursor": nextCursor,
+				"has_more":    nextCursor != "",
 			},
 		},
 	})
@@ -145,6 +157,14 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 
 	payment, err := h.service.CreatePayment(userID.(uint), req)
 	if err != nil {
+		var prerequisitesErr *course.PrerequisitesNotMetError
+		if errors.As(err, &prerequisitesErr) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":                 err.Error(),
+				"missing_prerequisites": prerequisitesErr.Missing,
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -206,7 +226,13 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 		limit = 10
 	}
 
-	payments, total, err := h.service.GetUserPayments(userID.(uint), page, limit)
+	payments, total, nextCursor, err := h.service.GetUserPayments(userID.(uint), page, limit, c.Query("cursor"))
+	if err == cursor.ErrInvalidCursor {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve payments",
@@ -224,6 +250,8 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 			"limit":       limit,
 			"total":       total,
 			"total_pages": totalPages,
+			"next_cursor": nextCursor,
+			"has_more":    nextCursor != "",
 		},
 	})
 }
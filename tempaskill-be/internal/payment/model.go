@@ -20,6 +20,7 @@ type PaymentTransaction struct {
 	SettlementTime    *time.Time `json:"settlement_time,omitempty"`
 	PaymentURL        string    `gorm:"size:500" json:"payment_url,omitempty"`
 	MidtransResponse  string    `gorm:"type:text" json:"midtrans_response,omitempty"`
+	NeedsRefund       bool      `gorm:"not null;default:false" json:"needs_refund"` // Settled while the course was full; cleared if the buyer gets a seat
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"gorm.io/gorm"
 )
 
@@ -11,11 +12,12 @@ type PaymentRepository interface {
 	Create(transaction *PaymentTransaction) error
 	FindByOrderID(orderID string) (*PaymentTransaction, error)
 	FindByID(id uint) (*PaymentTransaction, error)
-	FindByUserID(userID uint, page, limit int) ([]PaymentTransaction, int, error)
+	FindByUserID(userID uint, page, limit int, after string) ([]PaymentTransaction, int, string, error)
 	FindAll(page, limit int) ([]PaymentTransaction, int, error)
-	FindWithFilters(query PaymentListQuery) ([]PaymentTransaction, int, error)
+	FindWithFilters(query PaymentListQuery) ([]PaymentTransaction, int, string, error)
 	Update(transaction *PaymentTransaction) error
-	UpdateStatus(orderID, status string, settlementTime *time.Time) error
+	UpdateStatus(orderID, status string, settlementTime *time.Time) (bool, error)
+	SetNeedsRefund(id uint, needsRefund bool) error
 	FindPendingPaymentByUserAndCourse(userID uint, courseID uint) (*PaymentTransaction, error)
 }
 
@@ -49,28 +51,41 @@ func (r *paymentRepository) FindByID(id uint) (*PaymentTransaction, error) {
 	return &transaction, nil
 }
 
-func (r *paymentRepository) FindByUserID(userID uint, page, limit int) ([]PaymentTransaction, int, error) {
+// FindByUserID returns a user's payments, newest first. after is the next_cursor of the
+// previous page; when set it replaces page and the total is not counted.
+func (r *paymentRepository) FindByUserID(userID uint, page, limit int, after string) ([]PaymentTransaction, int, string, error) {
 	var transactions []PaymentTransaction
 	var total int64
 
-	offset := (page - 1) * limit
+	keys := []cursor.Key{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+	db := r.db.Preload("User").Preload("Course").Where("user_id = ?", userID)
 
-	// Get total count
-	r.db.Model(&PaymentTransaction{}).Where("user_id = ?", userID).Count(&total)
+	if after != "" {
+		values, err := cursor.Decode(after, len(keys))
+		if err != nil {
+			return nil, 0, "", err
+		}
+		condition, args := cursor.Condition(keys, values)
+		db = db.Where(condition, args...)
+	} else {
+		// Get total count
+		r.db.Model(&PaymentTransaction{}).Where("user_id = ?", userID).Count(&total)
+		db = db.Offset((page - 1) * limit)
+	}
 
-	// Get paginated results
-	err := r.db.Preload("User").Preload("Course").
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
+	// Get paginated results, plus one row to tell whether a next page exists
+	err := db.Order(cursor.OrderClause(keys)).
+		Limit(limit + 1).
 		Find(&transactions).Error
 
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 
-	return transactions, int(total), nil
+	transactions, next := cursor.Next(transactions, limit, func(t PaymentTransaction) []interface{} {
+		return []interface{}{t.CreatedAt, t.ID}
+	})
+	return transactions, int(total), next, nil
 }
 
 func (r *paymentRepository) FindAll(page, limit int) ([]PaymentTransaction, int, error) {
@@ -100,7 +115,10 @@ func (r *paymentRepository) Update(transaction *PaymentTransaction) error {
 	return r.db.Save(transaction).Error
 }
 
-func (r *paymentRepository) UpdateStatus(orderID, status string, settlementTime *time.Time) error {
+// UpdateStatus moves a payment to a new status and reports whether it changed. The status
+// is compared in the UPDATE itself, so of concurrent or resent notifications with the same
+// status only one reports a change.
+func (r *paymentRepository) UpdateStatus(orderID, status string, settlementTime *time.Time) (bool, error) {
 	updateData := map[string]interface{}{
 		"transaction_status": status,
 		"updated_at":         time.Now(),
@@ -110,13 +128,22 @@ func (r *paymentRepository) UpdateStatus(orderID, status string, settlementTime
 		updateData["settlement_time"] = settlementTime
 	}
 
+	result := r.db.Model(&PaymentTransaction{}).
+		Where("order_id = ? AND transaction_status <> ?", orderID, status).
+		Updates(updateData)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *paymentRepository) SetNeedsRefund(id uint, needsRefund bool) error {
 	return r.db.Model(&PaymentTransaction{}).
-		Where("order_id = ?", orderID).
-		Updates(updateData).Error
+		Where("id = ?", id).
+		Update("needs_refund", needsRefund).Error
 }
 
-// FindWithFilters retrieves payments with advanced filtering, pagination, and sorting
-func (r *paymentRepository) FindWithFilters(query PaymentListQuery) ([]PaymentTransaction, int, error) {
+// FindWithFilters retrieves payments with advanced filtering, pagination, and sorting.
+// With query.Cursor the page is read by keyset and the total is not counted; the returned
+// cursor points at the next page either way ("" on the last page).
+func (r *paymentRepository) FindWithFilters(query PaymentListQuery) ([]PaymentTransaction, int, string, error) {
 	var transactions []PaymentTransaction
 	var total int64
 
@@ -146,43 +173,59 @@ func (r *paymentRepository) FindWithFilters(query PaymentListQuery) ([]PaymentTr
 			Where("users.name LIKE ? OR users.email LIKE ?", searchPattern, searchPattern)
 	}
 
-	// Count total before pagination
-	db.Count(&total)
-
-	// Sorting
+	// Sorting (id breaks ties so the keyset is unique)
 	sortBy := "payment_transactions.created_at"
 	sortOrder := "DESC"
+	sortValue := func(t PaymentTransaction) interface{} { return t.CreatedAt }
 	
 	if query.SortBy != "" {
 		switch query.SortBy {
 		case "amount":
 			sortBy = "payment_transactions.gross_amount"
+			sortValue = func(t PaymentTransaction) interface{} { return t.GrossAmount }
 		case "date":
 			sortBy = "payment_transactions.created_at"
 		case "status":
 			sortBy = "payment_transactions.transaction_status"
+			sortValue = func(t PaymentTransaction) interface{} { return t.TransactionStatus }
 		}
 	}
 	
 	if query.SortOrder != "" && (query.SortOrder == "ASC" || query.SortOrder == "DESC") {
 		sortOrder = query.SortOrder
 	}
+	desc := sortOrder == "DESC"
+	keys := []cursor.Key{{Column: sortBy, Desc: desc}, {Column: "payment_transactions.id", Desc: desc}}
 
 	// Pagination
-	offset := (query.Page - 1) * query.Limit
+	if query.Cursor != "" {
+		values, err := cursor.Decode(query.Cursor, len(keys))
+		if err != nil {
+			return nil, 0, "", err
+		}
+		condition, args := cursor.Condition(keys, values)
+		db = db.Where(condition, args...)
+	} else {
+		// Count total before pagination
+		db.Count(&total)
+		db = db.Offset((query.Page - 1) * query.Limit)
+	}
 	
-	// Execute query with preloads (use same db query chain)
+	// Execute query with preloads (use same db query chain), plus one row to tell whether
+	// a next page exists
 	err := db.Preload("User").Preload("Course").Preload("Course.Instructor").
-		Order(sortBy + " " + sortOrder).
-		Offset(offset).
-		Limit(query.Limit).
+		Order(cursor.OrderClause(keys)).
+		Limit(query.Limit + 1).
 		Find(&transactions).Error
 
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 
-	return transactions, int(total), nil
+	transactions, next := cursor.Next(transactions, query.Limit, func(t PaymentTransaction) []interface{} {
+		return []interface{}{sortValue(t), t.ID}
+	})
+	return transactions, int(total), next, nil
 }
 
 func (r *paymentRepository) FindPendingPaymentByUserAndCourse(userID uint, courseID uint) (*PaymentTransaction, error) {
@@ -2,25 +2,29 @@ package payment
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha512"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"time"
 
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/auth"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type PaymentService interface {
 	CreatePayment(userID uint, req CreatePaymentRequest) (*PaymentResponse, error)
 	GetPaymentStatus(orderID string) (*PaymentResponse, error)
-	GetUserPayments(userID uint, page, limit int) ([]PaymentResponse, int, error)
+	GetUserPayments(userID uint, page, limit int, after string) ([]PaymentResponse, int, string, error)
 	GetAllPayments(page, limit int) ([]PaymentResponse, int, error)
-	GetPayments(userID uint, userRole string, query PaymentListQuery) ([]PaymentWithDetails, int, error)
+	GetPayments(userID uint, userRole string, query PaymentListQuery) ([]PaymentWithDetails, int, string, error)
 	GetPaymentStats(userID uint, userRole string) (*PaymentStatsResponse, error)
 	HandleMidtransNotification(notification MidtransNotification) error
 }
@@ -28,6 +32,7 @@ type PaymentService interface {
 type paymentService struct {
 	repo           PaymentRepository
 	courseRepo     course.Repository
+	courseService  course.Service
 	userRepo       auth.Repository
 	midtransConfig MidtransConfig
 }
@@ -39,10 +44,11 @@ type MidtransConfig struct {
 	BaseURL      string
 }
 
-func NewPaymentService(repo PaymentRepository, courseRepo course.Repository, userRepo auth.Repository, config MidtransConfig) PaymentService {
+func NewPaymentService(repo PaymentRepository, courseRepo course.Repository, courseService course.Service, userRepo auth.Repository, config MidtransConfig) PaymentService {
 	return &paymentService{
 		repo:           repo,
 		courseRepo:     courseRepo,
+		courseService:  courseService,
 		userRepo:       userRepo,
 		midtransConfig: config,
 	}
@@ -56,6 +62,22 @@ func (s *paymentService) CreatePayment(userID uint, req CreatePaymentRequest) (*
 		return nil, fmt.Errorf("course not found: %w", err)
 	}
 
+	// Enrolled students may buy (renew) time-limited access; lifetime access has nothing left
+	// to buy. New buyers need a free seat (seats of a full course go to its waitlist) and the
+	// prerequisites, same as EnrollCourse
+	if enrollment, err := s.courseRepo.FindEnrollment(context.Background(), userID, course.ID); err == nil {
+		if enrollment.ExpiresAt == nil {
+			return nil, fmt.Errorf("you already have lifetime access to this course")
+		}
+	} else {
+		if course.IsFull() {
+			return nil, fmt.Errorf("course is full; join the waitlist instead")
+		}
+		if err := s.courseService.CheckPrerequisites(context.Background(), userID, course.ID); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if user already purchased this course
 	existingPayment, _ := s.repo.FindByOrderID(fmt.Sprintf("user_%d_course_%d", userID, req.CourseID))
 	if existingPayment != nil && existingPayment.TransactionStatus == "settlement" {
@@ -182,6 +204,7 @@ func (s *paymentService) CreatePayment(userID uint, req CreatePaymentRequest) (*
 		TransactionStatus: transaction.TransactionStatus,
 		TransactionTime:   transaction.TransactionTime,
 		PaymentURL:        transaction.PaymentURL,
+		NeedsRefund:       transaction.NeedsRefund,
 		CreatedAt:         transaction.CreatedAt,
 		UpdatedAt:         transaction.UpdatedAt,
 	}
@@ -209,6 +232,7 @@ func (s *paymentService) GetPaymentStatus(orderID string) (*PaymentResponse, err
 		TransactionTime:   transaction.TransactionTime,
 		SettlementTime:    transaction.SettlementTime,
 		PaymentURL:        transaction.PaymentURL,
+		NeedsRefund:       transaction.NeedsRefund,
 		CreatedAt:         transaction.CreatedAt,
 		UpdatedAt:         transaction.UpdatedAt,
 	}
@@ -216,10 +240,10 @@ func (s *paymentService) GetPaymentStatus(orderID string) (*PaymentResponse, err
 	return response, nil
 }
 
-func (s *paymentService) GetUserPayments(userID uint, page, limit int) ([]PaymentResponse, int, error) {
-	transactions, total, err := s.repo.FindByUserID(userID, page, limit)
+func (s *paymentService) GetUserPayments(userID uint, page, limit int, after string) ([]PaymentResponse, int, string, error) {
+	transactions, total, nextCursor, err := s.repo.FindByUserID(userID, page, limit, after)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 
 	responses := make([]PaymentResponse, len(transactions))
@@ -238,12 +262,13 @@ func (s *paymentService) GetUserPayments(userID uint, page, limit int) ([]Paymen
 			TransactionTime:   transaction.TransactionTime,
 			SettlementTime:    transaction.SettlementTime,
 			PaymentURL:        transaction.PaymentURL,
+			NeedsRefund:       transaction.NeedsRefund,
 			CreatedAt:         transaction.CreatedAt,
 			UpdatedAt:         transaction.UpdatedAt,
 		}
 	}
 
-	return responses, total, nil
+	return responses, total, nextCursor, nil
 }
 
 func (s *paymentService) GetAllPayments(page, limit int) ([]PaymentResponse, int, error) {
@@ -268,6 +293,7 @@ func (s *paymentService) GetAllPayments(page, limit int) ([]PaymentResponse, int
 			TransactionTime:   transaction.TransactionTime,
 			SettlementTime:    transaction.SettlementTime,
 			PaymentURL:        transaction.PaymentURL,
+			NeedsRefund:       transaction.NeedsRefund,
 			CreatedAt:         transaction.CreatedAt,
 			UpdatedAt:         transaction.UpdatedAt,
 		}
@@ -277,7 +303,7 @@ func (s *paymentService) GetAllPayments(page, limit int) ([]PaymentResponse, int
 }
 
 // GetPayments retrieves payments with role-based filtering
-func (s *paymentService) GetPayments(userID uint, userRole string, query PaymentListQuery) ([]PaymentWithDetails, int, error) {
+func (s *paymentService) GetPayments(userID uint, userRole string, query PaymentListQuery) ([]PaymentWithDetails, int, string, error) {
 	// Apply role-based filters
 	switch userRole {
 	case "instructor":
@@ -291,13 +317,13 @@ func (s *paymentService) GetPayments(userID uint, userRole string, query Payment
 	case "admin":
 		// Admin sees everything, no additional filters
 	default:
-		return nil, 0, fmt.Errorf("invalid user role")
+		return nil, 0, "", fmt.Errorf("invalid user role")
 	}
 
 	// Retrieve transactions from repository
-	transactions, total, err := s.repo.FindWithFilters(query)
+	transactions, total, nextCursor, err := s.repo.FindWithFilters(query)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, "", err
 	}
 
 	// Convert to detailed response
@@ -313,6 +339,7 @@ func (s *paymentService) GetPayments(userID uint, userRole string, query Payment
 			TransactionTime:   transaction.TransactionTime,
 			SettlementTime:    transaction.SettlementTime,
 			PaymentURL:        transaction.PaymentURL,
+			NeedsRefund:       transaction.NeedsRefund,
 			UserID:            transaction.UserID,
 			CourseID:          transaction.CourseID,
 			CreatedAt:         transaction.CreatedAt,
@@ -340,7 +367,7 @@ func (s *paymentService) GetPayments(userID uint, userRole string, query Payment
 		responses[i] = response
 	}
 
-	return responses, total, nil
+	return responses, total, nextCursor, nil
 }
 
 // GetPaymentStats retrieves payment statistics with role-based filtering
@@ -424,13 +451,14 @@ func (s *paymentService) HandleMidtransNotification(notification MidtransNotific
 	}
 
 	// Update payment status
-	err := s.repo.UpdateStatus(notification.OrderID, notification.TransactionStatus, settlementTime)
+	changed, err := s.repo.UpdateStatus(notification.OrderID, notification.TransactionStatus, settlementTime)
 	if err != nil {
 		return fmt.Errorf("failed to update payment status: %w", err)
 	}
 
-	// If payment is successful, enroll user in course
-	if notification.TransactionStatus == "settlement" {
+	// If payment is successful, enroll user in course. Midtrans resends notifications, so
+	// access and earnings are only granted when the payment first moves into settlement.
+	if changed && notification.TransactionStatus == "settlement" {
 		if err := s.enrollUserInCourse(notification.OrderID); err != nil {
 			// Log error but don't fail the webhook
 			fmt.Printf("Failed to enroll user in course: %v\n", err)
@@ -535,10 +563,29 @@ func (s *paymentService) getUserByID(userID uint) (*auth.User, error) {
 	return u, nil
 }
 
+// enrollUserInCourse gives the buyer access after a successful payment. Buying a course
+// again while enrolled renews the access period (see course.GrantAccess). When the last
+// seats went while the payment was pending, the buyer is put at the front of the waitlist
+// and the payment is flagged for refund until a seat opens up.
 func (s *paymentService) enrollUserInCourse(orderID string) error {
-	// This should enroll the user in the course after successful payment
-	// Implementation depends on enrollment system
-	return nil
+	payment, err := s.repo.FindByOrderID(orderID)
+	if err != nil {
+		return fmt.Errorf("payment not found: %w", err)
+	}
+
+	c, err := s.getCourseByID(payment.CourseID)
+	if err != nil {
+		return fmt.Errorf("course not found: %w", err)
+	}
+
+	_, err = course.GrantAccess(context.Background(), s.courseRepo, c, payment.UserID, payment.ID, time.Now())
+	var waitlisted *course.WaitlistedError
+	if errors.As(err, &waitlisted) {
+		fmt.Printf("Warning: course %d is full; payment %s waitlisted at position %d and flagged for refund\n",
+			c.ID, orderID, waitlisted.Position)
+		return s.repo.SetNeedsRefund(payment.ID, true)
+	}
+	return err
 }
 
 func (s *paymentService) createInstructorEarning(orderID string) error {
@@ -549,7 +596,7 @@ func (s *paymentService) createInstructorEarning(orderID string) error {
 	}
 	
 	// Get course to find instructor
-	course, err := s.getCourseByID(payment.CourseID)
+	c, err := s.getCourseByID(payment.CourseID)
 	if err != nil {
 		return fmt.Errorf("course not found: %w", err)
 	}
@@ -568,25 +615,71 @@ func (s *paymentService) createInstructorEarning(orderID string) error {
 	}
 	availableDate := transactionDate.AddDate(0, 0, holdingDays)
 	
-	// Create earning record using raw SQL to avoid import cycle
-	db := s.repo.(*paymentRepository).db
-	earning := map[string]interface{}{
-		"instructor_id":          course.InstructorID,
-		"payment_transaction_id": payment.ID,
-		"course_id":              course.ID,
-		"gross_amount":           grossAmount,
-		"platform_fee":           platformFee,
-		"instructor_share":       instructorShare,
-		"transaction_date":       transactionDate,
-		"available_date":         availableDate,
-		"status":                 "held",
-		"created_at":             time.Now(),
-		"updated_at":             time.Now(),
+	// Split the sale between the instructor and co-instructors with a revenue share
+	collaborators, err := s.courseRepo.FindCollaborators(context.Background(), c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get course collaborators: %w", err)
 	}
-	
-	if err := db.Table("instructor_earnings").Create(&earning).Error; err != nil {
-		return fmt.Errorf("failed to create earning: %w", err)
+
+	// Create earning records using raw SQL to avoid import cycle; all shares or none
+	db := s.repo.(*paymentRepository).db
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, part := range splitEarning(course.SplitRevenue(c.InstructorID, collaborators), grossAmount, platformFee, instructorShare) {
+			earning := map[string]interface{}{
+				"instructor_id":          part.userID,
+				"payment_transaction_id": payment.ID,
+				"course_id":              c.ID,
+				"gross_amount":           part.grossAmount,
+				"platform_fee":           part.platformFee,
+				"instructor_share":       part.instructorShare,
+				"transaction_date":       transactionDate,
+				"available_date":         availableDate,
+				"status":                 "held",
+				"created_at":             time.Now(),
+				"updated_at":             time.Now(),
+			}
+
+			if err := tx.Table("instructor_earnings").Create(&earning).Error; err != nil {
+				return fmt.Errorf("failed to create earning: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// earningPart is one user's part of a sale
+type earningPart struct {
+	userID          uint
+	grossAmount     float64
+	platformFee     float64
+	instructorShare float64
+}
+
+// splitEarning divides the sale amounts by revenue share. Parts are rounded to cents and
+// the last share takes the remainder, so the parts always add up to the totals.
+func splitEarning(shares []course.RevenueShare, grossAmount, platformFee, instructorShare float64) []earningPart {
+	parts := make([]earningPart, 0, len(shares))
+	var gross, fee, share float64
+	for i, revenueShare := range shares {
+		part := earningPart{userID: revenueShare.UserID}
+		if i == len(shares)-1 {
+			part.grossAmount = roundCents(grossAmount - gross)
+			part.platformFee = roundCents(platformFee - fee)
+			part.instructorShare = roundCents(instructorShare - share)
+		} else {
+			ratio := float64(revenueShare.Percent) / 100
+			part.grossAmount = roundCents(grossAmount * ratio)
+			part.platformFee = roundCents(platformFee * ratio)
+			part.instructorShare = roundCents(instructorShare * ratio)
+			gross += part.grossAmount
+			fee += part.platformFee
+			share += part.instructorShare
+		}
+		parts = append(parts, part)
 	}
-	
-	return nil
+	return parts
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
 }
\ No newline at end of file
@@ -69,6 +69,36 @@ func (h *Handler) MarkLessonComplete(c *gin.Context) {
 			})
 			return
 		}
+		if err == ErrQuizNotPassed {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "QUIZ_NOT_PASSED",
+					"message": "You must pass the quiz before completing this lesson",
+				},
+			})
+			return
+		}
+		if err == ErrLessonLocked {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "LESSON_LOCKED",
+					"message": "This lesson has not been released yet",
+				},
+			})
+			return
+		}
+		if err == ErrAssignmentPending {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "ASSIGNMENT_NOT_ACCEPTED",
+					"message": "Your assignment submission must be accepted before completing this lesson",
+				},
+			})
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"success": false,
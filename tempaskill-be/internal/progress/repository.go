@@ -17,6 +17,9 @@ type Repository interface {
 	GetUserProgress(ctx context.Context, userID uint) ([]*LessonProgress, error)
 	IsLessonCompleted(ctx context.Context, userID, lessonID uint) (bool, error)
 	GetCourseCompletionCount(ctx context.Context, userID, courseID uint) (int64, error)
+	HasPassedQuiz(ctx context.Context, userID, quizID uint) (bool, error)
+	HasAcceptedSubmission(ctx context.Context, userID, lessonID uint) (bool, error)
+	CountUnreadAnnouncements(ctx context.Context, userID uint) (map[uint]int, error)
 }
 
 type repository struct {
@@ -130,3 +133,64 @@ func (r *repository) GetCourseCompletionCount(ctx context.Context, userID, cours
 
 	return count, nil
 }
+
+// HasPassedQuiz checks whether the user has a passing attempt for a quiz
+// (quiz_attempts is owned by the quiz module)
+func (r *repository) HasPassedQuiz(ctx context.Context, userID, quizID uint) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Table("quiz_attempts").
+		Where("user_id = ? AND quiz_id = ? AND passed = ?", userID, quizID, true).
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// HasAcceptedSubmission checks whether the user has an accepted assignment submission for a lesson
+// (assignment_submissions is owned by the assignment module)
+func (r *repository) HasAcceptedSubmission(ctx context.Context, userID, lessonID uint) (bool, error) {
+	var count int64
+	result := r.db.WithContext(ctx).
+		Table("assignment_submissions").
+		Where("user_id = ? AND lesson_id = ? AND status = ?", userID, lessonID, "accepted").
+		Count(&count)
+
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+// CountUnreadAnnouncements returns the number of unread announcements per enrolled course
+// (course_announcements and announcement_reads are owned by the announcement module)
+func (r *repository) CountUnreadAnnouncements(ctx context.Context, userID uint) (map[uint]int, error) {
+	var rows []struct {
+		CourseID uint
+		Count    int
+	}
+	if err := r.db.WithContext(ctx).
+		Table("course_announcements AS a").
+		Select("a.course_id, COUNT(*) AS count").
+		Joins("JOIN enrollments AS e ON e.course_id = a.course_id AND e.user_id = ? AND e.deleted_at IS NULL AND (e.expires_at IS NULL OR e.expires_at > ?)", userID, time.Now()).
+		Joins("LEFT JOIN announcement_reads AS ar ON ar.announcement_id = a.id AND ar.user_id = ?", userID).
+		Where("ar.id IS NULL").
+		Group("a.course_id").
+		Scan(&rows).Error; err != nil {
+		logger.Error("Database error counting unread announcements",
+			zap.Error(err),
+			zap.Uint("user_id", userID),
+		)
+		return nil, err
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.CourseID] = row.Count
+	}
+	return counts, nil
+}
@@ -13,6 +13,9 @@ var (
 	ErrCourseNotFound     = errors.New("course not found")
 	ErrNotEnrolled        = errors.New("not enrolled in this course")
 	ErrUnauthorized       = errors.New("unauthorized access")
+	ErrQuizNotPassed      = errors.New("quiz lesson requires a passing quiz attempt")
+	ErrAssignmentPending  = errors.New("assignment lesson requires an accepted submission")
+	ErrLessonLocked       = errors.New("lesson is not released yet")
 )
 
 type Service interface {
@@ -22,33 +25,62 @@ type Service interface {
 }
 
 type service struct {
-	repo       Repository
-	courseRepo course.Repository
+	repo          Repository
+	courseRepo    course.Repository
+	courseService course.Service
 }
 
-func NewService(repo Repository, courseRepo course.Repository) Service {
+func NewService(repo Repository, courseRepo course.Repository, courseService course.Service) Service {
 	return &service{
-		repo:       repo,
-		courseRepo: courseRepo,
+		repo:          repo,
+		courseRepo:    courseRepo,
+		courseService: courseService,
 	}
 }
 
 // MarkLessonComplete marks a lesson as completed
 func (s *service) MarkLessonComplete(ctx context.Context, userID, lessonID uint) (*CourseProgressResponse, error) {
-	// Get lesson to verify it exists and get course ID
-	lesson, err := s.courseRepo.FindLessonByID(ctx, lessonID)
-	if err != nil {
-		return nil, ErrLessonNotFound
-	}
-
-	// Check if user is enrolled in the course
-	enrolled, err := s.courseRepo.IsUserEnrolled(ctx, userID, lesson.CourseID)
+	// Only enrolled students can complete lessons, and drip-fed lessons only once they are
+	// released (same access rules as course.Service.GetLesson)
+	access, err := s.courseService.CheckLessonAccess(ctx, userID, lessonID)
 	if err != nil {
+		switch err {
+		case course.ErrLessonNotFound, course.ErrCourseNotFound:
+			return nil, ErrLessonNotFound
+		case course.ErrUnauthorized, course.ErrNotEnrolled:
+			return nil, ErrNotEnrolled
+		}
 		return nil, err
 	}
-	if !enrolled {
+	if !access.IsEnrolled {
 		return nil, ErrNotEnrolled
 	}
+	if !access.IsParticipant() {
+		return nil, ErrLessonLocked
+	}
+	lesson := access.Lesson
+
+	// Quiz lessons are completed by passing the quiz, not by the client's word
+	if lesson.Type == course.LessonTypeQuiz && lesson.QuizID != nil {
+		passed, err := s.repo.HasPassedQuiz(ctx, userID, *lesson.QuizID)
+		if err != nil {
+			return nil, err
+		}
+		if !passed {
+			return nil, ErrQuizNotPassed
+		}
+	}
+
+	// Assignment lessons are completed when the instructor accepts a submission
+	if lesson.Type == course.LessonTypeAssignment {
+		accepted, err := s.repo.HasAcceptedSubmission(ctx, userID, lessonID)
+		if err != nil {
+			return nil, err
+		}
+		if !accepted {
+			return nil, ErrAssignmentPending
+		}
+	}
 
 	// Mark lesson as complete (idempotent)
 	_, err = s.repo.MarkLessonComplete(ctx, userID, lessonID, lesson.CourseID)
@@ -83,6 +115,12 @@ func (s *service) GetCourseProgress(ctx context.Context, userID, courseID uint)
 		return nil, err
 	}
 
+	// Get sections to group lessons
+	sections, err := s.courseRepo.FindSectionsByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get user's progress for this course
 	progressRecords, err := s.repo.GetCourseProgress(ctx, userID, courseID)
 	if err != nil {
@@ -111,12 +149,16 @@ func (s *service) GetCourseProgress(ctx context.Context, userID, courseID uint)
 
 		lessonResponses = append(lessonResponses, &LessonProgressResponse{
 			LessonID:    lesson.ID,
+			SectionID:   lesson.SectionID,
 			Title:       lesson.Title,
+			Type:        lesson.Type,
 			IsCompleted: completed,
 			CompletedAt: completedAt,
 		})
 	}
 
+	sectionResponses := groupLessonProgressBySection(sections, lessonResponses)
+
 	// Calculate percentage
 	totalLessons := len(lessons)
 	percentage := 0.0
@@ -143,11 +185,52 @@ func (s *service) GetCourseProgress(ctx context.Context, userID, courseID uint)
 		Percentage:         percentage,
 		CompletedLessonIDs: completedLessonIDs,
 		Lessons:            lessonResponses,
+		Sections:           sectionResponses,
 		StartedAt:          startedAt,
 		LastAccessed:       lastAccessed,
 	}, nil
 }
 
+// groupLessonProgressBySection builds per-section completion counts.
+// Lessons without a section are left out (they are still part of the flat list).
+func groupLessonProgressBySection(sections []*course.Section, lessons []*LessonProgressResponse) []*SectionProgressResponse {
+	responses := make([]*SectionProgressResponse, 0, len(sections))
+	sectionMap := make(map[uint]*SectionProgressResponse, len(sections))
+	for _, section := range sections {
+		resp := &SectionProgressResponse{
+			SectionID:  section.ID,
+			Title:      section.Title,
+			OrderIndex: section.OrderIndex,
+			Lessons:    []*LessonProgressResponse{},
+		}
+		sectionMap[section.ID] = resp
+		responses = append(responses, resp)
+	}
+
+	for _, lesson := range lessons {
+		if lesson.SectionID == nil {
+			continue
+		}
+		section, ok := sectionMap[*lesson.SectionID]
+		if !ok {
+			continue
+		}
+		section.Lessons = append(section.Lessons, lesson)
+		section.TotalLessons++
+		if lesson.IsCompleted {
+			section.CompletedLessons++
+		}
+	}
+
+	for _, section := range responses {
+		if section.TotalLessons > 0 {
+			section.Percentage = float64(section.CompletedLessons) / float64(section.TotalLessons) * 100
+		}
+	}
+
+	return responses
+}
+
 // GetUserProgress gets progress summary for all enrolled courses
 func (s *service) GetUserProgress(ctx context.Context, userID uint) (*UserProgressSummary, error) {
 	// Get ALL enrollments first (source of truth)
@@ -162,6 +245,13 @@ func (s *service) GetUserProgress(ctx context.Context, userID uint) (*UserProgre
 		return nil, err
 	}
 
+	// Unread course announcements (dashboard badges)
+	unreadAnnouncements, err := s.repo.CountUnreadAnnouncements(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	totalUnread := 0
+
 	// Group progress by course for easy lookup
 	courseProgressMap := make(map[uint][]*LessonProgress)
 	for _, p := range allProgress {
@@ -238,13 +328,16 @@ func (s *service) GetUserProgress(ctx context.Context, userID uint) (*UserProgre
 			IsCompleted:        isCompleted,         // Computed field
 			CompletedAt:        completedAt,
 			EnrolledAt:         &enrollment.EnrolledAt,
+			UnreadAnnouncements: unreadAnnouncements[enrollment.CourseID],
 		})
+		totalUnread += unreadAnnouncements[enrollment.CourseID]
 	}
 
 	return &UserProgressSummary{
-		TotalEnrolled:   totalEnrolled,
-		TotalCompleted:  totalCompleted,
-		TotalInProgress: totalInProgress,
-		Courses:         courseSummaries,
+		TotalEnrolled:       totalEnrolled,
+		TotalCompleted:      totalCompleted,
+		TotalInProgress:     totalInProgress,
+		UnreadAnnouncements: totalUnread,
+		Courses:             courseSummaries,
 	}, nil
 }
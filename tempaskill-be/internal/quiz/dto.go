@@ -0,0 +1,135 @@
+package quiz
+
+import "time"
+
+// CreateQuizRequest represents quiz creation payload
+type CreateQuizRequest struct {
+	Title               string `json:"title" binding:"required,min=3,max=200"`
+	Description         string `json:"description" binding:"omitempty,max=2000"`
+	PassMark            int    `json:"pass_mark" binding:"required,min=1,max=100"`
+	MaxAttempts         int    `json:"max_attempts" binding:"omitempty,min=0,max=100"`  // 0 = unlimited
+	QuestionsPerAttempt int    `json:"questions_per_attempt" binding:"omitempty,min=0"` // 0 = whole question bank
+	ShuffleQuestions    *bool  `json:"shuffle_questions"`                               // Defaults to true
+	ShuffleOptions      *bool  `json:"shuffle_options"`                                 // Defaults to true
+}
+
+// UpdateQuizRequest represents quiz update payload (nil = keep current value)
+type UpdateQuizRequest struct {
+	Title               *string `json:"title" binding:"omitempty,min=3,max=200"`
+	Description         *string `json:"description" binding:"omitempty,max=2000"`
+	PassMark            *int    `json:"pass_mark" binding:"omitempty,min=1,max=100"`
+	MaxAttempts         *int    `json:"max_attempts" binding:"omitempty,min=0,max=100"`
+	QuestionsPerAttempt *int    `json:"questions_per_attempt" binding:"omitempty,min=0"`
+	ShuffleQuestions    *bool   `json:"shuffle_questions"`
+	ShuffleOptions      *bool   `json:"shuffle_options"`
+}
+
+// QuestionRequest represents question create/replace payload
+//
+// Depending on Type:
+//   - single_choice:   Options with exactly one correct option
+//   - multiple_choice: Options with at least one correct option
+//   - true_false:      CorrectBoolean (options are generated)
+//   - short_answer:    AcceptedAnswers (exact match after trimming)
+type QuestionRequest struct {
+	Type            string        `json:"type" binding:"required,oneof=single_choice multiple_choice true_false short_answer"`
+	Prompt          string        `json:"prompt" binding:"required,min=3"`
+	Explanation     string        `json:"explanation" binding:"omitempty,max=2000"`
+	Points          int           `json:"points" binding:"omitempty,min=1,max=100"` // Defaults to 1
+	OrderIndex      int           `json:"order_index" binding:"omitempty,min=0"`
+	Options         []OptionInput `json:"options" binding:"omitempty,max=10,dive"`
+	CorrectBoolean  *bool         `json:"correct_boolean"`
+	AcceptedAnswers []string      `json:"accepted_answers" binding:"omitempty,max=20,dive,min=1,max=200"`
+	CaseSensitive   bool          `json:"case_sensitive"`
+}
+
+// OptionInput is a choice in a QuestionRequest
+type OptionInput struct {
+	ID        uint   `json:"id"` // Replacing a question: the existing option this edits (0 = new option)
+	Text      string `json:"text" binding:"required,max=500"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// SubmitAttemptRequest represents the answers of an attempt.
+// Questions without an answer are graded as incorrect.
+type SubmitAttemptRequest struct {
+	Answers []AnswerInput `json:"answers" binding:"required,dive"`
+}
+
+// AnswerInput is the answer to a single question
+type AnswerInput struct {
+	QuestionID uint   `json:"question_id" binding:"required,min=1"`
+	OptionIDs  []uint `json:"option_ids"`                       // choice and true/false questions
+	Text       string `json:"text" binding:"omitempty,max=500"` // short_answer questions
+}
+
+// QuizResponse is the API representation of a quiz.
+// Questions (with answers) are only included for the course instructor;
+// attempt statistics are only included for learners.
+type QuizResponse struct {
+	ID                  uint                `json:"id"`
+	CourseID            uint                `json:"course_id"`
+	Title               string              `json:"title"`
+	Description         string              `json:"description"`
+	PassMark            int                 `json:"pass_mark"`
+	MaxAttempts         int                 `json:"max_attempts"`
+	QuestionsPerAttempt int                 `json:"questions_per_attempt"`
+	ShuffleQuestions    bool                `json:"shuffle_questions"`
+	ShuffleOptions      bool                `json:"shuffle_options"`
+	QuestionCount       int                 `json:"question_count"`
+	Questions           []*QuestionResponse `json:"questions,omitempty"`
+	AttemptsUsed        *int                `json:"attempts_used,omitempty"`
+	AttemptsRemaining   *int                `json:"attempts_remaining,omitempty"` // nil = unlimited
+	BestPercentage      *int                `json:"best_percentage,omitempty"`
+	Passed              *bool               `json:"passed,omitempty"`
+	CreatedAt           time.Time           `json:"created_at"`
+}
+
+// QuestionResponse is a question; answer fields are only set when revealed
+type QuestionResponse struct {
+	ID              uint              `json:"id"`
+	Type            string            `json:"type"`
+	Prompt          string            `json:"prompt"`
+	Points          int               `json:"points"`
+	OrderIndex      int               `json:"order_index"`
+	Options         []*OptionResponse `json:"options"`
+	Explanation     string            `json:"explanation,omitempty"`
+	AcceptedAnswers []string          `json:"accepted_answers,omitempty"`
+	CaseSensitive   bool              `json:"case_sensitive,omitempty"`
+}
+
+// OptionResponse is a question option; IsCorrect is only set when revealed
+type OptionResponse struct {
+	ID        uint   `json:"id"`
+	Text      string `json:"text"`
+	IsCorrect *bool  `json:"is_correct,omitempty"`
+}
+
+// AttemptResponse is an attempt with its questions (in progress) or results (submitted)
+type AttemptResponse struct {
+	ID              uint                `json:"id"`
+	QuizID          uint                `json:"quiz_id"`
+	Status          string              `json:"status"`
+	Score           int                 `json:"score"`
+	MaxScore        int                 `json:"max_score"`
+	Percentage      int                 `json:"percentage"`
+	PassMark        int                 `json:"pass_mark"`
+	Passed          bool                `json:"passed"`
+	AnswersRevealed bool                `json:"answers_revealed"` // Correct answers are shown once passed or out of attempts
+	StartedAt       time.Time           `json:"started_at"`
+	SubmittedAt     *time.Time          `json:"submitted_at"`
+	Questions       []*QuestionResponse `json:"questions,omitempty"`
+	Results         []*AnswerResult     `json:"results,omitempty"`
+}
+
+// AnswerResult is the grading of one answer
+type AnswerResult struct {
+	QuestionID        uint     `json:"question_id"`
+	IsCorrect         bool     `json:"is_correct"`
+	PointsAwarded     int      `json:"points_awarded"`
+	SelectedOptionIDs []uint   `json:"selected_option_ids"`
+	TextAnswer        string   `json:"text_answer,omitempty"`
+	CorrectOptionIDs  []uint   `json:"correct_option_ids,omitempty"`
+	AcceptedAnswers   []string `json:"accepted_answers,omitempty"`
+	Explanation       string   `json:"explanation,omitempty"`
+}
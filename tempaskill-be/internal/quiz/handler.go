@@ -0,0 +1,379 @@
+package quiz
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	service Service
+}
+
+func NewHandler(service Service) *Handler {
+	return &Handler{service: service}
+}
+
+// writeError maps service errors to HTTP status codes
+func writeError(c *gin.Context, err error) {
+	switch err {
+	case ErrQuizNotFound, ErrQuestionNotFound, ErrAttemptNotFound, ErrCourseNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case ErrUnauthorized, ErrNotEnrolled, ErrLessonLocked, ErrAttemptLimitReached:
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case ErrNoQuestions, ErrAttemptAlreadySubmitted, ErrInvalidChoiceOptions, ErrInvalidOptionID,
+		ErrTrueFalseAnswerRequired, ErrAcceptedAnswersRequired:
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// getUser returns the authenticated user's ID and role from the JWT middleware
+func getUser(c *gin.Context) (uint, string, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, "", false
+	}
+	userRole, _ := c.Get("userRole")
+	role, _ := userRole.(string)
+	return userID.(uint), role, true
+}
+
+// parseID parses a numeric path parameter
+func parseID(c *gin.Context, param string, label string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(param), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// CreateQuiz handles POST /courses/:id/quizzes
+func (h *Handler) CreateQuiz(c *gin.Context) {
+	courseID, ok := parseID(c, "id", "course")
+	if !ok {
+		return
+	}
+
+	var req CreateQuizRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	quiz, err := h.service.CreateQuiz(c.Request.Context(), userID, userRole, courseID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Quiz created successfully",
+		"data":    quiz,
+	})
+}
+
+// ListCourseQuizzes handles GET /courses/:id/quizzes
+func (h *Handler) ListCourseQuizzes(c *gin.Context) {
+	courseID, ok := parseID(c, "id", "course")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	quizzes, err := h.service.ListCourseQuizzes(c.Request.Context(), userID, userRole, courseID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": quizzes,
+	})
+}
+
+// GetQuiz handles GET /quizzes/:id
+func (h *Handler) GetQuiz(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	quiz, err := h.service.GetQuiz(c.Request.Context(), userID, userRole, quizID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": quiz,
+	})
+}
+
+// UpdateQuiz handles PATCH /quizzes/:id
+func (h *Handler) UpdateQuiz(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+
+	var req UpdateQuizRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	quiz, err := h.service.UpdateQuiz(c.Request.Context(), userID, userRole, quizID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quiz updated successfully",
+		"data":    quiz,
+	})
+}
+
+// DeleteQuiz handles DELETE /quizzes/:id
+func (h *Handler) DeleteQuiz(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteQuiz(c.Request.Context(), userID, userRole, quizID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Quiz deleted successfully",
+	})
+}
+
+// AddQuestion handles POST /quizzes/:id/questions
+func (h *Handler) AddQuestion(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+
+	var req QuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	question, err := h.service.AddQuestion(c.Request.Context(), userID, userRole, quizID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Question added successfully",
+		"data":    question,
+	})
+}
+
+// ReplaceQuestion handles PUT /quizzes/:id/questions/:questionId
+func (h *Handler) ReplaceQuestion(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+	questionID, ok := parseID(c, "questionId", "question")
+	if !ok {
+		return
+	}
+
+	var req QuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	question, err := h.service.ReplaceQuestion(c.Request.Context(), userID, userRole, quizID, questionID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Question updated successfully",
+		"data":    question,
+	})
+}
+
+// DeleteQuestion handles DELETE /quizzes/:id/questions/:questionId
+func (h *Handler) DeleteQuestion(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+	questionID, ok := parseID(c, "questionId", "question")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteQuestion(c.Request.Context(), userID, userRole, quizID, questionID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Question deleted successfully",
+	})
+}
+
+// StartAttempt handles POST /quizzes/:id/attempts
+func (h *Handler) StartAttempt(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+
+	userID, userRole, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	attempt, err := h.service.StartAttempt(c.Request.Context(), userID, userRole, quizID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attempt started",
+		"data":    attempt,
+	})
+}
+
+// ListMyAttempts handles GET /quizzes/:id/attempts
+func (h *Handler) ListMyAttempts(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	attempts, err := h.service.ListMyAttempts(c.Request.Context(), userID, quizID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": attempts,
+	})
+}
+
+// GetAttempt handles GET /quizzes/:id/attempts/:attemptId
+func (h *Handler) GetAttempt(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+	attemptID, ok := parseID(c, "attemptId", "attempt")
+	if !ok {
+		return
+	}
+
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	attempt, err := h.service.GetAttempt(c.Request.Context(), userID, quizID, attemptID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": attempt,
+	})
+}
+
+// SubmitAttempt handles POST /quizzes/:id/attempts/:attemptId/submit
+func (h *Handler) SubmitAttempt(c *gin.Context) {
+	quizID, ok := parseID(c, "id", "quiz")
+	if !ok {
+		return
+	}
+	attemptID, ok := parseID(c, "attemptId", "attempt")
+	if !ok {
+		return
+	}
+
+	var req SubmitAttemptRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, _, ok := getUser(c)
+	if !ok {
+		return
+	}
+
+	attempt, err := h.service.SubmitAttempt(c.Request.Context(), userID, quizID, attemptID, &req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Attempt submitted and graded",
+		"data":    attempt,
+	})
+}
@@ -0,0 +1,124 @@
+package quiz
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Question types
+const (
+	QuestionTypeSingleChoice   = "single_choice"
+	QuestionTypeMultipleChoice = "multiple_choice"
+	QuestionTypeTrueFalse      = "true_false"
+	QuestionTypeShortAnswer    = "short_answer"
+)
+
+// Attempt statuses
+const (
+	AttemptStatusInProgress = "in_progress"
+	AttemptStatusSubmitted  = "submitted"
+)
+
+// Quiz is an assessment owned by a course. Its questions form the question bank;
+// each attempt draws QuestionsPerAttempt of them (all when 0).
+type Quiz struct {
+	ID                  uint           `gorm:"primaryKey" json:"id"`
+	CourseID            uint           `gorm:"not null;index" json:"course_id"`
+	Title               string         `gorm:"type:varchar(200);not null" json:"title"`
+	Description         string         `gorm:"type:text" json:"description"`
+	PassMark            int            `gorm:"not null;default:70" json:"pass_mark"`   // minimum percentage to pass (1-100)
+	MaxAttempts         int            `gorm:"not null;default:0" json:"max_attempts"` // 0 = unlimited
+	QuestionsPerAttempt int            `gorm:"not null;default:0" json:"questions_per_attempt"`
+	ShuffleQuestions    bool           `gorm:"default:false" json:"shuffle_questions"`
+	ShuffleOptions      bool           `gorm:"default:false" json:"shuffle_options"`
+	CreatedBy           uint           `gorm:"not null" json:"created_by"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Questions []Question `gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE" json:"questions,omitempty"`
+}
+
+// Question is a single question in a quiz's question bank
+type Question struct {
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	QuizID          uint           `gorm:"not null;index" json:"quiz_id"`
+	Type            string         `gorm:"type:varchar(20);not null" json:"type"` // single_choice, multiple_choice, true_false, short_answer
+	Prompt          string         `gorm:"type:text;not null" json:"prompt"`      // MDX
+	Explanation     string         `gorm:"type:text" json:"explanation"`          // Shown once answers are revealed
+	Points          int            `gorm:"not null;default:1" json:"points"`
+	OrderIndex      int            `gorm:"not null;default:0" json:"order_index"`
+	AcceptedAnswers []string       `gorm:"serializer:json;type:json" json:"accepted_answers,omitempty"` // short_answer only
+	CaseSensitive   bool           `gorm:"default:false" json:"case_sensitive"`                         // short_answer only
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Options []QuestionOption `gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE" json:"options,omitempty"`
+}
+
+// QuestionOption is a choice of a single/multiple choice or true/false question
+type QuestionOption struct {
+	ID         uint   `gorm:"primaryKey" json:"id"`
+	QuestionID uint   `gorm:"not null;index" json:"question_id"`
+	Text       string `gorm:"type:text;not null" json:"text"`
+	IsCorrect  bool   `gorm:"default:false" json:"is_correct"`
+	OrderIndex int    `gorm:"not null;default:0" json:"order_index"`
+}
+
+// Attempt is one user's try at a quiz
+type Attempt struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	QuizID      uint       `gorm:"not null;index:idx_quiz_attempts_quiz_user" json:"quiz_id"`
+	UserID      uint       `gorm:"not null;index:idx_quiz_attempts_quiz_user" json:"user_id"`
+	QuestionIDs []uint     `gorm:"serializer:json;type:json" json:"question_ids"` // Questions served, in order
+	Status      string     `gorm:"type:varchar(20);not null;default:'in_progress'" json:"status"`
+	Score       int        `gorm:"default:0" json:"score"`
+	MaxScore    int        `gorm:"default:0" json:"max_score"`
+	Percentage  int        `gorm:"default:0" json:"percentage"`
+	Passed      bool       `gorm:"default:false;index" json:"passed"`
+	StartedAt   time.Time  `json:"started_at"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+
+	// Relations
+	Answers []AttemptAnswer `gorm:"foreignKey:AttemptID;constraint:OnDelete:CASCADE" json:"answers,omitempty"`
+}
+
+// AttemptAnswer is the graded answer to one question of an attempt
+type AttemptAnswer struct {
+	ID                uint   `gorm:"primaryKey" json:"id"`
+	AttemptID         uint   `gorm:"not null;index" json:"attempt_id"`
+	QuestionID        uint   `gorm:"not null" json:"question_id"`
+	SelectedOptionIDs []uint `gorm:"serializer:json;type:json" json:"selected_option_ids"`
+	TextAnswer        string `gorm:"type:text" json:"text_answer"`
+	IsCorrect         bool   `gorm:"default:false" json:"is_correct"`
+	PointsAwarded     int    `gorm:"default:0" json:"points_awarded"`
+}
+
+// TableName specifies the table name for Quiz model
+func (Quiz) TableName() string {
+	return "quizzes"
+}
+
+// TableName specifies the table name for Question model
+func (Question) TableName() string {
+	return "quiz_questions"
+}
+
+// TableName specifies the table name for QuestionOption model
+func (QuestionOption) TableName() string {
+	return "quiz_question_options"
+}
+
+// TableName specifies the table name for Attempt model
+func (Attempt) TableName() string {
+	return "quiz_attempts"
+}
+
+// TableName specifies the table name for AttemptAnswer model
+func (AttemptAnswer) TableName() string {
+	return "quiz_attempt_answers"
+}
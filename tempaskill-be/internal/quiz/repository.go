@@ -0,0 +1,319 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	// Quiz operations
+	CreateQuiz(ctx context.Context, quiz *Quiz) error
+	FindQuizByID(ctx context.Context, id uint) (*Quiz, error)
+	FindQuizWithQuestions(ctx context.Context, id uint) (*Quiz, error)
+	FindQuizzesByCourseID(ctx context.Context, courseID uint) ([]*Quiz, error)
+	UpdateQuiz(ctx context.Context, quiz *Quiz) error
+	DeleteQuiz(ctx context.Context, id uint) error
+	CountQuestions(ctx context.Context, quizID uint) (int, error)
+
+	// Question operations
+	CreateQuestion(ctx context.Context, question *Question) error
+	FindQuestionByID(ctx context.Context, id uint) (*Question, error)
+	ReplaceQuestion(ctx context.Context, question *Question) error
+	DeleteQuestion(ctx context.Context, id uint) error
+
+	// Attempt operations
+	CreateAttemptWithinLimit(ctx context.Context, attempt *Attempt, maxAttempts int) (*Attempt, error)
+	FindAttemptByID(ctx context.Context, id uint) (*Attempt, error)
+	FindInProgressAttempt(ctx context.Context, quizID, userID uint) (*Attempt, error)
+	FindAttemptsByUser(ctx context.Context, quizID, userID uint) ([]*Attempt, error)
+	SaveAttemptResult(ctx context.Context, attempt *Attempt) error
+}
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) Repository {
+	return &repository{db: db}
+}
+
+// Quiz operations
+
+func (r *repository) CreateQuiz(ctx context.Context, quiz *Quiz) error {
+	if err := r.db.WithContext(ctx).Create(quiz).Error; err != nil {
+		logger.Error("Failed to create quiz in database",
+			zap.Error(err),
+			zap.Uint("course_id", quiz.CourseID),
+			zap.String("title", quiz.Title),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) FindQuizByID(ctx context.Context, id uint) (*Quiz, error) {
+	var quiz Quiz
+	if err := r.db.WithContext(ctx).First(&quiz, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding quiz by ID",
+				zap.Error(err),
+				zap.Uint("quiz_id", id),
+			)
+		}
+		return nil, err
+	}
+	return &quiz, nil
+}
+
+// FindQuizWithQuestions loads a quiz with its question bank and options, in display order
+func (r *repository) FindQuizWithQuestions(ctx context.Context, id uint) (*Quiz, error) {
+	var quiz Quiz
+	if err := r.db.WithContext(ctx).
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC, id ASC")
+		}).
+		Preload("Questions.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC, id ASC")
+		}).
+		First(&quiz, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Database error finding quiz with questions",
+				zap.Error(err),
+				zap.Uint("quiz_id", id),
+			)
+		}
+		return nil, err
+	}
+	return &quiz, nil
+}
+
+func (r *repository) FindQuizzesByCourseID(ctx context.Context, courseID uint) ([]*Quiz, error) {
+	var quizzes []*Quiz
+	if err := r.db.WithContext(ctx).Where("course_id = ?", courseID).
+		Order("created_at ASC").Find(&quizzes).Error; err != nil {
+		return nil, err
+	}
+	return quizzes, nil
+}
+
+func (r *repository) UpdateQuiz(ctx context.Context, quiz *Quiz) error {
+	return r.db.WithContext(ctx).Model(quiz).Select(
+		"title", "description", "pass_mark", "max_attempts",
+		"questions_per_attempt", "shuffle_questions", "shuffle_options",
+	).Updates(quiz).Error
+}
+
+func (r *repository) DeleteQuiz(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Quiz{}, id).Error
+}
+
+func (r *repository) CountQuestions(ctx context.Context, quizID uint) (int, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&Question{}).
+		Where("quiz_id = ?", quizID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// Question operations
+
+// CreateQuestion creates a question together with its options
+func (r *repository) CreateQuestion(ctx context.Context, question *Question) error {
+	if err := r.db.WithContext(ctx).Create(question).Error; err != nil {
+		logger.Error("Failed to create quiz question",
+			zap.Error(err),
+			zap.Uint("quiz_id", question.QuizID),
+		)
+		return err
+	}
+	return nil
+}
+
+func (r *repository) FindQuestionByID(ctx context.Context, id uint) (*Question, error) {
+	var question Question
+	if err := r.db.WithContext(ctx).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_index ASC, id ASC")
+		}).
+		First(&question, id).Error; err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// ReplaceQuestion updates a question and its options: options with an ID are updated in
+// place, new ones (ID 0) are created and the options left out are deleted
+func (r *repository) ReplaceQuestion(ctx context.Context, question *Question) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(question).Select(
+			"type", "prompt", "explanation", "points", "order_index",
+			"accepted_answers", "case_sensitive",
+		).Updates(question).Error; err != nil {
+			logger.Error("Failed to update quiz question",
+				zap.Error(err),
+				zap.Uint("question_id", question.ID),
+			)
+			return err
+		}
+
+		keep := make([]uint, 0, len(question.Options))
+		for _, option := range question.Options {
+			if option.ID != 0 {
+				keep = append(keep, option.ID)
+			}
+		}
+		removed := tx.Where("question_id = ?", question.ID)
+		if len(keep) > 0 {
+			removed = removed.Where("id NOT IN ?", keep)
+		}
+		if err := removed.Delete(&QuestionOption{}).Error; err != nil {
+			return err
+		}
+
+		for i := range question.Options {
+			option := &question.Options[i]
+			option.QuestionID = question.ID
+			if option.ID == 0 {
+				if err := tx.Create(option).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Model(option).Select("text", "is_correct", "order_index").Updates(option).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *repository) DeleteQuestion(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&Question{}, id).Error
+}
+
+// Attempt operations
+
+// CreateAttemptWithinLimit starts an attempt unless the user already has one in progress
+// (returned instead) or has used maxAttempts (0 = unlimited). The user row is locked for
+// the check, so parallel starts by the same user cannot both get past the limit.
+func (r *repository) CreateAttemptWithinLimit(ctx context.Context, attempt *Attempt, maxAttempts int) (*Attempt, error) {
+	started := attempt
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var userID uint
+		if err := tx.Table("users").Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").Where("id = ?", attempt.UserID).Scan(&userID).Error; err != nil {
+			return err
+		}
+
+		var existing Attempt
+		err := tx.Where("quiz_id = ? AND user_id = ? AND status = ?", attempt.QuizID, attempt.UserID, AttemptStatusInProgress).
+			Order("started_at DESC").
+			First(&existing).Error
+		if err == nil {
+			started = &existing
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if maxAttempts > 0 {
+			var used int64
+			if err := tx.Model(&Attempt{}).
+				Where("quiz_id = ? AND user_id = ?", attempt.QuizID, attempt.UserID).
+				Count(&used).Error; err != nil {
+				return err
+			}
+			if int(used) >= maxAttempts {
+				return ErrAttemptLimitReached
+			}
+		}
+		return tx.Create(attempt).Error
+	})
+	if err != nil {
+		if err != ErrAttemptLimitReached {
+			logger.Error("Failed to create quiz attempt",
+				zap.Error(err),
+				zap.Uint("quiz_id", attempt.QuizID),
+				zap.Uint("user_id", attempt.UserID),
+			)
+		}
+		return nil, err
+	}
+	return started, nil
+}
+
+func (r *repository) FindAttemptByID(ctx context.Context, id uint) (*Attempt, error) {
+	var attempt Attempt
+	if err := r.db.WithContext(ctx).Preload("Answers").First(&attempt, id).Error; err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// FindInProgressAttempt returns the user's unsubmitted attempt, if any
+func (r *repository) FindInProgressAttempt(ctx context.Context, quizID, userID uint) (*Attempt, error) {
+	var attempt Attempt
+	if err := r.db.WithContext(ctx).
+		Where("quiz_id = ? AND user_id = ? AND status = ?", quizID, userID, AttemptStatusInProgress).
+		Order("started_at DESC").
+		First(&attempt).Error; err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *repository) FindAttemptsByUser(ctx context.Context, quizID, userID uint) ([]*Attempt, error) {
+	var attempts []*Attempt
+	if err := r.db.WithContext(ctx).
+		Where("quiz_id = ? AND user_id = ?", quizID, userID).
+		Order("started_at DESC").
+		Find(&attempts).Error; err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+// SaveAttemptResult stores the grading of a submitted attempt and its answers.
+// The status check makes concurrent double submissions a no-op for the loser.
+func (r *repository) SaveAttemptResult(ctx context.Context, attempt *Attempt) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Attempt{}).
+			Where("id = ? AND status = ?", attempt.ID, AttemptStatusInProgress).
+			Updates(map[string]interface{}{
+				"status":       attempt.Status,
+				"score":        attempt.Score,
+				"max_score":    attempt.MaxScore,
+				"percentage":   attempt.Percentage,
+				"passed":       attempt.Passed,
+				"submitted_at": attempt.SubmittedAt,
+			})
+		if result.Error != nil {
+			logger.Error("Failed to save quiz attempt result",
+				zap.Error(result.Error),
+				zap.Uint("attempt_id", attempt.ID),
+			)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAttemptAlreadySubmitted
+		}
+
+		for i := range attempt.Answers {
+			attempt.Answers[i].AttemptID = attempt.ID
+		}
+		if len(attempt.Answers) > 0 {
+			if err := tx.Create(&attempt.Answers).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,39 @@
+package quiz
+
+import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/middleware"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterRoutes(router *gin.RouterGroup, db *gorm.DB, authMiddleware *middleware.AuthMiddleware, notifier course.Notifier) {
+	// Initialize layers
+	repo := NewRepository(db)
+	courseRepo := course.NewRepository(db)
+	service := NewService(repo, courseRepo, course.NewService(courseRepo, notifier))
+	handler := NewHandler(service)
+
+	// All quiz routes require authentication
+	protected := router.Group("")
+	protected.Use(authMiddleware.RequireAuth())
+	{
+		// Quiz management (instructor only - authorization checked in service layer)
+		protected.POST("/courses/:id/quizzes", handler.CreateQuiz)       // Create quiz
+		protected.GET("/courses/:id/quizzes", handler.ListCourseQuizzes) // List course quizzes
+		protected.PATCH("/quizzes/:id", handler.UpdateQuiz)              // Update quiz settings
+		protected.DELETE("/quizzes/:id", handler.DeleteQuiz)             // Delete quiz
+
+		// Question bank (instructor only)
+		protected.POST("/quizzes/:id/questions", handler.AddQuestion)                  // Add question
+		protected.PUT("/quizzes/:id/questions/:questionId", handler.ReplaceQuestion)   // Replace question
+		protected.DELETE("/quizzes/:id/questions/:questionId", handler.DeleteQuestion) // Delete question
+
+		// Quiz taking (enrolled students; instructors can preview)
+		protected.GET("/quizzes/:id", handler.GetQuiz)                                   // Quiz detail (full bank for instructor)
+		protected.POST("/quizzes/:id/attempts", handler.StartAttempt)                    // Start or resume attempt
+		protected.GET("/quizzes/:id/attempts", handler.ListMyAttempts)                   // My attempts
+		protected.GET("/quizzes/:id/attempts/:attemptId", handler.GetAttempt)            // Attempt detail
+		protected.POST("/quizzes/:id/attempts/:attemptId/submit", handler.SubmitAttempt) // Submit and grade
+	}
+}
@@ -0,0 +1,796 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
+)
+
+var (
+	ErrQuizNotFound            = errors.New("quiz not found")
+	ErrQuestionNotFound        = errors.New("question not found")
+	ErrAttemptNotFound         = errors.New("attempt not found")
+	ErrCourseNotFound          = errors.New("course not found")
+	ErrUnauthorized            = errors.New("unauthorized access")
+	ErrNotEnrolled             = errors.New("not enrolled in this course")
+	ErrLessonLocked            = errors.New("lesson is not released yet")
+	ErrNoQuestions             = errors.New("quiz has no questions")
+	ErrAttemptLimitReached     = errors.New("maximum number of attempts reached")
+	ErrAttemptAlreadySubmitted = errors.New("attempt has already been submitted")
+	ErrInvalidChoiceOptions    = errors.New("choice questions need at least two options with a valid set of correct options")
+	ErrInvalidOptionID         = errors.New("option does not belong to this question")
+	ErrTrueFalseAnswerRequired = errors.New("true/false questions require correct_boolean")
+	ErrAcceptedAnswersRequired = errors.New("short answer questions require at least one accepted answer")
+)
+
+type Service interface {
+	// Quiz management (course instructor or admin)
+	CreateQuiz(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateQuizRequest) (*QuizResponse, error)
+	ListCourseQuizzes(ctx context.Context, userID uint, userRole string, courseID uint) ([]*QuizResponse, error)
+	UpdateQuiz(ctx context.Context, userID uint, userRole string, quizID uint, req *UpdateQuizRequest) (*QuizResponse, error)
+	DeleteQuiz(ctx context.Context, userID uint, userRole string, quizID uint) error
+
+	// Question bank management (course instructor or admin)
+	AddQuestion(ctx context.Context, userID uint, userRole string, quizID uint, req *QuestionRequest) (*QuestionResponse, error)
+	ReplaceQuestion(ctx context.Context, userID uint, userRole string, quizID uint, questionID uint, req *QuestionRequest) (*QuestionResponse, error)
+	DeleteQuestion(ctx context.Context, userID uint, userRole string, quizID uint, questionID uint) error
+
+	// Quiz taking
+	GetQuiz(ctx context.Context, userID uint, userRole string, quizID uint) (*QuizResponse, error)
+	StartAttempt(ctx context.Context, userID uint, userRole string, quizID uint) (*AttemptResponse, error)
+	SubmitAttempt(ctx context.Context, userID uint, quizID uint, attemptID uint, req *SubmitAttemptRequest) (*AttemptResponse, error)
+	GetAttempt(ctx context.Context, userID uint, quizID uint, attemptID uint) (*AttemptResponse, error)
+	ListMyAttempts(ctx context.Context, userID uint, quizID uint) ([]*AttemptResponse, error)
+}
+
+type service struct {
+	repo          Repository
+	courseRepo    course.Repository
+	courseService course.Service
+}
+
+func NewService(repo Repository, courseRepo course.Repository, courseService course.Service) Service {
+	return &service{
+		repo:          repo,
+		courseRepo:    courseRepo,
+		courseService: courseService,
+	}
+}
+
+// Helper: Verify the user may manage the course's quizzes (instructor, content collaborators or admin)
+func (s *service) checkCourseManager(ctx context.Context, userID uint, userRole string, courseID uint) error {
+	c, err := s.courseRepo.FindCourseByID(ctx, courseID)
+	if err != nil {
+		return ErrCourseNotFound
+	}
+	if c.InstructorID == userID || userRole == "admin" {
+		return nil
+	}
+	collaborator, err := s.courseRepo.FindCollaborator(ctx, courseID, userID)
+	if err != nil || !course.RoleAllows(collaborator.Role, course.PermissionEditContent) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// Helper: Load a quiz (with questions) the user may manage
+func (s *service) findManageableQuiz(ctx context.Context, userID uint, userRole string, quizID uint) (*Quiz, error) {
+	quiz, err := s.repo.FindQuizWithQuestions(ctx, quizID)
+	if err != nil {
+		return nil, ErrQuizNotFound
+	}
+	if err := s.checkCourseManager(ctx, userID, userRole, quiz.CourseID); err != nil {
+		return nil, err
+	}
+	return quiz, nil
+}
+
+// Helper: Verify the user may take the quiz: its managers (previews), or enrolled students
+// once a lesson using the quiz is released to them (same rules as course.Service.GetLesson)
+func (s *service) checkLearnerAccess(ctx context.Context, userID uint, userRole string, quiz *Quiz) error {
+	if s.checkCourseManager(ctx, userID, userRole, quiz.CourseID) == nil {
+		return nil
+	}
+	enrolled, err := s.courseRepo.IsUserEnrolled(ctx, userID, quiz.CourseID)
+	if err != nil {
+		return err
+	}
+	if !enrolled {
+		return ErrNotEnrolled
+	}
+
+	lessons, err := s.courseRepo.FindLessonsByCourseID(ctx, quiz.CourseID)
+	if err != nil {
+		return err
+	}
+	locked := false
+	for _, lesson := range lessons {
+		if lesson.QuizID == nil || *lesson.QuizID != quiz.ID {
+			continue
+		}
+		access, err := s.courseService.CheckLessonAccess(ctx, userID, lesson.ID)
+		if err == nil && access.IsParticipant() {
+			return nil
+		}
+		locked = true
+	}
+	if locked {
+		return ErrLessonLocked
+	}
+	return nil // Not used by a lesson: open to the course's students
+}
+
+// Quiz management
+
+func (s *service) CreateQuiz(ctx context.Context, userID uint, userRole string, courseID uint, req *CreateQuizRequest) (*QuizResponse, error) {
+	if err := s.checkCourseManager(ctx, userID, userRole, courseID); err != nil {
+		return nil, err
+	}
+
+	quiz := &Quiz{
+		CourseID:            courseID,
+		Title:               req.Title,
+		Description:         req.Description,
+		PassMark:            req.PassMark,
+		MaxAttempts:         req.MaxAttempts,
+		QuestionsPerAttempt: req.QuestionsPerAttempt,
+		ShuffleQuestions:    req.ShuffleQuestions == nil || *req.ShuffleQuestions,
+		ShuffleOptions:      req.ShuffleOptions == nil || *req.ShuffleOptions,
+		CreatedBy:           userID,
+	}
+
+	if err := s.repo.CreateQuiz(ctx, quiz); err != nil {
+		return nil, err
+	}
+
+	return toQuizResponse(quiz, true), nil
+}
+
+func (s *service) ListCourseQuizzes(ctx context.Context, userID uint, userRole string, courseID uint) ([]*QuizResponse, error) {
+	if err := s.checkCourseManager(ctx, userID, userRole, courseID); err != nil {
+		return nil, err
+	}
+
+	quizzes, err := s.repo.FindQuizzesByCourseID(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*QuizResponse, 0, len(quizzes))
+	for _, quiz := range quizzes {
+		resp := toQuizResponse(quiz, false)
+		if count, err := s.repo.CountQuestions(ctx, quiz.ID); err == nil {
+			resp.QuestionCount = count
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses, nil
+}
+
+func (s *service) UpdateQuiz(ctx context.Context, userID uint, userRole string, quizID uint, req *UpdateQuizRequest) (*QuizResponse, error) {
+	quiz, err := s.findManageableQuiz(ctx, userID, userRole, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		quiz.Title = *req.Title
+	}
+	if req.Description != nil {
+		quiz.Description = *req.Description
+	}
+	if req.PassMark != nil {
+		quiz.PassMark = *req.PassMark
+	}
+	if req.MaxAttempts != nil {
+		quiz.MaxAttempts = *req.MaxAttempts
+	}
+	if req.QuestionsPerAttempt != nil {
+		quiz.QuestionsPerAttempt = *req.QuestionsPerAttempt
+	}
+	if req.ShuffleQuestions != nil {
+		quiz.ShuffleQuestions = *req.ShuffleQuestions
+	}
+	if req.ShuffleOptions != nil {
+		quiz.ShuffleOptions = *req.ShuffleOptions
+	}
+
+	if err := s.repo.UpdateQuiz(ctx, quiz); err != nil {
+		return nil, err
+	}
+
+	return toQuizResponse(quiz, true), nil
+}
+
+func (s *service) DeleteQuiz(ctx context.Context, userID uint, userRole string, quizID uint) error {
+	if _, err := s.findManageableQuiz(ctx, userID, userRole, quizID); err != nil {
+		return err
+	}
+	return s.repo.DeleteQuiz(ctx, quizID)
+}
+
+// Question bank management
+
+// buildQuestion validates a question request and converts it to a Question
+func buildQuestion(req *QuestionRequest) (*Question, error) {
+	question := &Question{
+		Type:          req.Type,
+		Prompt:        req.Prompt,
+		Explanation:   req.Explanation,
+		Points:        req.Points,
+		OrderIndex:    req.OrderIndex,
+		CaseSensitive: req.CaseSensitive,
+	}
+	if question.Points == 0 {
+		question.Points = 1
+	}
+
+	switch req.Type {
+	case QuestionTypeSingleChoice, QuestionTypeMultipleChoice:
+		correct := 0
+		for _, option := range req.Options {
+			if option.IsCorrect {
+				correct++
+			}
+		}
+		if len(req.Options) < 2 || correct == 0 ||
+			(req.Type == QuestionTypeSingleChoice && correct != 1) {
+			return nil, ErrInvalidChoiceOptions
+		}
+		for i, option := range req.Options {
+			question.Options = append(question.Options, QuestionOption{
+				Text:       option.Text,
+				IsCorrect:  option.IsCorrect,
+				OrderIndex: i,
+			})
+		}
+	case QuestionTypeTrueFalse:
+		if req.CorrectBoolean == nil {
+			return nil, ErrTrueFalseAnswerRequired
+		}
+		question.Options = []QuestionOption{
+			{Text: "True", IsCorrect: *req.CorrectBoolean, OrderIndex: 0},
+			{Text: "False", IsCorrect: !*req.CorrectBoolean, OrderIndex: 1},
+		}
+	case QuestionTypeShortAnswer:
+		for _, answer := range req.AcceptedAnswers {
+			if strings.TrimSpace(answer) != "" {
+				question.AcceptedAnswers = append(question.AcceptedAnswers, strings.TrimSpace(answer))
+			}
+		}
+		if len(question.AcceptedAnswers) == 0 {
+			return nil, ErrAcceptedAnswersRequired
+		}
+	}
+
+	return question, nil
+}
+
+func (s *service) AddQuestion(ctx context.Context, userID uint, userRole string, quizID uint, req *QuestionRequest) (*QuestionResponse, error) {
+	if _, err := s.findManageableQuiz(ctx, userID, userRole, quizID); err != nil {
+		return nil, err
+	}
+
+	question, err := buildQuestion(req)
+	if err != nil {
+		return nil, err
+	}
+	question.QuizID = quizID
+
+	if err := s.repo.CreateQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+
+	return toQuestionResponse(question, true), nil
+}
+
+func (s *service) ReplaceQuestion(ctx context.Context, userID uint, userRole string, quizID uint, questionID uint, req *QuestionRequest) (*QuestionResponse, error) {
+	if _, err := s.findManageableQuiz(ctx, userID, userRole, quizID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindQuestionByID(ctx, questionID)
+	if err != nil || existing.QuizID != quizID {
+		return nil, ErrQuestionNotFound
+	}
+
+	question, err := buildQuestion(req)
+	if err != nil {
+		return nil, err
+	}
+	question.ID = existing.ID
+	question.QuizID = quizID
+	question.CreatedAt = existing.CreatedAt
+	if err := keepOptionIDs(question, existing, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReplaceQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+
+	return toQuestionResponse(question, true), nil
+}
+
+// Helper: Carry the IDs of edited options over to the replacement question, so attempts in
+// progress can still submit them and past answers keep pointing at the same options.
+// Choice options are matched by the ID sent with them; generated true/false options by position.
+func keepOptionIDs(question *Question, existing *Question, req *QuestionRequest) error {
+	owned := make(map[uint]bool, len(existing.Options))
+	for _, option := range existing.Options {
+		owned[option.ID] = true
+	}
+
+	switch question.Type {
+	case QuestionTypeSingleChoice, QuestionTypeMultipleChoice:
+		for i, input := range req.Options {
+			if input.ID == 0 {
+				continue
+			}
+			if !owned[input.ID] {
+				return ErrInvalidOptionID
+			}
+			delete(owned, input.ID) // Each existing option is kept at most once
+			question.Options[i].ID = input.ID
+		}
+	case QuestionTypeTrueFalse:
+		if existing.Type == QuestionTypeTrueFalse && len(existing.Options) == len(question.Options) {
+			for i := range question.Options {
+				question.Options[i].ID = existing.Options[i].ID
+			}
+		}
+	}
+	return nil
+}
+
+func (s *service) DeleteQuestion(ctx context.Context, userID uint, userRole string, quizID uint, questionID uint) error {
+	if _, err := s.findManageableQuiz(ctx, userID, userRole, quizID); err != nil {
+		return err
+	}
+
+	question, err := s.repo.FindQuestionByID(ctx, questionID)
+	if err != nil || question.QuizID != quizID {
+		return ErrQuestionNotFound
+	}
+
+	return s.repo.DeleteQuestion(ctx, questionID)
+}
+
+// Quiz taking
+
+// GetQuiz returns the full question bank to course managers and
+// a summary with the caller's attempt statistics to learners
+func (s *service) GetQuiz(ctx context.Context, userID uint, userRole string, quizID uint) (*QuizResponse, error) {
+	quiz, err := s.repo.FindQuizWithQuestions(ctx, quizID)
+	if err != nil {
+		return nil, ErrQuizNotFound
+	}
+
+	if s.checkCourseManager(ctx, userID, userRole, quiz.CourseID) == nil {
+		return toQuizResponse(quiz, true), nil
+	}
+
+	if err := s.checkLearnerAccess(ctx, userID, userRole, quiz); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.repo.FindAttemptsByUser(ctx, quizID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toQuizResponse(quiz, false)
+	used := len(attempts)
+	resp.AttemptsUsed = &used
+	if quiz.MaxAttempts > 0 {
+		remaining := quiz.MaxAttempts - used
+		if remaining < 0 {
+			remaining = 0
+		}
+		resp.AttemptsRemaining = &remaining
+	}
+
+	passed := false
+	for _, attempt := range attempts {
+		if attempt.Status != AttemptStatusSubmitted {
+			continue
+		}
+		if resp.BestPercentage == nil || attempt.Percentage > *resp.BestPercentage {
+			best := attempt.Percentage
+			resp.BestPercentage = &best
+		}
+		passed = passed || attempt.Passed
+	}
+	resp.Passed = &passed
+
+	return resp, nil
+}
+
+// StartAttempt starts a new attempt, or resumes the caller's unsubmitted one
+func (s *service) StartAttempt(ctx context.Context, userID uint, userRole string, quizID uint) (*AttemptResponse, error) {
+	quiz, err := s.repo.FindQuizWithQuestions(ctx, quizID)
+	if err != nil {
+		return nil, ErrQuizNotFound
+	}
+
+	if err := s.checkLearnerAccess(ctx, userID, userRole, quiz); err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.repo.FindInProgressAttempt(ctx, quizID, userID); err == nil {
+		return buildAttemptResponse(quiz, existing, false), nil
+	}
+
+	if len(quiz.Questions) == 0 {
+		return nil, ErrNoQuestions
+	}
+
+	attempt := &Attempt{
+		QuizID:      quizID,
+		UserID:      userID,
+		QuestionIDs: pickQuestions(quiz, rand.New(rand.NewSource(time.Now().UnixNano()))),
+		Status:      AttemptStatusInProgress,
+		StartedAt:   time.Now(),
+	}
+
+	// Checked again with the insert: a parallel start may have used the last attempt or be in progress
+	started, err := s.repo.CreateAttemptWithinLimit(ctx, attempt, quiz.MaxAttempts)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAttemptResponse(quiz, started, false), nil
+}
+
+// SubmitAttempt grades the answers of an in-progress attempt
+func (s *service) SubmitAttempt(ctx context.Context, userID uint, quizID uint, attemptID uint, req *SubmitAttemptRequest) (*AttemptResponse, error) {
+	quiz, err := s.repo.FindQuizWithQuestions(ctx, quizID)
+	if err != nil {
+		return nil, ErrQuizNotFound
+	}
+
+	attempt, err := s.repo.FindAttemptByID(ctx, attemptID)
+	if err != nil || attempt.QuizID != quizID || attempt.UserID != userID {
+		return nil, ErrAttemptNotFound
+	}
+	if attempt.Status == AttemptStatusSubmitted {
+		return nil, ErrAttemptAlreadySubmitted
+	}
+
+	gradeAttempt(quiz, attempt, req.Answers)
+
+	if err := s.repo.SaveAttemptResult(ctx, attempt); err != nil {
+		return nil, err
+	}
+
+	reveal, err := s.answersRevealed(ctx, quiz, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAttemptResponse(quiz, attempt, reveal), nil
+}
+
+func (s *service) GetAttempt(ctx context.Context, userID uint, quizID uint, attemptID uint) (*AttemptResponse, error) {
+	quiz, err := s.repo.FindQuizWithQuestions(ctx, quizID)
+	if err != nil {
+		return nil, ErrQuizNotFound
+	}
+
+	attempt, err := s.repo.FindAttemptByID(ctx, attemptID)
+	if err != nil || attempt.QuizID != quizID || attempt.UserID != userID {
+		return nil, ErrAttemptNotFound
+	}
+
+	reveal := false
+	if attempt.Status == AttemptStatusSubmitted {
+		if reveal, err = s.answersRevealed(ctx, quiz, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return buildAttemptResponse(quiz, attempt, reveal), nil
+}
+
+// ListMyAttempts lists the caller's attempts (scores only), newest first
+func (s *service) ListMyAttempts(ctx context.Context, userID uint, quizID uint) ([]*AttemptResponse, error) {
+	quiz, err := s.repo.FindQuizByID(ctx, quizID)
+	if err != nil {
+		return nil, ErrQuizNotFound
+	}
+
+	attempts, err := s.repo.FindAttemptsByUser(ctx, quizID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*AttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		responses = append(responses, &AttemptResponse{
+			ID:          attempt.ID,
+			QuizID:      attempt.QuizID,
+			Status:      attempt.Status,
+			Score:       attempt.Score,
+			MaxScore:    attempt.MaxScore,
+			Percentage:  attempt.Percentage,
+			PassMark:    quiz.PassMark,
+			Passed:      attempt.Passed,
+			StartedAt:   attempt.StartedAt,
+			SubmittedAt: attempt.SubmittedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+// answersRevealed reports whether correct answers may be shown to the user:
+// once they passed, or when they have no attempts left
+func (s *service) answersRevealed(ctx context.Context, quiz *Quiz, userID uint) (bool, error) {
+	attempts, err := s.repo.FindAttemptsByUser(ctx, quiz.ID, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, attempt := range attempts {
+		if attempt.Passed {
+			return true, nil
+		}
+	}
+	return quiz.MaxAttempts > 0 && len(attempts) >= quiz.MaxAttempts, nil
+}
+
+// Grading helpers
+
+// pickQuestions selects the questions of a new attempt from the question bank.
+// A subset (QuestionsPerAttempt) is always drawn at random; the order is only
+// randomized when ShuffleQuestions is set.
+func pickQuestions(quiz *Quiz, rng *rand.Rand) []uint {
+	indices := make([]int, len(quiz.Questions))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	count := len(indices)
+	if quiz.QuestionsPerAttempt > 0 && quiz.QuestionsPerAttempt < count {
+		count = quiz.QuestionsPerAttempt
+	}
+	if quiz.ShuffleQuestions || count < len(indices) {
+		rng.Shuffle(len(indices), func(i, j int) {
+			indices[i], indices[j] = indices[j], indices[i]
+		})
+	}
+
+	selected := indices[:count]
+	if !quiz.ShuffleQuestions {
+		sort.Ints(selected)
+	}
+
+	ids := make([]uint, 0, count)
+	for _, i := range selected {
+		ids = append(ids, quiz.Questions[i].ID)
+	}
+	return ids
+}
+
+// normalizeAnswer trims and collapses whitespace for exact-match comparison
+func normalizeAnswer(answer string, caseSensitive bool) string {
+	answer = strings.Join(strings.Fields(answer), " ")
+	if !caseSensitive {
+		answer = strings.ToLower(answer)
+	}
+	return answer
+}
+
+// gradeAnswer reports whether an answer is fully correct (no partial credit)
+func gradeAnswer(question *Question, answer *AnswerInput) bool {
+	if answer == nil {
+		return false
+	}
+
+	if question.Type == QuestionTypeShortAnswer {
+		given := normalizeAnswer(answer.Text, question.CaseSensitive)
+		if given == "" {
+			return false
+		}
+		for _, accepted := range question.AcceptedAnswers {
+			if normalizeAnswer(accepted, question.CaseSensitive) == given {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Choice questions: the selected set must equal the set of correct options
+	selected := make(map[uint]bool, len(answer.OptionIDs))
+	for _, id := range answer.OptionIDs {
+		selected[id] = true
+	}
+	if question.Type != QuestionTypeMultipleChoice && len(selected) != 1 {
+		return false
+	}
+
+	matched := 0
+	for _, option := range question.Options {
+		if option.IsCorrect != selected[option.ID] {
+			return false
+		}
+		if option.IsCorrect {
+			matched++
+		}
+	}
+	return matched == len(selected)
+}
+
+// gradeAttempt grades the answers against the attempt's questions and fills in the result.
+// Questions removed from the bank after the attempt started are not counted.
+func gradeAttempt(quiz *Quiz, attempt *Attempt, answers []AnswerInput) {
+	questions := make(map[uint]*Question, len(quiz.Questions))
+	for i := range quiz.Questions {
+		questions[quiz.Questions[i].ID] = &quiz.Questions[i]
+	}
+	given := make(map[uint]*AnswerInput, len(answers))
+	for i := range answers {
+		given[answers[i].QuestionID] = &answers[i]
+	}
+
+	attempt.Score = 0
+	attempt.MaxScore = 0
+	attempt.Answers = make([]AttemptAnswer, 0, len(attempt.QuestionIDs))
+	for _, questionID := range attempt.QuestionIDs {
+		question, ok := questions[questionID]
+		if !ok {
+			continue
+		}
+
+		graded := AttemptAnswer{QuestionID: questionID}
+		if answer := given[questionID]; answer != nil {
+			graded.SelectedOptionIDs = answer.OptionIDs
+			graded.TextAnswer = answer.Text
+		}
+		if gradeAnswer(question, given[questionID]) {
+			graded.IsCorrect = true
+			graded.PointsAwarded = question.Points
+		}
+
+		attempt.MaxScore += question.Points
+		attempt.Score += graded.PointsAwarded
+		attempt.Answers = append(attempt.Answers, graded)
+	}
+
+	attempt.Percentage = 0
+	if attempt.MaxScore > 0 {
+		attempt.Percentage = attempt.Score * 100 / attempt.MaxScore
+	}
+	attempt.Passed = attempt.Percentage >= quiz.PassMark
+
+	now := time.Now()
+	attempt.Status = AttemptStatusSubmitted
+	attempt.SubmittedAt = &now
+}
+
+// Response helpers
+
+func toQuizResponse(quiz *Quiz, includeQuestions bool) *QuizResponse {
+	resp := &QuizResponse{
+		ID:                  quiz.ID,
+		CourseID:            quiz.CourseID,
+		Title:               quiz.Title,
+		Description:         quiz.Description,
+		PassMark:            quiz.PassMark,
+		MaxAttempts:         quiz.MaxAttempts,
+		QuestionsPerAttempt: quiz.QuestionsPerAttempt,
+		ShuffleQuestions:    quiz.ShuffleQuestions,
+		ShuffleOptions:      quiz.ShuffleOptions,
+		QuestionCount:       len(quiz.Questions),
+		CreatedAt:           quiz.CreatedAt,
+	}
+
+	if includeQuestions {
+		resp.Questions = make([]*QuestionResponse, 0, len(quiz.Questions))
+		for i := range quiz.Questions {
+			resp.Questions = append(resp.Questions, toQuestionResponse(&quiz.Questions[i], true))
+		}
+	}
+
+	return resp
+}
+
+// toQuestionResponse converts a question; answers are only included when revealed
+func toQuestionResponse(question *Question, reveal bool) *QuestionResponse {
+	resp := &QuestionResponse{
+		ID:         question.ID,
+		Type:       question.Type,
+		Prompt:     question.Prompt,
+		Points:     question.Points,
+		OrderIndex: question.OrderIndex,
+		Options:    make([]*OptionResponse, 0, len(question.Options)),
+	}
+
+	for _, option := range question.Options {
+		optionResp := &OptionResponse{ID: option.ID, Text: option.Text}
+		if reveal {
+			isCorrect := option.IsCorrect
+			optionResp.IsCorrect = &isCorrect
+		}
+		resp.Options = append(resp.Options, optionResp)
+	}
+
+	if reveal {
+		resp.Explanation = question.Explanation
+		resp.AcceptedAnswers = question.AcceptedAnswers
+		resp.CaseSensitive = question.CaseSensitive
+	}
+
+	return resp
+}
+
+// buildAttemptResponse renders an attempt's questions in the order they were served.
+// Option order is shuffled with a seed derived from the attempt so it stays stable.
+func buildAttemptResponse(quiz *Quiz, attempt *Attempt, reveal bool) *AttemptResponse {
+	resp := &AttemptResponse{
+		ID:              attempt.ID,
+		QuizID:          attempt.QuizID,
+		Status:          attempt.Status,
+		Score:           attempt.Score,
+		MaxScore:        attempt.MaxScore,
+		Percentage:      attempt.Percentage,
+		PassMark:        quiz.PassMark,
+		Passed:          attempt.Passed,
+		AnswersRevealed: reveal,
+		StartedAt:       attempt.StartedAt,
+		SubmittedAt:     attempt.SubmittedAt,
+		Questions:       make([]*QuestionResponse, 0, len(attempt.QuestionIDs)),
+	}
+
+	questions := make(map[uint]*Question, len(quiz.Questions))
+	for i := range quiz.Questions {
+		questions[quiz.Questions[i].ID] = &quiz.Questions[i]
+	}
+
+	rng := rand.New(rand.NewSource(int64(attempt.ID)))
+	for _, questionID := range attempt.QuestionIDs {
+		question, ok := questions[questionID]
+		if !ok {
+			continue
+		}
+		questionResp := toQuestionResponse(question, reveal)
+		if quiz.ShuffleOptions && question.Type != QuestionTypeTrueFalse {
+			rng.Shuffle(len(questionResp.Options), func(i, j int) {
+				questionResp.Options[i], questionResp.Options[j] = questionResp.Options[j], questionResp.Options[i]
+			})
+		}
+		resp.Questions = append(resp.Questions, questionResp)
+	}
+
+	if attempt.Status != AttemptStatusSubmitted {
+		return resp
+	}
+
+	resp.Results = make([]*AnswerResult, 0, len(attempt.Answers))
+	for _, answer := range attempt.Answers {
+		result := &AnswerResult{
+			QuestionID:        answer.QuestionID,
+			IsCorrect:         answer.IsCorrect,
+			PointsAwarded:     answer.PointsAwarded,
+			SelectedOptionIDs: answer.SelectedOptionIDs,
+			TextAnswer:        answer.TextAnswer,
+		}
+		if question, ok := questions[answer.QuestionID]; ok && reveal {
+			for _, option := range question.Options {
+				if option.IsCorrect {
+					result.CorrectOptionIDs = append(result.CorrectOptionIDs, option.ID)
+				}
+			}
+			result.AcceptedAnswers = question.AcceptedAnswers
+			result.Explanation = question.Explanation
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp
+}
@@ -0,0 +1,145 @@
+package quiz
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGradeAnswer tests auto-grading of each question type
+func TestGradeAnswer(t *testing.T) {
+	single := &Question{Type: QuestionTypeSingleChoice, Options: []QuestionOption{
+		{ID: 1, IsCorrect: true}, {ID: 2}, {ID: 3},
+	}}
+	multiple := &Question{Type: QuestionTypeMultipleChoice, Options: []QuestionOption{
+		{ID: 1, IsCorrect: true}, {ID: 2, IsCorrect: true}, {ID: 3},
+	}}
+	short := &Question{Type: QuestionTypeShortAnswer, AcceptedAnswers: []string{"Go Routine", "goroutine"}}
+	shortCase := &Question{Type: QuestionTypeShortAnswer, AcceptedAnswers: []string{"HTTP"}, CaseSensitive: true}
+
+	tests := []struct {
+		name     string
+		question *Question
+		answer   *AnswerInput
+		expected bool
+	}{
+		{"Single correct", single, &AnswerInput{OptionIDs: []uint{1}}, true},
+		{"Single wrong", single, &AnswerInput{OptionIDs: []uint{2}}, false},
+		{"Single with extra selection", single, &AnswerInput{OptionIDs: []uint{1, 2}}, false},
+		{"Multiple all correct", multiple, &AnswerInput{OptionIDs: []uint{2, 1}}, true},
+		{"Multiple partial", multiple, &AnswerInput{OptionIDs: []uint{1}}, false},
+		{"Multiple with wrong option", multiple, &AnswerInput{OptionIDs: []uint{1, 2, 3}}, false},
+		{"Multiple unknown option", multiple, &AnswerInput{OptionIDs: []uint{1, 2, 99}}, false},
+		{"Short answer normalized", short, &AnswerInput{Text: "  go   routine "}, true},
+		{"Short answer case insensitive", short, &AnswerInput{Text: "GOROUTINE"}, true},
+		{"Short answer wrong", short, &AnswerInput{Text: "thread"}, false},
+		{"Short answer empty", short, &AnswerInput{Text: "  "}, false},
+		{"Short answer case sensitive", shortCase, &AnswerInput{Text: "http"}, false},
+		{"No answer", single, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, gradeAnswer(tt.question, tt.answer))
+		})
+	}
+}
+
+// TestGradeAttempt tests scoring and the pass mark
+func TestGradeAttempt(t *testing.T) {
+	quiz := &Quiz{PassMark: 60, Questions: []Question{
+		{ID: 1, Type: QuestionTypeTrueFalse, Points: 1, Options: []QuestionOption{{ID: 11, IsCorrect: true}, {ID: 12}}},
+		{ID: 2, Type: QuestionTypeShortAnswer, Points: 2, AcceptedAnswers: []string{"gin"}},
+	}}
+	attempt := &Attempt{QuestionIDs: []uint{2, 1, 3}} // 3 was removed from the bank
+
+	gradeAttempt(quiz, attempt, []AnswerInput{
+		{QuestionID: 1, OptionIDs: []uint{12}},
+		{QuestionID: 2, Text: "Gin"},
+	})
+
+	assert.Equal(t, 2, attempt.Score)
+	assert.Equal(t, 3, attempt.MaxScore)
+	assert.Equal(t, 66, attempt.Percentage)
+	assert.True(t, attempt.Passed)
+	assert.Equal(t, AttemptStatusSubmitted, attempt.Status)
+	assert.NotNil(t, attempt.SubmittedAt)
+	assert.Len(t, attempt.Answers, 2)
+}
+
+// TestPickQuestions tests drawing questions from the bank
+func TestPickQuestions(t *testing.T) {
+	quiz := &Quiz{Questions: []Question{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}}
+	rng := rand.New(rand.NewSource(1))
+
+	// Whole bank, original order
+	assert.Equal(t, []uint{1, 2, 3, 4, 5}, pickQuestions(quiz, rng))
+
+	// Subset keeps bank order when not shuffling
+	quiz.QuestionsPerAttempt = 3
+	picked := pickQuestions(quiz, rng)
+	assert.Len(t, picked, 3)
+	assert.IsIncreasing(t, picked)
+
+	// Shuffled whole bank contains every question once
+	quiz.QuestionsPerAttempt = 0
+	quiz.ShuffleQuestions = true
+	assert.ElementsMatch(t, []uint{1, 2, 3, 4, 5}, pickQuestions(quiz, rng))
+}
+
+// TestBuildQuestion tests question validation per type
+func TestBuildQuestion(t *testing.T) {
+	yes := true
+
+	_, err := buildQuestion(&QuestionRequest{Type: QuestionTypeSingleChoice, Options: []OptionInput{
+		{Text: "A", IsCorrect: true}, {Text: "B", IsCorrect: true},
+	}})
+	assert.Equal(t, ErrInvalidChoiceOptions, err)
+
+	_, err = buildQuestion(&QuestionRequest{Type: QuestionTypeMultipleChoice, Options: []OptionInput{{Text: "A", IsCorrect: true}}})
+	assert.Equal(t, ErrInvalidChoiceOptions, err)
+
+	_, err = buildQuestion(&QuestionRequest{Type: QuestionTypeTrueFalse})
+	assert.Equal(t, ErrTrueFalseAnswerRequired, err)
+
+	_, err = buildQuestion(&QuestionRequest{Type: QuestionTypeShortAnswer, AcceptedAnswers: []string{" "}})
+	assert.Equal(t, ErrAcceptedAnswersRequired, err)
+
+	question, err := buildQuestion(&QuestionRequest{Type: QuestionTypeTrueFalse, CorrectBoolean: &yes})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, question.Points)
+	assert.Len(t, question.Options, 2)
+	assert.True(t, question.Options[0].IsCorrect)
+}
+
+// TestKeepOptionIDs tests that replacing a question keeps the IDs of the options it edits
+func TestKeepOptionIDs(t *testing.T) {
+	existing := &Question{Type: QuestionTypeSingleChoice, Options: []QuestionOption{{ID: 11}, {ID: 12}, {ID: 13}}}
+
+	req := &QuestionRequest{Type: QuestionTypeSingleChoice, Options: []OptionInput{
+		{ID: 12, Text: "B", IsCorrect: true}, {Text: "New"}, {ID: 11, Text: "A"},
+	}}
+	question, err := buildQuestion(req)
+	assert.NoError(t, err)
+	assert.NoError(t, keepOptionIDs(question, existing, req))
+	assert.Equal(t, []uint{12, 0, 11}, []uint{question.Options[0].ID, question.Options[1].ID, question.Options[2].ID})
+
+	// Options of another question, or the same option twice, are rejected
+	for _, ids := range [][2]uint{{11, 99}, {11, 11}} {
+		req = &QuestionRequest{Type: QuestionTypeSingleChoice, Options: []OptionInput{
+			{ID: ids[0], Text: "A", IsCorrect: true}, {ID: ids[1], Text: "B"},
+		}}
+		question, _ = buildQuestion(req)
+		assert.Equal(t, ErrInvalidOptionID, keepOptionIDs(question, existing, req))
+	}
+
+	// True/false options keep their IDs by position
+	yes := true
+	existing = &Question{Type: QuestionTypeTrueFalse, Options: []QuestionOption{{ID: 21}, {ID: 22}}}
+	req = &QuestionRequest{Type: QuestionTypeTrueFalse, CorrectBoolean: &yes}
+	question, _ = buildQuestion(req)
+	assert.NoError(t, keepOptionIDs(question, existing, req))
+	assert.Equal(t, uint(21), question.Options[0].ID)
+	assert.Equal(t, uint(22), question.Options[1].ID)
+}
@@ -44,9 +44,12 @@ type ReviewListResponse struct {
 }
 
 // PaginationMeta represents pagination information
+// Total and TotalPages are only counted for page-based requests (0 when following a cursor).
 type PaginationMeta struct {
-	Page        int `json:"page"`
-	Limit       int `json:"limit"`
-	Total       int `json:"total"`
-	TotalPages  int `json:"total_pages"`
+	Page        int    `json:"page"`
+	Limit       int    `json:"limit"`
+	Total       int    `json:"total"`
+	TotalPages  int    `json:"total_pages"`
+	NextCursor  string `json:"next_cursor,omitempty"` // Pass as cursor to fetch the next page
+	HasMore     bool   `json:"has_more"`
 }
\ No newline at end of file
@@ -1,9 +1,11 @@
 package review
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"github.com/gin-gonic/gin"
 )
 
@@ -175,8 +177,14 @@ func (h *ReviewHandler) GetCourseReviews(c *gin.Context) {
 		limit = 10
 	}
 
-	result, err := h.service.GetCourseReviews(uint(courseID), page, limit)
+	result, err := h.service.GetCourseReviews(uint(courseID), page, limit, c.Query("cursor"))
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve reviews",
 		})
@@ -214,8 +222,14 @@ func (h *ReviewHandler) GetUserReviews(c *gin.Context) {
 		limit = 10
 	}
 
-	reviews, total, err := h.service.GetUserReviews(userID.(uint), page, limit)
+	reviews, total, nextCursor, err := h.service.GetUserReviews(userID.(uint), page, limit, c.Query("cursor"))
 	if err != nil {
+		if errors.Is(err, cursor.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve user reviews",
 		})
@@ -232,6 +246,8 @@ func (h *ReviewHandler) GetUserReviews(c *gin.Context) {
 			"limit":       limit,
 			"total":       total,
 			"total_pages": totalPages,
+			"next_cursor": nextCursor,
+			"has_more":    nextCursor != "",
 		},
 	})
 }
@@ -1,7 +1,9 @@
 package review
 
 import (
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ReviewRepository interface {
@@ -10,10 +12,11 @@ type ReviewRepository interface {
 	Delete(id uint, userID uint) error
 	FindByID(id uint) (*CourseReview, error)
 	FindByUserAndCourse(userID, courseID uint) (*CourseReview, error)
-	FindByCourseID(courseID uint, page, limit int) ([]CourseReview, int, error)
-	FindByUserID(userID uint, page, limit int) ([]CourseReview, int, error)
+	FindByCourseID(courseID uint, page, limit int, after string) ([]CourseReview, int, string, error)
+	FindByUserID(userID uint, page, limit int, after string) ([]CourseReview, int, string, error)
 	GetCourseReviewSummary(courseID uint) (*CourseReviewSummary, error)
 	Exists(userID, courseID uint) (bool, error)
+	RecalculateAllCourseRatings() (int64, error)
 }
 
 type reviewRepository struct {
@@ -24,16 +27,69 @@ func NewRepository(db *gorm.DB) ReviewRepository {
 	return &reviewRepository{db: db}
 }
 
+// refreshCourseRating recomputes a course's average_rating and review_count from its reviews.
+// It runs in the transaction that changed a review; the course row lock serializes concurrent
+// reviews of the same course so neither overwrites the other's aggregate.
+func refreshCourseRating(tx *gorm.DB, courseID uint) error {
+	var locked struct{ ID uint }
+	if err := tx.Table("courses").Select("id").Where("id = ?", courseID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).Scan(&locked).Error; err != nil {
+		return err
+	}
+	return tx.Exec(`
+		UPDATE courses SET
+			average_rating = (SELECT COALESCE(ROUND(AVG(rating), 2), 0) FROM course_reviews WHERE course_id = ?),
+			review_count = (SELECT COUNT(*) FROM course_reviews WHERE course_id = ?)
+		WHERE id = ?
+	`, courseID, courseID, courseID).Error
+}
+
 func (r *reviewRepository) Create(review *CourseReview) error {
-	return r.db.Create(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return refreshCourseRating(tx, review.CourseID)
+	})
 }
 
 func (r *reviewRepository) Update(review *CourseReview) error {
-	return r.db.Save(review).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return refreshCourseRating(tx, review.CourseID)
+	})
 }
 
 func (r *reviewRepository) Delete(id uint, userID uint) error {
-	return r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&CourseReview{}).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var review CourseReview
+		if err := tx.Select("id", "course_id").Where("id = ? AND user_id = ?", id, userID).
+			First(&review).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+		return refreshCourseRating(tx, review.CourseID)
+	})
+}
+
+// RecalculateAllCourseRatings rebuilds average_rating and review_count of every course from
+// course_reviews (backfill / repair). Returns the number of courses whose values changed.
+func (r *reviewRepository) RecalculateAllCourseRatings() (int64, error) {
+	result := r.db.Exec(`
+		UPDATE courses
+		LEFT JOIN (
+			SELECT course_id, ROUND(AVG(rating), 2) AS average_rating, COUNT(*) AS review_count
+			FROM course_reviews
+			GROUP BY course_id
+		) AS stats ON stats.course_id = courses.id
+		SET courses.average_rating = COALESCE(stats.average_rating, 0),
+			courses.review_count = COALESCE(stats.review_count, 0)
+	`)
+	return result.RowsAffected, result.Error
 }
 
 func (r *reviewRepository) FindByID(id uint) (*CourseReview, error) {
@@ -55,48 +111,48 @@ func (r *reviewRepository) FindByUserAndCourse(userID, courseID uint) (*CourseRe
 	return &review, nil
 }
 
-func (r *reviewRepository) FindByCourseID(courseID uint, page, limit int) ([]CourseReview, int, error) {
+// reviewKeys is the keyset of review lists: newest first
+var reviewKeys = []cursor.Key{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+
+// findPage reads one page of the reviews matching db with the given relation loaded, by keyset
+// after the cursor when one is given (total is not counted then) or by page number otherwise.
+// It also returns the next page cursor.
+func findPage(db *gorm.DB, preload string, page, limit int, after string) ([]CourseReview, int, string, error) {
 	var reviews []CourseReview
 	var total int64
 
-	offset := (page - 1) * limit
-
-	// Get total count
-	if err := r.db.Model(&CourseReview{}).Where("course_id = ?", courseID).Count(&total).Error; err != nil {
-		return nil, 0, err
+	if after != "" {
+		values, err := cursor.Decode(after, len(reviewKeys))
+		if err != nil {
+			return nil, 0, "", err
+		}
+		condition, args := cursor.Condition(reviewKeys, values)
+		db = db.Where(condition, args...)
+	} else {
+		// Get total count
+		if err := db.Count(&total).Error; err != nil {
+			return nil, 0, "", err
+		}
+		db = db.Offset((page - 1) * limit)
 	}
 
-	// Get paginated results
-	err := r.db.Where("course_id = ?", courseID).
-		Preload("User").
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
-		Find(&reviews).Error
+	// Get paginated results, plus one row to tell whether a next page exists
+	if err := db.Preload(preload).Order(cursor.OrderClause(reviewKeys)).Limit(limit + 1).Find(&reviews).Error; err != nil {
+		return nil, 0, "", err
+	}
 
-	return reviews, int(total), err
+	reviews, next := cursor.Next(reviews, limit, func(review CourseReview) []interface{} {
+		return []interface{}{review.CreatedAt, review.ID}
+	})
+	return reviews, int(total), next, nil
 }
 
-func (r *reviewRepository) FindByUserID(userID uint, page, limit int) ([]CourseReview, int, error) {
-	var reviews []CourseReview
-	var total int64
-
-	offset := (page - 1) * limit
-
-	// Get total count
-	if err := r.db.Model(&CourseReview{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Get paginated results
-	err := r.db.Where("user_id = ?", userID).
-		Preload("Course").
-		Order("created_at DESC").
-		Offset(offset).
-		Limit(limit).
-		Find(&reviews).Error
+func (r *reviewRepository) FindByCourseID(courseID uint, page, limit int, after string) ([]CourseReview, int, string, error) {
+	return findPage(r.db.Model(&CourseReview{}).Where("course_id = ?", courseID).Session(&gorm.Session{}), "User", page, limit, after)
+}
 
-	return reviews, int(total), err
+func (r *reviewRepository) FindByUserID(userID uint, page, limit int, after string) ([]CourseReview, int, string, error) {
+	return findPage(r.db.Model(&CourseReview{}).Where("user_id = ?", userID).Session(&gorm.Session{}), "Course", page, limit, after)
 }
 
 func (r *reviewRepository) GetCourseReviewSummary(courseID uint) (*CourseReviewSummary, error) {
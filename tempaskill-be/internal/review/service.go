@@ -10,8 +10,8 @@ type ReviewService interface {
 	UpdateReview(userID uint, reviewID uint, req UpdateReviewRequest) (*ReviewResponse, error)
 	DeleteReview(userID uint, reviewID uint) error
 	GetReview(reviewID uint) (*ReviewResponse, error)
-	GetCourseReviews(courseID uint, page, limit int) (*ReviewListResponse, error)
-	GetUserReviews(userID uint, page, limit int) ([]ReviewResponse, int, error)
+	GetCourseReviews(courseID uint, page, limit int, after string) (*ReviewListResponse, error)
+	GetUserReviews(userID uint, page, limit int, after string) ([]ReviewResponse, int, string, error)
 	GetCourseReviewSummary(courseID uint) (*CourseReviewSummary, error)
 	CanUserReviewCourse(userID, courseID uint) (bool, error)
 }
@@ -116,7 +116,9 @@ func (s *reviewService) GetReview(reviewID uint) (*ReviewResponse, error) {
 	return s.convertToResponse(review), nil
 }
 
-func (s *reviewService) GetCourseReviews(courseID uint, page, limit int) (*ReviewListResponse, error) {
+// GetCourseReviews returns a page of a course's reviews, newest first. after is the
+// next_cursor of the previous page; when set it replaces page.
+func (s *reviewService) GetCourseReviews(courseID uint, page, limit int, after string) (*ReviewListResponse, error) {
 	// Validate pagination parameters
 	if page < 1 {
 		page = 1
@@ -126,7 +128,7 @@ func (s *reviewService) GetCourseReviews(courseID uint, page, limit int) (*Revie
 	}
 
 	// Get reviews
-	reviews, total, err := s.repo.FindByCourseID(courseID, page, limit)
+	reviews, total, nextCursor, err := s.repo.FindByCourseID(courseID, page, limit, after)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get course reviews: %w", err)
 	}
@@ -154,11 +156,13 @@ func (s *reviewService) GetCourseReviews(courseID uint, page, limit int) (*Revie
 			Limit:      limit,
 			Total:      total,
 			TotalPages: totalPages,
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
 		},
 	}, nil
 }
 
-func (s *reviewService) GetUserReviews(userID uint, page, limit int) ([]ReviewResponse, int, error) {
+func (s *reviewService) GetUserReviews(userID uint, page, limit int, after string) ([]ReviewResponse, int, string, error) {
 	// Validate pagination parameters
 	if page < 1 {
 			page = 1
@@ -167,9 +171,9 @@ func (s *reviewService) GetUserReviews(userID uint, page, limit int) ([]ReviewRe
 		limit = 10
 	}
 
-	reviews, total, err := s.repo.FindByUserID(userID, page, limit)
+	reviews, total, nextCursor, err := s.repo.FindByUserID(userID, page, limit, after)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to get user reviews: %w", err)
+		return nil, 0, "", fmt.Errorf("failed to get user reviews: %w", err)
 	}
 
 	// Convert reviews to responses
@@ -178,7 +182,7 @@ func (s *reviewService) GetUserReviews(userID uint, page, limit int) ([]ReviewRe
 		reviewResponses[i] = *s.convertToResponse(&review)
 	}
 
-	return reviewResponses, total, nil
+	return reviewResponses, total, nextCursor, nil
 }
 
 func (s *reviewService) GetCourseReviewSummary(courseID uint) (*CourseReviewSummary, error) {
@@ -38,6 +38,9 @@ func (h *Handler) CreateSession(c *gin.Context) {
 		if err == ErrInvalidScheduleTime {
 			statusCode = http.StatusBadRequest
 		}
+		if err == ErrUnauthorized {
+			statusCode = http.StatusForbidden
+		}
 		c.JSON(statusCode, gin.H{"error": err.Error()})
 		return
 	}
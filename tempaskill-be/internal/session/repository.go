@@ -24,8 +24,17 @@ type Repository interface {
 	FindSessionParticipants(ctx context.Context, sessionID uint) ([]*SessionParticipant, error)
 	MarkAttendance(ctx context.Context, sessionID, userID uint) error
 	CountParticipants(ctx context.Context, sessionID uint) (int, error)
+
+	// Course team lookups (courses and collaborators are owned by the course module)
+	FindCourseRole(ctx context.Context, courseID, userID uint) (string, error)
 }
 
+// Course roles returned by FindCourseRole besides collaborator roles
+const (
+	courseRoleOwner = "owner"
+	courseRoleAdmin = "admin"
+)
+
 type repository struct {
 	db *gorm.DB
 }
@@ -263,4 +272,46 @@ func (r *repository) CountParticipants(ctx context.Context, sessionID uint) (int
 		return 0, err
 	}
 	return int(count), nil
-}
\ No newline at end of file
+}
+// FindCourseRole returns the user's role on a course: owner, admin, a collaborator role, or "" for none
+func (r *repository) FindCourseRole(ctx context.Context, courseID, userID uint) (string, error) {
+	var instructorID uint
+	if err := r.db.WithContext(ctx).Table("courses").
+		Select("instructor_id").
+		Where("id = ? AND deleted_at IS NULL", courseID).
+		Scan(&instructorID).Error; err != nil {
+		logger.Error("Failed to find course instructor",
+			zap.Error(err),
+			zap.Uint("course_id", courseID),
+		)
+		return "", err
+	}
+	if instructorID != 0 && instructorID == userID {
+		return courseRoleOwner, nil
+	}
+
+	var userRole string
+	if err := r.db.WithContext(ctx).Table("users").
+		Select("role").
+		Where("id = ?", userID).
+		Scan(&userRole).Error; err != nil {
+		return "", err
+	}
+	if userRole == courseRoleAdmin {
+		return courseRoleAdmin, nil
+	}
+
+	var collaboratorRole string
+	if err := r.db.WithContext(ctx).Table("course_collaborators").
+		Select("role").
+		Where("course_id = ? AND user_id = ?", courseID, userID).
+		Scan(&collaboratorRole).Error; err != nil {
+		logger.Error("Failed to find course collaborator role",
+			zap.Error(err),
+			zap.Uint("course_id", courseID),
+			zap.Uint("user_id", userID),
+		)
+		return "", err
+	}
+	return collaboratorRole, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/internal/course"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -43,6 +44,19 @@ func NewService(repo Repository) Service {
 	return &service{repo: repo}
 }
 
+// Helper: Check the user's permission on a course. The course instructor and admins
+// hold every permission, collaborators only those granted by their role.
+func (s *service) hasCoursePermission(ctx context.Context, courseID, userID uint, permission string) bool {
+	role, err := s.repo.FindCourseRole(ctx, courseID, userID)
+	if err != nil {
+		return false
+	}
+	if role == courseRoleOwner || role == courseRoleAdmin {
+		return true
+	}
+	return course.RoleAllows(role, permission)
+}
+
 // Session operations
 func (s *service) CreateSession(ctx context.Context, userID uint, req *CreateSessionRequest) (*Session, error) {
 	// Parse scheduled time
@@ -65,8 +79,14 @@ func (s *service) CreateSession(ctx context.Context, userID uint, req *CreateSes
 		return nil, ErrInvalidScheduleTime
 	}
 
-	// TODO: Check if user is instructor of the course
-	// For now, allow any authenticated user to create sessions
+	// Only the course team (instructor, co-instructors) or admins may host sessions
+	if !s.hasCoursePermission(ctx, req.CourseID, userID, course.PermissionManageCourse) {
+		logger.Error("User is not authorized to create session for course",
+			zap.Uint("user_id", userID),
+			zap.Uint("course_id", req.CourseID),
+		)
+		return nil, ErrUnauthorized
+	}
 
 	session := &Session{
 		CourseID:        req.CourseID,
@@ -215,8 +235,8 @@ func (s *service) UpdateSession(ctx context.Context, userID uint, sessionID uint
 		return nil, ErrSessionNotFound
 	}
 
-	// Check if user is the instructor
-	if session.InstructorID != userID {
+	// Check if user is the host or manages the course
+	if session.InstructorID != userID && !s.hasCoursePermission(ctx, session.CourseID, userID, course.PermissionManageCourse) {
 		logger.Error("User is not authorized to update session",
 			zap.Uint("user_id", userID),
 			zap.Uint("session_id", sessionID),
@@ -305,8 +325,8 @@ func (s *service) DeleteSession(ctx context.Context, userID uint, sessionID uint
 		return ErrSessionNotFound
 	}
 
-	// Check if user is the instructor
-	if session.InstructorID != userID {
+	// Check if user is the host or manages the course
+	if session.InstructorID != userID && !s.hasCoursePermission(ctx, session.CourseID, userID, course.PermissionManageCourse) {
 		logger.Error("User is not authorized to delete session",
 			zap.Uint("user_id", userID),
 			zap.Uint("session_id", sessionID),
@@ -477,8 +497,8 @@ func (s *service) GetSessionParticipants(ctx context.Context, userID uint, sessi
 		return nil, ErrSessionNotFound
 	}
 
-	// Check if user is the instructor
-	if session.InstructorID != userID {
+	// Check if user is the host or teaches the course (co-instructors, TAs)
+	if session.InstructorID != userID && !s.hasCoursePermission(ctx, session.CourseID, userID, course.PermissionTeach) {
 		logger.Error("User is not authorized to view participants",
 			zap.Uint("user_id", userID),
 			zap.Uint("session_id", sessionID),
@@ -528,8 +548,8 @@ func (s *service) MarkAttendance(ctx context.Context, userID uint, sessionID uin
 		return ErrSessionNotFound
 	}
 
-	// Check if user is the instructor
-	if session.InstructorID != userID {
+	// Check if user is the host or teaches the course (co-instructors, TAs)
+	if session.InstructorID != userID && !s.hasCoursePermission(ctx, session.CourseID, userID, course.PermissionTeach) {
 		logger.Error("User is not authorized to mark attendance",
 			zap.Uint("user_id", userID),
 			zap.Uint("session_id", sessionID),
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/firebase"
 	"github.com/google/uuid"
 )
@@ -16,8 +18,17 @@ import (
 // Service handles file upload operations
 type Service interface {
 	UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (*UploadedFile, error)
+	UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (*UploadedFile, error)
+	UploadImageData(ctx context.Context, filename string, data []byte, folder string) (*UploadedFile, error)
+	UploadFileData(ctx context.Context, filename string, data []byte, folder string) (*UploadedFile, error)
+	SignedURL(ctx context.Context, path string, ttl time.Duration) (string, error)
+	ReadFile(ctx context.Context, path string) ([]byte, error)
+	DeleteFile(ctx context.Context, path string) error
 }
 
+// MaxFileSize is the maximum size of non-image files (documents, archives)
+const MaxFileSize = int64(20 * 1024 * 1024) // 20MB
+
 type service struct{}
 
 // NewService creates a new upload service
@@ -104,6 +115,229 @@ func (s *service) UploadImage(ctx context.Context, fileHeader *multipart.FileHea
 	}, nil
 }
 
+// UploadFile uploads a document/archive to Firebase Storage.
+// Unlike images the object is kept private: use SignedURL to hand out
+// short-lived download links after checking access.
+func (s *service) UploadFile(ctx context.Context, fileHeader *multipart.FileHeader, folder string) (*UploadedFile, error) {
+	// Validate file type (by extension, browsers report inconsistent MIME types for documents)
+	ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
+	contentType, ok := allowedFileTypes[ext]
+	if !ok {
+		return nil, fmt.Errorf("invalid file type: %s. Allowed: pdf, doc, docx, ppt, pptx, key, xls, xlsx, txt, md, csv, json, ipynb, zip, tar, gz, 7z, jpg, png", ext)
+	}
+
+	// Validate file size
+	if fileHeader.Size > MaxFileSize {
+		return nil, fmt.Errorf("file too large: %d bytes. Maximum: %d bytes (20MB)", fileHeader.Size, MaxFileSize)
+	}
+
+	// Open the uploaded file
+	src, err := fileHeader.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer src.Close()
+
+	// Construct path: folder/year/month/uuid.ext
+	now := time.Now()
+	path := filepath.Join(folder, fmt.Sprintf("%d", now.Year()), fmt.Sprintf("%02d", now.Month()), uuid.New().String()+ext)
+	path = strings.ReplaceAll(path, "\\", "/") // Use forward slashes for cloud storage
+
+	bucket, err := firebase.GetStorageClient().DefaultBucket()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage bucket: %v", err)
+	}
+
+	writer := bucket.Object(path).NewWriter(ctx)
+	writer.ContentType = contentType
+	writer.ContentDisposition = fmt.Sprintf("attachment; filename=%q", filepath.Base(fileHeader.Filename))
+
+	if _, err := io.Copy(writer, src); err != nil {
+		writer.Close()
+		return nil, fmt.Errorf("failed to upload file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close writer: %v", err)
+	}
+
+	return &UploadedFile{
+		Filename:   fileHeader.Filename,
+		Size:       fileHeader.Size,
+		MimeType:   contentType,
+		Path:       path,
+		UploadedAt: now,
+	}, nil
+}
+
+// UploadImageData uploads an in-memory image (e.g. from an imported course archive)
+// to Firebase Storage and makes it public, like UploadImage
+func (s *service) UploadImageData(ctx context.Context, filename string, data []byte, folder string) (*UploadedFile, error) {
+	contentType := http.DetectContentType(data)
+	if !isValidImageType(contentType) {
+		return nil, fmt.Errorf("invalid file type: %s. Allowed: jpg, jpeg, png, gif, webp", contentType)
+	}
+
+	maxSize := int64(5 * 1024 * 1024) // 5MB
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("file too large: %d bytes. Maximum: %d bytes (5MB)", len(data), maxSize)
+	}
+
+	now := time.Now()
+	path := filepath.Join(folder, fmt.Sprintf("%d", now.Year()), fmt.Sprintf("%02d", now.Month()), uuid.New().String()+filepath.Ext(filename))
+	path = strings.ReplaceAll(path, "\\", "/") // Use forward slashes for cloud storage
+
+	bucket, err := firebase.GetStorageClient().DefaultBucket()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage bucket: %v", err)
+	}
+
+	obj := bucket.Object(path)
+	writer := obj.NewWriter(ctx)
+	writer.ContentType = contentType
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return nil, fmt.Errorf("failed to upload file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close writer: %v", err)
+	}
+
+	if err := obj.ACL().Set(ctx, "allUsers", "READER"); err != nil {
+		return nil, fmt.Errorf("failed to make file public: %v", err)
+	}
+
+	publicURL := fmt.Sprintf("https://firebasestorage.googleapis.com/v0/b/%s/o/%s?alt=media",
+		obj.BucketName(), strings.ReplaceAll(path, "/", "%2F"))
+
+	return &UploadedFile{
+		Filename:   filename,
+		Size:       int64(len(data)),
+		MimeType:   contentType,
+		Path:       path,
+		URL:        publicURL,
+		UploadedAt: now,
+	}, nil
+}
+
+// UploadFileData uploads an in-memory document (e.g. a lesson attachment from an imported
+// course archive) as a private object, like UploadFile
+func (s *service) UploadFileData(ctx context.Context, filename string, data []byte, folder string) (*UploadedFile, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	contentType, ok := allowedFileTypes[ext]
+	if !ok {
+		return nil, fmt.Errorf("invalid file type: %s", ext)
+	}
+	if int64(len(data)) > MaxFileSize {
+		return nil, fmt.Errorf("file too large: %d bytes. Maximum: %d bytes (20MB)", len(data), MaxFileSize)
+	}
+
+	now := time.Now()
+	path := filepath.Join(folder, fmt.Sprintf("%d", now.Year()), fmt.Sprintf("%02d", now.Month()), uuid.New().String()+ext)
+	path = strings.ReplaceAll(path, "\\", "/") // Use forward slashes for cloud storage
+
+	bucket, err := firebase.GetStorageClient().DefaultBucket()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage bucket: %v", err)
+	}
+
+	writer := bucket.Object(path).NewWriter(ctx)
+	writer.ContentType = contentType
+	writer.ContentDisposition = fmt.Sprintf("attachment; filename=%q", filepath.Base(filename))
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return nil, fmt.Errorf("failed to upload file: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close writer: %v", err)
+	}
+
+	return &UploadedFile{
+		Filename:   filename,
+		Size:       int64(len(data)),
+		MimeType:   contentType,
+		Path:       path,
+		UploadedAt: now,
+	}, nil
+}
+
+// ReadFile downloads a stored object (at most MaxFileSize bytes), e.g. to export a private file
+func (s *service) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	bucket, err := firebase.GetStorageClient().DefaultBucket()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get storage bucket: %v", err)
+	}
+
+	reader, err := bucket.Object(path).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	if int64(len(data)) > MaxFileSize {
+		return nil, fmt.Errorf("file too large: more than %d bytes", MaxFileSize)
+	}
+	return data, nil
+}
+
+// SignedURL returns a temporary download URL for a private object
+func (s *service) SignedURL(ctx context.Context, path string, ttl time.Duration) (string, error) {
+	bucket, err := firebase.GetStorageClient().DefaultBucket()
+	if err != nil {
+		return "", fmt.Errorf("failed to get storage bucket: %v", err)
+	}
+
+	url, err := bucket.SignedURL(path, &storage.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(ttl),
+		Scheme:  storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to sign download URL: %v", err)
+	}
+
+	return url, nil
+}
+
+// DeleteFile removes a stored object, e.g. an upload whose database record could not be saved
+func (s *service) DeleteFile(ctx context.Context, path string) error {
+	bucket, err := firebase.GetStorageClient().DefaultBucket()
+	if err != nil {
+		return fmt.Errorf("failed to get storage bucket: %v", err)
+	}
+	if err := bucket.Object(path).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete file: %v", err)
+	}
+	return nil
+}
+
+// allowedFileTypes maps allowed document, archive and resource extensions to their content type
+var allowedFileTypes = map[string]string{
+	".pdf":   "application/pdf",
+	".doc":   "application/msword",
+	".docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".ppt":   "application/vnd.ms-powerpoint",
+	".pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".key":   "application/vnd.apple.keynote",
+	".xls":   "application/vnd.ms-excel",
+	".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".txt":   "text/plain",
+	".md":    "text/markdown",
+	".csv":   "text/csv",
+	".json":  "application/json",
+	".ipynb": "application/x-ipynb+json",
+	".zip":   "application/zip",
+	".tar":   "application/x-tar",
+	".gz":    "application/gzip",
+	".7z":    "application/x-7z-compressed",
+	".jpg":   "image/jpeg",
+	".jpeg":  "image/jpeg",
+	".png":   "image/png",
+}
+
 // isValidImageType checks if the content type is a valid image type
 func isValidImageType(contentType string) bool {
 	validTypes := []string{
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"github.com/gin-gonic/gin"
 )
 
@@ -66,7 +67,11 @@ func (h *WithdrawalHandler) ListWithdrawals(c *gin.Context) {
 		instructorID = userID.(uint)
 	}
 	
-	withdrawals, total, err := h.service.ListWithdrawals(instructorID, status, page, limit)
+	withdrawals, total, nextCursor, err := h.service.ListWithdrawals(instructorID, status, page, limit, c.Query("cursor"))
+	if err == cursor.ErrInvalidCursor {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -82,6 +87,8 @@ func (h *WithdrawalHandler) ListWithdrawals(c *gin.Context) {
 				"limit":       limit,
 				"total":       total,
 				"total_pages": totalPages,
+				"next_cursor": nextCursor,
+				"has_more":    nextCursor != "",
 			},
 		},
 	})
@@ -2,6 +2,8 @@ package withdrawal
 
 import (
 	"time"
+
+	"github.com/Hasanromadon/tempa-skill/tempaskill-be/pkg/cursor"
 	"gorm.io/gorm"
 )
 
@@ -9,7 +11,7 @@ type WithdrawalRepository interface {
 	GetBalance(instructorID uint) (*BalanceResponse, error)
 	CreateWithdrawal(withdrawal *WithdrawalRequest) error
 	GetWithdrawalByID(id uint) (*WithdrawalRequest, error)
-	ListWithdrawals(instructorID uint, status string, limit, offset int) ([]WithdrawalRequest, int64, error)
+	ListWithdrawals(instructorID uint, status string, limit, offset int, after string) ([]WithdrawalRequest, int64, string, error)
 	UpdateWithdrawalStatus(id uint, status string, processedBy uint, notes string) error
 	HasPendingWithdrawal(instructorID uint) (bool, error)
 	
@@ -72,7 +74,10 @@ func (r *withdrawalRepository) GetWithdrawalByID(id uint) (*WithdrawalRequest, e
 	return &withdrawal, err
 }
 
-func (r *withdrawalRepository) ListWithdrawals(instructorID uint, status string, limit, offset int) ([]WithdrawalRequest, int64, error) {
+// ListWithdrawals returns withdrawals newest first. after is the next_cursor of the previous
+// page; when set it replaces offset and the total is not counted. The returned cursor points
+// at the next page ("" on the last page).
+func (r *withdrawalRepository) ListWithdrawals(instructorID uint, status string, limit, offset int, after string) ([]WithdrawalRequest, int64, string, error) {
 	var withdrawals []WithdrawalRequest
 	var total int64
 	
@@ -84,10 +89,28 @@ func (r *withdrawalRepository) ListWithdrawals(instructorID uint, status string,
 		query = query.Where("status = ?", status)
 	}
 	
-	query.Count(&total)
-	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&withdrawals).Error
+	keys := []cursor.Key{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}}
+	if after != "" {
+		values, err := cursor.Decode(after, len(keys))
+		if err != nil {
+			return nil, 0, "", err
+		}
+		condition, args := cursor.Condition(keys, values)
+		query = query.Where(condition, args...)
+	} else {
+		query.Count(&total)
+		query = query.Offset(offset)
+	}
+	// One extra row tells whether a next page exists
+	err := query.Order(cursor.OrderClause(keys)).Limit(limit + 1).Find(&withdrawals).Error
+	if err != nil {
+		return nil, 0, "", err
+	}
 	
-	return withdrawals, total, err
+	withdrawals, next := cursor.Next(withdrawals, limit, func(w WithdrawalRequest) []interface{} {
+		return []interface{}{w.CreatedAt, w.ID}
+	})
+	return withdrawals, total, next, nil
 }
 
 func (r *withdrawalRepository) UpdateWithdrawalStatus(id uint, status string, processedBy uint, notes string) error {
@@ -8,7 +8,7 @@ type WithdrawalService interface {
 	GetBalance(instructorID uint) (*BalanceResponse, error)
 	RequestWithdrawal(instructorID uint, req CreateWithdrawalRequest) (*WithdrawalResponse, error)
 	GetWithdrawal(id uint, userID uint, isAdmin bool) (*WithdrawalRequest, error)
-	ListWithdrawals(instructorID uint, status string, page, limit int) ([]WithdrawalRequest, int64, error)
+	ListWithdrawals(instructorID uint, status string, page, limit int, after string) ([]WithdrawalRequest, int64, string, error)
 	ProcessWithdrawal(id uint, adminID uint, req ProcessWithdrawalRequest) error
 	
 	// Bank account methods
@@ -94,9 +94,9 @@ func (s *withdrawalService) GetWithdrawal(id uint, userID uint, isAdmin bool) (*
 	return withdrawal, nil
 }
 
-func (s *withdrawalService) ListWithdrawals(instructorID uint, status string, page, limit int) ([]WithdrawalRequest, int64, error) {
+func (s *withdrawalService) ListWithdrawals(instructorID uint, status string, page, limit int, after string) ([]WithdrawalRequest, int64, string, error) {
 	offset := (page - 1) * limit
-	return s.repo.ListWithdrawals(instructorID, status, limit, offset)
+	return s.repo.ListWithdrawals(instructorID, status, limit, offset, after)
 }
 
 func (s *withdrawalService) ProcessWithdrawal(id uint, adminID uint, req ProcessWithdrawalRequest) error {
@@ -0,0 +1,24 @@
+-- Migration: 017_create_course_sections_table.sql
+-- Description: Group lessons into sections (modules) within a course
+-- Date: 2026-10-16
+
+CREATE TABLE course_sections (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    order_index INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+
+    INDEX idx_course_sections_course (course_id),
+    INDEX idx_course_sections_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Lessons optionally belong to a section (NULL = not assigned)
+ALTER TABLE lessons
+ADD COLUMN section_id BIGINT UNSIGNED NULL AFTER course_id,
+ADD INDEX idx_lessons_section_id (section_id);
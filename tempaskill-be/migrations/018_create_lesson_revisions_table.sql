@@ -0,0 +1,36 @@
+-- Migration: 018_create_lesson_revisions_table.sql
+-- Description: Lesson revision history with draft/publish workflow
+-- Date: 2026-10-16
+
+CREATE TABLE lesson_revisions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    lesson_id BIGINT UNSIGNED NOT NULL,
+    version INT NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content LONGTEXT,
+    duration INT DEFAULT 0,
+    author_id BIGINT UNSIGNED NOT NULL,
+    restored_from_id BIGINT UNSIGNED NULL,
+    published_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+
+    UNIQUE INDEX idx_lesson_revisions_version (lesson_id, version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Live lesson content always mirrors the published revision;
+-- draft_revision_id points at unpublished edits (NULL = none)
+ALTER TABLE lessons
+ADD COLUMN published_revision_id BIGINT UNSIGNED NULL,
+ADD COLUMN draft_revision_id BIGINT UNSIGNED NULL;
+
+-- Backfill: current content of every lesson becomes its first published revision
+INSERT INTO lesson_revisions (lesson_id, version, title, content, duration, author_id, published_at, created_at)
+SELECT l.id, 1, l.title, l.content, l.duration, c.instructor_id, l.updated_at, l.updated_at
+FROM lessons l
+JOIN courses c ON c.id = l.course_id;
+
+UPDATE lessons l
+JOIN lesson_revisions r ON r.lesson_id = l.id AND r.version = 1
+SET l.published_revision_id = r.id;
@@ -0,0 +1,13 @@
+-- Migration: 019_add_lesson_types.sql
+-- Description: Lesson type discriminator (text, video, quiz, assignment) with typed payloads
+-- Date: 2026-10-16
+
+ALTER TABLE lessons
+ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'text' AFTER slug,
+ADD COLUMN video_url VARCHAR(500) NULL,
+ADD COLUMN video_duration INT DEFAULT 0 COMMENT 'Video length in seconds',
+ADD COLUMN caption_tracks JSON NULL COMMENT 'Array of {language, label, url}',
+ADD COLUMN quiz_id BIGINT UNSIGNED NULL,
+ADD COLUMN assignment_brief TEXT NULL,
+ADD COLUMN assignment_max_score INT DEFAULT 0,
+ADD INDEX idx_lessons_quiz_id (quiz_id);
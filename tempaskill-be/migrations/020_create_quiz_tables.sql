@@ -0,0 +1,90 @@
+-- Migration: 020_create_quiz_tables.sql
+-- Description: Quiz engine (question banks, attempts, auto-graded answers)
+-- Date: 2026-10-16
+
+CREATE TABLE quizzes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    pass_mark INT NOT NULL DEFAULT 70 COMMENT 'Minimum percentage to pass',
+    max_attempts INT NOT NULL DEFAULT 0 COMMENT '0 = unlimited',
+    questions_per_attempt INT NOT NULL DEFAULT 0 COMMENT '0 = whole question bank',
+    shuffle_questions BOOLEAN DEFAULT FALSE,
+    shuffle_options BOOLEAN DEFAULT FALSE,
+    created_by BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+
+    INDEX idx_quizzes_course (course_id),
+    INDEX idx_quizzes_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE quiz_questions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    quiz_id BIGINT UNSIGNED NOT NULL,
+    type VARCHAR(20) NOT NULL COMMENT 'single_choice, multiple_choice, true_false, short_answer',
+    prompt TEXT NOT NULL,
+    explanation TEXT,
+    points INT NOT NULL DEFAULT 1,
+    order_index INT NOT NULL DEFAULT 0,
+    accepted_answers JSON NULL COMMENT 'short_answer only',
+    case_sensitive BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+
+    INDEX idx_quiz_questions_quiz (quiz_id),
+    INDEX idx_quiz_questions_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE quiz_question_options (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    question_id BIGINT UNSIGNED NOT NULL,
+    text TEXT NOT NULL,
+    is_correct BOOLEAN DEFAULT FALSE,
+    order_index INT NOT NULL DEFAULT 0,
+
+    FOREIGN KEY (question_id) REFERENCES quiz_questions(id) ON DELETE CASCADE,
+
+    INDEX idx_quiz_question_options_question (question_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE quiz_attempts (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    quiz_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    question_ids JSON NULL COMMENT 'Questions served, in order',
+    status VARCHAR(20) NOT NULL DEFAULT 'in_progress',
+    score INT DEFAULT 0,
+    max_score INT DEFAULT 0,
+    percentage INT DEFAULT 0,
+    passed BOOLEAN DEFAULT FALSE,
+    started_at TIMESTAMP NULL,
+    submitted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    INDEX idx_quiz_attempts_quiz_user (quiz_id, user_id),
+    INDEX idx_quiz_attempts_passed (passed)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE quiz_attempt_answers (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    attempt_id BIGINT UNSIGNED NOT NULL,
+    question_id BIGINT UNSIGNED NOT NULL,
+    selected_option_ids JSON NULL,
+    text_answer TEXT,
+    is_correct BOOLEAN DEFAULT FALSE,
+    points_awarded INT DEFAULT 0,
+
+    FOREIGN KEY (attempt_id) REFERENCES quiz_attempts(id) ON DELETE CASCADE,
+
+    INDEX idx_quiz_attempt_answers_attempt (attempt_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
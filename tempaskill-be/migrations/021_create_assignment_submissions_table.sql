@@ -0,0 +1,33 @@
+-- Migration: 021_create_assignment_submissions_table.sql
+-- Description: Assignment submissions (file/link hand-ins with instructor grading)
+-- Date: 2026-10-16
+
+CREATE TABLE assignment_submissions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    lesson_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    course_id BIGINT UNSIGNED NOT NULL,
+    attempt INT NOT NULL DEFAULT 1 COMMENT '1, 2, 3... per student and lesson',
+    status VARCHAR(20) NOT NULL DEFAULT 'submitted' COMMENT 'submitted, needs_revision, accepted, superseded',
+    file_path VARCHAR(500) COMMENT 'Private storage path, served via signed URL',
+    file_name VARCHAR(255),
+    file_size BIGINT DEFAULT 0,
+    mime_type VARCHAR(100),
+    link_url VARCHAR(500),
+    note TEXT,
+    score INT NULL,
+    feedback TEXT,
+    graded_by BIGINT UNSIGNED NULL,
+    graded_at TIMESTAMP NULL,
+    submitted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (graded_by) REFERENCES users(id) ON DELETE SET NULL,
+
+    INDEX idx_assignment_submissions_lesson_user (lesson_id, user_id),
+    INDEX idx_assignment_submissions_course (course_id),
+    INDEX idx_assignment_submissions_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
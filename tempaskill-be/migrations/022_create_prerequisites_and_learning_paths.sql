@@ -0,0 +1,46 @@
+-- Migration: 022_create_prerequisites_and_learning_paths.sql
+-- Description: Course prerequisites (enforced at enrollment) and ordered learning paths
+-- Date: 2026-10-16
+
+CREATE TABLE course_prerequisites (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL COMMENT 'Course that requires the prerequisite',
+    prerequisite_course_id BIGINT UNSIGNED NOT NULL COMMENT 'Course that must be completed first',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (prerequisite_course_id) REFERENCES courses(id) ON DELETE CASCADE,
+
+    UNIQUE KEY idx_course_prerequisites_pair (course_id, prerequisite_course_id),
+    INDEX idx_course_prerequisites_prerequisite (prerequisite_course_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE learning_paths (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    slug VARCHAR(250) NOT NULL,
+    description TEXT,
+    thumbnail_url VARCHAR(255),
+    is_published BOOLEAN DEFAULT FALSE,
+    created_by BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    UNIQUE KEY idx_learning_paths_slug (slug),
+    INDEX idx_learning_paths_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE learning_path_courses (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    path_id BIGINT UNSIGNED NOT NULL,
+    course_id BIGINT UNSIGNED NOT NULL,
+    order_index INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (path_id) REFERENCES learning_paths(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+
+    UNIQUE KEY idx_learning_path_courses_pair (path_id, course_id),
+    INDEX idx_learning_path_courses_course (course_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
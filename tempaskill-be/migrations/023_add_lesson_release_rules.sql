@@ -0,0 +1,9 @@
+-- Migration: 023_add_lesson_release_rules.sql
+-- Description: Drip release rules per lesson (fixed date, N days after enrollment, after another lesson)
+-- Date: 2026-10-16
+
+ALTER TABLE lessons
+ADD COLUMN release_rule VARCHAR(30) NOT NULL DEFAULT 'immediate' COMMENT 'immediate, fixed_date, days_after_enrollment, after_lesson',
+ADD COLUMN release_at TIMESTAMP NULL COMMENT 'fixed_date',
+ADD COLUMN release_after_days INT DEFAULT 0 COMMENT 'days_after_enrollment',
+ADD COLUMN release_after_lesson_id BIGINT UNSIGNED NULL COMMENT 'after_lesson';
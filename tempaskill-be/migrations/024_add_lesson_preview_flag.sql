@@ -0,0 +1,6 @@
+-- Migration: 024_add_lesson_preview_flag.sql
+-- Description: Free preview lessons (full content for non-enrolled visitors)
+-- Date: 2026-10-16
+
+ALTER TABLE lessons
+ADD COLUMN is_preview BOOLEAN DEFAULT FALSE AFTER is_published;
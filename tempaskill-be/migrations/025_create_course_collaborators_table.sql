@@ -0,0 +1,20 @@
+-- Migration: 025_create_course_collaborators_table.sql
+-- Description: Course collaborators (co-instructor, editor, TA) with per-course revenue share
+-- Date: 2026-10-16
+
+CREATE TABLE course_collaborators (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    role VARCHAR(20) NOT NULL COMMENT 'co_instructor, editor, ta',
+    revenue_share INT NOT NULL DEFAULT 0 COMMENT 'Percent of the instructor share (co-instructors only); the owner keeps the remainder',
+    added_by BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE KEY idx_course_collaborators_pair (course_id, user_id),
+    INDEX idx_course_collaborators_user (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,12 @@
+-- Migration: 026_add_fulltext_search_indexes.sql
+-- Description: FULLTEXT indexes for course search (course title/description, lesson title/content)
+-- Date: 2026-10-16
+--
+-- InnoDB only indexes words of at least innodb_ft_min_token_size characters (default 3).
+-- Set it to 2 in my.cnf (and rebuild the indexes) so short terms like "go" or "ai" are searchable.
+
+ALTER TABLE courses
+ADD FULLTEXT INDEX ft_courses_search (title, description);
+
+ALTER TABLE lessons
+ADD FULLTEXT INDEX ft_lessons_search (title, content);
@@ -0,0 +1,30 @@
+-- Migration: 027_create_categories_table.sql
+-- Description: Admin-managed course category taxonomy (replaces the hardcoded category list)
+-- Date: 2026-10-16
+--
+-- courses.category keeps storing the category name; renaming a category updates its courses.
+
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    slug VARCHAR(60) NOT NULL,
+    icon VARCHAR(50) NOT NULL DEFAULT '',
+    parent_id BIGINT UNSIGNED NULL,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY idx_categories_name (name),
+    UNIQUE KEY idx_categories_slug (slug),
+    INDEX idx_categories_parent_id (parent_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Seed the categories that used to be hardcoded
+INSERT IGNORE INTO categories (name, slug, icon, sort_order) VALUES
+('Web Development', 'web-development', 'globe', 1),
+('Mobile Development', 'mobile-development', 'smartphone', 2),
+('Backend Development', 'backend-development', 'server', 3),
+('Data Science', 'data-science', 'bar-chart', 4),
+('DevOps', 'devops', 'cloud', 5),
+('Design', 'design', 'palette', 6),
+('Database', 'database', 'database', 7);
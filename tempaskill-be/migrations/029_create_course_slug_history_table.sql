@@ -0,0 +1,15 @@
+-- Migration: 029_create_course_slug_history_table.sql
+-- Description: Previous course slugs, so links to renamed courses resolve to the current slug
+-- Date: 2026-10-16
+
+CREATE TABLE course_slug_history (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL,
+    slug VARCHAR(250) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the course stopped using the slug',
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+
+    UNIQUE KEY idx_course_slug_history_slug (slug),
+    INDEX idx_course_slug_history_course_id (course_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,48 @@
+-- Migration: 030_add_course_review_workflow.sql
+-- Description: Course status lifecycle (draft → submitted → changes_requested → published → archived)
+--              with review history, and in-app notifications
+-- Date: 2026-10-16
+--
+-- is_published stays as a mirror of status = 'published' for modules that read it directly.
+
+ALTER TABLE courses
+ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft' COMMENT 'draft, submitted, changes_requested, published, archived' AFTER enrolled_count,
+ADD COLUMN submitted_at TIMESTAMP NULL AFTER status,
+ADD COLUMN reviewed_at TIMESTAMP NULL AFTER submitted_at,
+ADD COLUMN reviewed_by BIGINT UNSIGNED NULL AFTER reviewed_at,
+ADD COLUMN published_at TIMESTAMP NULL AFTER reviewed_by,
+ADD COLUMN archived_at TIMESTAMP NULL AFTER published_at,
+ADD INDEX idx_courses_status (status);
+
+-- Courses that were already live count as reviewed and published
+UPDATE courses SET status = 'published', published_at = updated_at WHERE is_published = TRUE;
+
+CREATE TABLE course_status_events (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL,
+    from_status VARCHAR(20) NOT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    actor_id BIGINT UNSIGNED NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    INDEX idx_course_status_events_course_id (course_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE notifications (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    type VARCHAR(50) NOT NULL COMMENT 'e.g. course_approved, course_changes_requested',
+    title VARCHAR(200) NOT NULL,
+    message TEXT,
+    link VARCHAR(255) COMMENT 'Frontend path to open',
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    INDEX idx_notifications_user_read (user_id, read_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
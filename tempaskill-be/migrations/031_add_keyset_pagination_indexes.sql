@@ -0,0 +1,21 @@
+-- Migration: 031_add_keyset_pagination_indexes.sql
+-- Description: Composite indexes backing cursor (keyset) pagination: sort column + id
+-- Date: 2026-10-16
+
+-- Course catalog (default sort: newest first)
+ALTER TABLE courses
+ADD INDEX idx_courses_created_at_id (created_at, id);
+
+-- Course and user review lists
+ALTER TABLE course_reviews
+ADD INDEX idx_reviews_course_created (course_id, created_at, id),
+ADD INDEX idx_reviews_user_created (user_id, created_at, id);
+
+-- Payment history and admin/instructor payment lists
+ALTER TABLE payment_transactions
+ADD INDEX idx_payments_user_created (user_id, created_at, id),
+ADD INDEX idx_payments_created_at_id (created_at, id);
+
+-- Withdrawal lists
+ALTER TABLE withdrawal_requests
+ADD INDEX idx_withdrawals_user_created (user_id, created_at, id);
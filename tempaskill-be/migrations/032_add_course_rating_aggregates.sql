@@ -0,0 +1,19 @@
+-- Migration: 032_add_course_rating_aggregates.sql
+-- Description: Denormalized average_rating and review_count on courses (kept in sync by the
+--              review repository) for rating sort and the min_rating filter
+-- Date: 2026-10-16
+
+ALTER TABLE courses
+ADD COLUMN average_rating DECIMAL(3,2) NOT NULL DEFAULT 0 AFTER enrolled_count,
+ADD COLUMN review_count INT NOT NULL DEFAULT 0 AFTER average_rating,
+ADD INDEX idx_courses_average_rating (average_rating);
+
+-- Backfill from existing reviews (same as `go run ./cmd/backfillratings`)
+UPDATE courses
+LEFT JOIN (
+    SELECT course_id, ROUND(AVG(rating), 2) AS average_rating, COUNT(*) AS review_count
+    FROM course_reviews
+    GROUP BY course_id
+) AS stats ON stats.course_id = courses.id
+SET courses.average_rating = COALESCE(stats.average_rating, 0),
+    courses.review_count = COALESCE(stats.review_count, 0);
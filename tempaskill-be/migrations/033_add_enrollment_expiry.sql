@@ -0,0 +1,11 @@
+-- Migration: 033_add_enrollment_expiry.sql
+-- Description: Time-limited course access. courses.access_duration_days (0 = lifetime) sets
+--              enrollments.expires_at at enrollment/payment time; NULL expires_at = lifetime
+-- Date: 2026-10-16
+
+ALTER TABLE courses
+ADD COLUMN access_duration_days INT NOT NULL DEFAULT 0 AFTER price;
+
+ALTER TABLE enrollments
+ADD COLUMN expires_at TIMESTAMP NULL AFTER enrolled_at,
+ADD INDEX idx_enrollments_expires_at (expires_at);
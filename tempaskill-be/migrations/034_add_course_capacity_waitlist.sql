@@ -0,0 +1,20 @@
+-- Migration: 034_add_course_capacity_waitlist.sql
+-- Description: Seat caps for cohort courses (courses.max_enrollments, 0 = unlimited) and the
+--              waitlist of full courses, promoted in join order as seats open up
+-- Date: 2026-10-16
+
+ALTER TABLE courses
+ADD COLUMN max_enrollments INT NOT NULL DEFAULT 0 AFTER access_duration_days;
+
+CREATE TABLE course_waitlist (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE INDEX idx_course_waitlist_course_user (course_id, user_id),
+    INDEX idx_course_waitlist_user_id (user_id),
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
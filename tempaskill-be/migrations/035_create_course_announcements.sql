@@ -0,0 +1,33 @@
+-- Migration: 035_create_course_announcements.sql
+-- Description: Course announcements posted by the course team to enrolled students, with
+--              per-user read receipts for unread counts
+-- Date: 2026-10-16
+
+CREATE TABLE course_announcements (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    course_id BIGINT UNSIGNED NOT NULL,
+    author_id BIGINT UNSIGNED NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    body LONGTEXT NOT NULL COMMENT 'MDX',
+    is_pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    INDEX idx_course_announcements_course_pinned (course_id, is_pinned)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE announcement_reads (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    announcement_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    read_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (announcement_id) REFERENCES course_announcements(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE INDEX idx_announcement_reads_pair (announcement_id, user_id),
+    INDEX idx_announcement_reads_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,44 @@
+-- Migration: 036_create_lesson_discussions.sql
+-- Description: Per-lesson discussion threads (comments and questions) with one level of
+--              replies, accepted answers, upvotes and moderator hiding
+-- Date: 2026-10-16
+
+CREATE TABLE lesson_comments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    lesson_id BIGINT UNSIGNED NOT NULL,
+    course_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    parent_id BIGINT UNSIGNED NULL COMMENT 'NULL = thread',
+    type VARCHAR(20) NOT NULL DEFAULT 'comment' COMMENT 'comment, question (threads only)',
+    body TEXT NOT NULL,
+    accepted_answer_id BIGINT UNSIGNED NULL COMMENT 'Questions: reply accepted by the course team',
+    upvote_count INT NOT NULL DEFAULT 0,
+    reply_count INT NOT NULL DEFAULT 0,
+    is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    edited_at TIMESTAMP NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    INDEX idx_lesson_comments_lesson_parent (lesson_id, parent_id),
+    INDEX idx_lesson_comments_course_id (course_id),
+    INDEX idx_lesson_comments_user_id (user_id),
+    INDEX idx_lesson_comments_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE lesson_comment_upvotes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    comment_id BIGINT UNSIGNED NOT NULL,
+    user_id BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (comment_id) REFERENCES lesson_comments(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+
+    UNIQUE INDEX idx_lesson_comment_upvotes_pair (comment_id, user_id),
+    INDEX idx_lesson_comment_upvotes_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
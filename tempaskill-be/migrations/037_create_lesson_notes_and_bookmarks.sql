@@ -0,0 +1,39 @@
+-- Migration: 037_create_lesson_notes_and_bookmarks.sql
+-- Description: Private learner notes on lessons (optionally anchored to a heading or video
+--              timestamp) and lesson bookmarks
+-- Date: 2026-10-16
+
+CREATE TABLE lesson_notes (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    course_id BIGINT UNSIGNED NOT NULL,
+    lesson_id BIGINT UNSIGNED NOT NULL,
+    body TEXT NOT NULL COMMENT 'Markdown',
+    heading VARCHAR(255) NULL COMMENT 'Anchor: heading text in the lesson content',
+    video_timestamp INT NULL COMMENT 'Anchor: seconds into the lesson video',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+
+    INDEX idx_lesson_notes_user_course (user_id, course_id),
+    INDEX idx_lesson_notes_lesson_id (lesson_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE lesson_bookmarks (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNSIGNED NOT NULL,
+    lesson_id BIGINT UNSIGNED NOT NULL,
+    course_id BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+
+    UNIQUE INDEX idx_lesson_bookmarks_pair (user_id, lesson_id),
+    INDEX idx_lesson_bookmarks_lesson_id (lesson_id),
+    INDEX idx_lesson_bookmarks_course_id (course_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
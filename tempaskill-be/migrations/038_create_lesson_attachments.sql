@@ -0,0 +1,27 @@
+-- Migration: 038_create_lesson_attachments.sql
+-- Description: Downloadable lesson resources (slides, starter code). Files are private in
+--              storage and served through short-lived signed links after an access check
+-- Date: 2026-10-16
+
+CREATE TABLE lesson_attachments (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    lesson_id BIGINT UNSIGNED NOT NULL,
+    course_id BIGINT UNSIGNED NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    file_path VARCHAR(500) NOT NULL COMMENT 'Storage object path (shared by duplicated courses)',
+    file_size BIGINT NOT NULL DEFAULT 0,
+    mime_type VARCHAR(100) NULL,
+    order_index INT NOT NULL DEFAULT 0,
+    uploaded_by BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+
+    FOREIGN KEY (lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    FOREIGN KEY (course_id) REFERENCES courses(id) ON DELETE CASCADE,
+
+    INDEX idx_lesson_attachments_lesson_id (lesson_id),
+    INDEX idx_lesson_attachments_course_id (course_id),
+    INDEX idx_lesson_attachments_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,12 @@
+-- Migration: 039_add_paid_waitlist_and_refund_flag.sql
+-- Description: Buyers whose payment settles after a course filled up are waitlisted at the
+--              front (course_waitlist.payment_id) and their payment is flagged for refund
+--              (payment_transactions.needs_refund) until a seat opens up
+-- Date: 2026-10-17
+
+ALTER TABLE course_waitlist
+ADD COLUMN payment_id BIGINT UNSIGNED NULL AFTER user_id,
+ADD INDEX idx_course_waitlist_payment_id (payment_id);
+
+ALTER TABLE payment_transactions
+ADD COLUMN needs_refund BOOLEAN NOT NULL DEFAULT FALSE AFTER midtrans_response;
@@ -0,0 +1,14 @@
+-- Migration: 040_add_lesson_revision_payloads.sql
+-- Description: Lesson type and typed payload are versioned with the content, so changing a
+--              lesson's type goes through a draft revision like any other edit. Existing
+--              revisions keep an empty type (publishing them leaves the lesson's type as is).
+-- Date: 2026-10-17
+
+ALTER TABLE lesson_revisions
+ADD COLUMN type VARCHAR(20) NULL,
+ADD COLUMN video_url VARCHAR(500) NULL,
+ADD COLUMN video_duration INT DEFAULT 0 COMMENT 'Video length in seconds',
+ADD COLUMN caption_tracks JSON NULL COMMENT 'Array of {language, label, url}',
+ADD COLUMN quiz_id BIGINT UNSIGNED NULL,
+ADD COLUMN assignment_brief TEXT NULL,
+ADD COLUMN assignment_max_score INT DEFAULT 0;
@@ -0,0 +1,150 @@
+// Package cursor implements opaque keyset pagination cursors.
+//
+// A cursor holds the sort key values of the last row of a page (sort column(s) plus the
+// unique id). The next page continues strictly after that row, so it is neither slowed down
+// by OFFSET nor shifted when rows are inserted or deleted between requests.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Key is one ORDER BY column of a keyset. The last key must be unique (usually the id).
+type Key struct {
+	Column string
+	Desc   bool
+}
+
+// value keeps the Go type of a key value so it binds back to SQL the same way
+type value struct {
+	Time   *time.Time `json:"t,omitempty"`
+	Number *float64   `json:"n,omitempty"`
+	String *string    `json:"s,omitempty"`
+}
+
+// Encode returns the cursor for a row from its key values, in key order.
+// Supported values: time.Time, string, bool and integer/float numbers.
+func Encode(values ...interface{}) string {
+	encoded := make([]value, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case time.Time:
+			encoded[i].Time = &v
+		case *time.Time:
+			if v != nil {
+				encoded[i].Time = v
+			}
+		case string:
+			encoded[i].String = &v
+		default:
+			if n, ok := toFloat(v); ok {
+				encoded[i].Number = &n
+			}
+		}
+	}
+	data, _ := json.Marshal(encoded)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a cursor into its key values. n is the number of keys the caller sorts by,
+// so a cursor taken under another sort is rejected.
+func Decode(token string, n int) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var encoded []value
+	if err := json.Unmarshal(data, &encoded); err != nil || len(encoded) != n {
+		return nil, ErrInvalidCursor
+	}
+
+	values := make([]interface{}, n)
+	for i, v := range encoded {
+		switch {
+		case v.Time != nil:
+			values[i] = *v.Time
+		case v.Number != nil:
+			values[i] = *v.Number
+		case v.String != nil:
+			values[i] = *v.String
+		default:
+			return nil, ErrInvalidCursor // NULL keys cannot be compared
+		}
+	}
+	return values, nil
+}
+
+// Condition builds the SQL condition selecting the rows after the cursor row:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) ..., with < for descending keys
+func Condition(keys []Key, values []interface{}) (string, []interface{}) {
+	var (
+		clauses []string
+		args    []interface{}
+	)
+	for i, key := range keys {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, keys[j].Column+" = ?")
+			args = append(args, values[j])
+		}
+		op := " > ?"
+		if key.Desc {
+			op = " < ?"
+		}
+		parts = append(parts, key.Column+op)
+		args = append(args, values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// OrderClause returns the ORDER BY clause matching the keys
+func OrderClause(keys []Key) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Column + " ASC"
+		if key.Desc {
+			parts[i] = key.Column + " DESC"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Next trims the extra row fetched (limit+1) to detect a following page and returns the
+// cursor for that page, or "" when rows is the last page
+func Next[T any](rows []T, limit int, keyValues func(T) []interface{}) ([]T, string) {
+	if limit < 1 || len(rows) <= limit {
+		return rows, ""
+	}
+	rows = rows[:limit]
+	return rows, Encode(keyValues(rows[limit-1])...)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,55 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEncodeDecode tests that key values keep their type through a cursor
+func TestEncodeDecode(t *testing.T) {
+	createdAt := time.Date(2026, 10, 16, 8, 30, 0, 123456000, time.UTC)
+	token := Encode(createdAt, "Go Basics", 150000.5, uint(42))
+
+	values, err := Decode(token, 4)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(values[0].(time.Time)))
+	assert.Equal(t, "Go Basics", values[1])
+	assert.Equal(t, 150000.5, values[2])
+	assert.Equal(t, float64(42), values[3])
+
+	// Wrong key count (cursor from another sort), garbage and NULL keys are rejected
+	_, err = Decode(token, 2)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = Decode("not-a-cursor", 2)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = Decode(Encode(nil, uint(1)), 2)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+// TestCondition tests the keyset condition and order clause
+func TestCondition(t *testing.T) {
+	keys := []Key{{Column: "price", Desc: false}, {Column: "id", Desc: true}}
+
+	condition, args := Condition(keys, []interface{}{100.0, 7.0})
+	assert.Equal(t, "((price > ?) OR (price = ? AND id < ?))", condition)
+	assert.Equal(t, []interface{}{100.0, 100.0, 7.0}, args)
+	assert.Equal(t, "price ASC, id DESC", OrderClause(keys))
+}
+
+// TestNext tests trimming the look-ahead row
+func TestNext(t *testing.T) {
+	ids := func(id int) []interface{} { return []interface{}{id} }
+
+	rows, next := Next([]int{1, 2, 3}, 2, ids)
+	assert.Equal(t, []int{1, 2}, rows)
+	values, err := Decode(next, 1)
+	require.NoError(t, err)
+	assert.Equal(t, float64(2), values[0])
+
+	rows, next = Next([]int{1, 2}, 2, ids)
+	assert.Equal(t, []int{1, 2}, rows)
+	assert.Empty(t, next)
+}
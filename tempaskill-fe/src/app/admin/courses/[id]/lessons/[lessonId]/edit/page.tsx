@@ -74,6 +74,7 @@ export default function EditLessonPage({ params }: PageProps) {
           order_index: data.orderIndex,
           duration: data.duration,
           is_published: data.isPublished,
+          publish: true,
         },
       });
 
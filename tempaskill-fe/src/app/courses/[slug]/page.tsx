@@ -58,7 +58,7 @@ import {
 import Image from "next/image";
 import Link from "next/link";
 import { useRouter } from "next/navigation";
-import { use, useState } from "react";
+import { use, useEffect, useState } from "react";
 import { toast } from "sonner";
 
 // --- Types ---
@@ -137,6 +137,13 @@ export default function CourseDetailPage({ params }: PageProps) {
   const [certificateError, setCertificateError] = useState("");
   const [downloading, setDownloading] = useState(false);
 
+  // Old slug of a renamed course: move to the current URL
+  useEffect(() => {
+    if (course?.canonical_slug) {
+      router.replace(ROUTES.COURSE_DETAIL(course.canonical_slug));
+    }
+  }, [course?.canonical_slug, router]);
+
   // --- Handlers ---
 
   const handleEnroll = async () => {
@@ -154,7 +161,13 @@ export default function CourseDetailPage({ params }: PageProps) {
 
     setEnrollError("");
     try {
-      await enrollCourse.mutateAsync(course.id);
+      const result = await enrollCourse.mutateAsync(course.id);
+      if (result.waitlisted) {
+        toast.info("Kursus sudah penuh", {
+          description: `Anda berada di urutan ${result.waitlist_position} daftar tunggu. Kami akan memberi tahu Anda saat ada kursi kosong.`,
+        });
+        return;
+      }
       toast.success("Berhasil mendaftar!", {
         description: `Selamat belajar di kursus "${course.title}"`,
       });
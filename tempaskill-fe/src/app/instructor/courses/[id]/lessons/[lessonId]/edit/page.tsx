@@ -39,6 +39,7 @@ export default function InstructorEditLessonPage({ params }: PageProps) {
         order_index: data.orderIndex,
         duration: data.duration ?? 0,
         is_published: data.isPublished ?? false,
+        publish: true,
       };
 
       console.log("🔄 Updating lesson with data:", apiData);
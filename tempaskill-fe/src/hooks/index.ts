@@ -1,4 +1,5 @@
 // Export all hooks from a single entry point
+export * from "./use-announcements";
 export * from "./use-auth";
 export * from "./use-bulk-selection";
 export * from "./use-certificate";
@@ -8,8 +9,10 @@ export * from "./use-dashboard-stats";
 export * from "./use-data-table";
 export * from "./use-debug";
 export * from "./use-delete-user";
+export * from "./use-discussions";
 export * from "./use-instructor";
 export * from "./use-lessons";
+export * from "./use-notes";
 export * from "./use-payment";
 export * from "./use-permissions";
 export * from "./use-progress";
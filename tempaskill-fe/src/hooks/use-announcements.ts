@@ -0,0 +1,102 @@
+import { apiClient } from "@/lib/api-client";
+import { API_ENDPOINTS } from "@/lib/constants";
+import type {
+  Announcement,
+  AnnouncementListResponse,
+  ApiResponse,
+  CreateAnnouncementRequest,
+  UpdateAnnouncementRequest,
+} from "@/types/api";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+
+// Hook for fetching a course's announcements (enrolled students and the course team)
+export const useCourseAnnouncements = (
+  courseId: number,
+  params?: { page?: number; limit?: number }
+) => {
+  return useQuery({
+    queryKey: ["announcements", courseId, params],
+    queryFn: async (): Promise<AnnouncementListResponse> => {
+      const response = await apiClient.get<
+        ApiResponse<AnnouncementListResponse>
+      >(API_ENDPOINTS.COURSES.ANNOUNCEMENTS(courseId), { params });
+      return response.data.data!;
+    },
+    enabled: !!courseId,
+  });
+};
+
+// Hook for posting an announcement (course team)
+export const useCreateAnnouncement = (courseId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (data: CreateAnnouncementRequest) => {
+      const response = await apiClient.post<ApiResponse<Announcement>>(
+        API_ENDPOINTS.COURSES.ANNOUNCEMENTS(courseId),
+        data
+      );
+      return response.data.data!;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["announcements", courseId] });
+    },
+  });
+};
+
+// Hook for editing, pinning or unpinning an announcement (course team)
+export const useUpdateAnnouncement = (courseId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async ({
+      id,
+      data,
+    }: {
+      id: number;
+      data: UpdateAnnouncementRequest;
+    }) => {
+      const response = await apiClient.patch<ApiResponse<Announcement>>(
+        API_ENDPOINTS.COURSES.ANNOUNCEMENT(courseId, id),
+        data
+      );
+      return response.data.data!;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["announcements", courseId] });
+    },
+  });
+};
+
+// Hook for deleting an announcement (course team)
+export const useDeleteAnnouncement = (courseId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (id: number) => {
+      await apiClient.delete(API_ENDPOINTS.COURSES.ANNOUNCEMENT(courseId, id));
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["announcements", courseId] });
+    },
+  });
+};
+
+// Hook for marking one announcement, or all of them, as read
+export const useMarkAnnouncementsRead = (courseId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (id?: number) => {
+      await apiClient.post(
+        id
+          ? API_ENDPOINTS.COURSES.ANNOUNCEMENT_READ(courseId, id)
+          : API_ENDPOINTS.COURSES.ANNOUNCEMENTS_READ_ALL(courseId)
+      );
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["announcements", courseId] });
+      queryClient.invalidateQueries({ queryKey: ["userProgress"] }); // Dashboard unread badge
+    },
+  });
+};
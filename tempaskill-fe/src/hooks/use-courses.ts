@@ -5,6 +5,9 @@ import type {
   Course,
   CourseListQuery,
   CourseListResponse,
+  Enrollment,
+  EnrollResponse,
+  WaitlistStatus,
 } from "@/types/api";
 import {
   keepPreviousData,
@@ -63,9 +66,10 @@ export const useEnrollCourse = () => {
 
   return useMutation({
     mutationFn: async (courseId: number) => {
-      const response = await apiClient.post<
-        ApiResponse<{ enrollment: unknown }>
-      >(API_ENDPOINTS.COURSES.ENROLL(courseId));
+      // A full course answers 202 with waitlisted: true and the waitlist position
+      const response = await apiClient.post<EnrollResponse>(
+        API_ENDPOINTS.COURSES.ENROLL(courseId)
+      );
       return response.data;
     },
     onSuccess: () => {
@@ -78,6 +82,58 @@ export const useEnrollCourse = () => {
   });
 };
 
+// Renew access to a free course (paid courses are renewed by buying them again)
+export const useRenewEnrollment = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (courseId: number) => {
+      const response = await apiClient.post<ApiResponse<Enrollment>>(
+        API_ENDPOINTS.COURSES.RENEW_ENROLLMENT(courseId)
+      );
+      return response.data;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["courses"] });
+      queryClient.invalidateQueries({ queryKey: ["course"] });
+      queryClient.invalidateQueries({ queryKey: ["progress"] });
+    },
+  });
+};
+
+// Own place on the waitlist of a full course
+export const useWaitlistPosition = (courseId: number, enabled = true) => {
+  return useQuery({
+    queryKey: ["waitlist", courseId],
+    queryFn: async () => {
+      const response = await apiClient.get<ApiResponse<WaitlistStatus>>(
+        API_ENDPOINTS.COURSES.WAITLIST(courseId)
+      );
+      return response.data.data;
+    },
+    enabled: !!courseId && enabled,
+    retry: false, // 404 = not on the waitlist
+  });
+};
+
+// Leave the waitlist of a full course
+export const useLeaveWaitlist = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (courseId: number) => {
+      const response = await apiClient.delete<ApiResponse<null>>(
+        API_ENDPOINTS.COURSES.WAITLIST(courseId)
+      );
+      return response.data;
+    },
+    onSuccess: (_, courseId) => {
+      queryClient.invalidateQueries({ queryKey: ["waitlist", courseId] });
+      queryClient.invalidateQueries({ queryKey: ["course"] });
+    },
+  });
+};
+
 // Unenroll from course
 export const useUnenrollCourse = () => {
   const queryClient = useQueryClient();
@@ -0,0 +1,139 @@
+import { apiClient } from "@/lib/api-client";
+import { API_ENDPOINTS } from "@/lib/constants";
+import type {
+  ApiResponse,
+  CommentListParams,
+  CommentListResponse,
+  CreateCommentRequest,
+  LessonComment,
+  UpdateCommentRequest,
+} from "@/types/api";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+
+// Hook for fetching a lesson's discussion threads (enrolled students and the course team)
+export const useLessonDiscussions = (
+  lessonId: number,
+  params?: CommentListParams
+) => {
+  return useQuery({
+    queryKey: ["discussions", lessonId, params],
+    queryFn: async (): Promise<CommentListResponse> => {
+      const response = await apiClient.get<ApiResponse<CommentListResponse>>(
+        API_ENDPOINTS.LESSONS.DISCUSSIONS(lessonId),
+        { params }
+      );
+      return response.data.data!;
+    },
+    enabled: !!lessonId,
+  });
+};
+
+// Hook for starting a thread or replying (parent_id)
+export const useCreateComment = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (data: CreateCommentRequest) => {
+      const response = await apiClient.post<ApiResponse<LessonComment>>(
+        API_ENDPOINTS.LESSONS.DISCUSSIONS(lessonId),
+        data
+      );
+      return response.data.data!;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["discussions", lessonId] });
+    },
+  });
+};
+
+// Hook for editing your own comment (within 30 minutes)
+export const useUpdateComment = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async ({
+      id,
+      data,
+    }: {
+      id: number;
+      data: UpdateCommentRequest;
+    }) => {
+      const response = await apiClient.patch<ApiResponse<LessonComment>>(
+        API_ENDPOINTS.DISCUSSIONS.DETAIL(id),
+        data
+      );
+      return response.data.data!;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["discussions", lessonId] });
+    },
+  });
+};
+
+// Hook for deleting a comment (author within 24 hours, or moderator)
+export const useDeleteComment = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (id: number) => {
+      await apiClient.delete(API_ENDPOINTS.DISCUSSIONS.DETAIL(id));
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["discussions", lessonId] });
+    },
+  });
+};
+
+// Hook for upvoting a comment or taking the upvote back
+export const useToggleUpvote = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async ({ id, upvote }: { id: number; upvote: boolean }) => {
+      if (upvote) {
+        await apiClient.post(API_ENDPOINTS.DISCUSSIONS.UPVOTE(id));
+      } else {
+        await apiClient.delete(API_ENDPOINTS.DISCUSSIONS.UPVOTE(id));
+      }
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["discussions", lessonId] });
+    },
+  });
+};
+
+// Hook for accepting a reply as a question's answer, or removing it (course team)
+export const useAcceptAnswer = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async ({ id, accept }: { id: number; accept: boolean }) => {
+      if (accept) {
+        await apiClient.post(API_ENDPOINTS.DISCUSSIONS.ACCEPT(id));
+      } else {
+        await apiClient.delete(API_ENDPOINTS.DISCUSSIONS.ACCEPT(id));
+      }
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["discussions", lessonId] });
+    },
+  });
+};
+
+// Hook for hiding a comment from students, or showing it again (course team)
+export const useHideComment = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async ({ id, hide }: { id: number; hide: boolean }) => {
+      if (hide) {
+        await apiClient.post(API_ENDPOINTS.DISCUSSIONS.HIDE(id));
+      } else {
+        await apiClient.delete(API_ENDPOINTS.DISCUSSIONS.HIDE(id));
+      }
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["discussions", lessonId] });
+    },
+  });
+};
@@ -1,6 +1,12 @@
 import apiClient from "@/lib/api-client";
 import { API_ENDPOINTS } from "@/lib/constants";
-import type { ApiResponse, Lesson } from "@/types/api";
+import type {
+  ApiResponse,
+  AttachmentDownload,
+  CourseLessons,
+  Lesson,
+  LessonAttachment,
+} from "@/types/api";
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 
 // Get lessons for a course
@@ -8,10 +14,11 @@ export const useCourseLessons = (courseId: number) => {
   return useQuery({
     queryKey: ["lessons", courseId],
     queryFn: async () => {
-      const response = await apiClient.get<ApiResponse<Lesson[]>>(
+      const response = await apiClient.get<ApiResponse<CourseLessons>>(
         API_ENDPOINTS.COURSES.LESSONS(courseId)
       );
-      return response.data.data || [];
+      // Flat list; the grouped tree is available under `sections`
+      return response.data.data?.lessons || [];
     },
     enabled: !!courseId,
     staleTime: 0, // Always refetch on invalidation
@@ -50,6 +57,7 @@ export const useCreateLesson = () => {
         order_index: number;
         duration: number;
         is_published: boolean;
+        is_preview?: boolean;
       };
     }) => {
       const response = await apiClient.post<ApiResponse<Lesson>>(
@@ -85,6 +93,8 @@ export const useUpdateLesson = () => {
         order_index: number;
         duration: number;
         is_published: boolean;
+        is_preview: boolean; // free sample for non-enrolled visitors
+        publish: boolean; // publish the saved draft immediately
       }>;
     }) => {
       console.log(`📤 PATCH /lessons/${id}`, data);
@@ -125,3 +135,55 @@ export const useDeleteLesson = () => {
     },
   });
 };
+
+// Upload a downloadable resource to a lesson (course team)
+export const useUploadAttachment = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async ({ file, title }: { file: File; title?: string }) => {
+      const formData = new FormData();
+      formData.append("file", file);
+      if (title) {
+        formData.append("title", title);
+      }
+      const response = await apiClient.post<ApiResponse<LessonAttachment>>(
+        API_ENDPOINTS.LESSONS.ATTACHMENTS(lessonId),
+        formData,
+        { headers: { "Content-Type": "multipart/form-data" } }
+      );
+      return response.data.data!;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["lesson", lessonId] });
+    },
+  });
+};
+
+// Delete a lesson attachment (course team)
+export const useDeleteAttachment = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (attachmentId: number) => {
+      await apiClient.delete(
+        API_ENDPOINTS.LESSONS.ATTACHMENT(lessonId, attachmentId)
+      );
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["lesson", lessonId] });
+    },
+  });
+};
+
+// Get a short-lived download link for an attachment (checked against lesson access)
+export const useDownloadAttachment = (lessonId: number) => {
+  return useMutation({
+    mutationFn: async (attachmentId: number) => {
+      const response = await apiClient.get<ApiResponse<AttachmentDownload>>(
+        API_ENDPOINTS.LESSONS.ATTACHMENT_DOWNLOAD(lessonId, attachmentId)
+      );
+      return response.data.data!;
+    },
+  });
+};
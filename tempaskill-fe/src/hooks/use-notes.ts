@@ -0,0 +1,134 @@
+import { apiClient } from "@/lib/api-client";
+import { API_ENDPOINTS } from "@/lib/constants";
+import type {
+  ApiResponse,
+  CourseNotes,
+  LessonBookmark,
+  LessonNote,
+  LessonNotes,
+  NoteRequest,
+} from "@/types/api";
+import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
+
+// Hook for fetching my notes and bookmark state on a lesson
+export const useLessonNotes = (lessonId: number) => {
+  return useQuery({
+    queryKey: ["notes", "lesson", lessonId],
+    queryFn: async (): Promise<LessonNotes> => {
+      const response = await apiClient.get<ApiResponse<LessonNotes>>(
+        API_ENDPOINTS.LESSONS.NOTES(lessonId)
+      );
+      return response.data.data!;
+    },
+    enabled: !!lessonId,
+  });
+};
+
+// Hook for fetching my notes across a course, grouped by lesson
+export const useCourseNotes = (courseId: number) => {
+  return useQuery({
+    queryKey: ["notes", "course", courseId],
+    queryFn: async (): Promise<CourseNotes> => {
+      const response = await apiClient.get<ApiResponse<CourseNotes>>(
+        API_ENDPOINTS.NOTES.COURSE(courseId)
+      );
+      return response.data.data!;
+    },
+    enabled: !!courseId,
+  });
+};
+
+// Hook for adding a note to a lesson
+export const useCreateNote = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (data: NoteRequest) => {
+      const response = await apiClient.post<ApiResponse<LessonNote>>(
+        API_ENDPOINTS.LESSONS.NOTES(lessonId),
+        data
+      );
+      return response.data.data!;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["notes"] });
+    },
+  });
+};
+
+// Hook for replacing a note's body and anchors
+export const useUpdateNote = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async ({ id, data }: { id: number; data: NoteRequest }) => {
+      const response = await apiClient.put<ApiResponse<LessonNote>>(
+        API_ENDPOINTS.NOTES.DETAIL(id),
+        data
+      );
+      return response.data.data!;
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["notes"] });
+    },
+  });
+};
+
+// Hook for deleting a note
+export const useDeleteNote = () => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (id: number) => {
+      await apiClient.delete(API_ENDPOINTS.NOTES.DETAIL(id));
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["notes"] });
+    },
+  });
+};
+
+// Hook for downloading my notes on a course as a Markdown file
+export const useExportNotes = () => {
+  return useMutation({
+    mutationFn: async (courseId: number) => {
+      const res = await apiClient.get(API_ENDPOINTS.NOTES.EXPORT(courseId), {
+        responseType: "blob",
+      });
+      return res.data as Blob;
+    },
+  });
+};
+
+// Hook for fetching my bookmarked lessons, optionally for one course
+export const useBookmarks = (courseId?: number) => {
+  return useQuery({
+    queryKey: ["bookmarks", courseId],
+    queryFn: async (): Promise<LessonBookmark[]> => {
+      const response = await apiClient.get<ApiResponse<LessonBookmark[]>>(
+        API_ENDPOINTS.NOTES.BOOKMARKS,
+        { params: courseId ? { course_id: courseId } : undefined }
+      );
+      return response.data.data!;
+    },
+  });
+};
+
+// Hook for bookmarking a lesson or removing the bookmark
+export const useToggleBookmark = (lessonId: number) => {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (bookmark: boolean) => {
+      if (bookmark) {
+        await apiClient.post(API_ENDPOINTS.LESSONS.BOOKMARK(lessonId));
+      } else {
+        await apiClient.delete(API_ENDPOINTS.LESSONS.BOOKMARK(lessonId));
+      }
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["notes"] });
+      queryClient.invalidateQueries({ queryKey: ["bookmarks"] });
+    },
+  });
+};
@@ -72,6 +72,15 @@ export const API_ENDPOINTS = {
     UPDATE: (id: number) => `/courses/${id}`,
     DELETE: (id: number) => `/courses/${id}`,
     ENROLL: (id: number) => `/courses/${id}/enroll`,
+    RENEW_ENROLLMENT: (id: number) => `/courses/${id}/enroll/renew`,
+    WAITLIST: (id: number) => `/courses/${id}/waitlist`,
+    ANNOUNCEMENTS: (id: number) => `/courses/${id}/announcements`,
+    ANNOUNCEMENT: (id: number, announcementId: number) =>
+      `/courses/${id}/announcements/${announcementId}`,
+    ANNOUNCEMENT_READ: (id: number, announcementId: number) =>
+      `/courses/${id}/announcements/${announcementId}/read`,
+    ANNOUNCEMENTS_READ_ALL: (id: number) =>
+      `/courses/${id}/announcements/read-all`,
     LESSONS: (id: number) => `/courses/${id}/lessons`,
   },
   LESSONS: {
@@ -80,6 +89,26 @@ export const API_ENDPOINTS = {
     UPDATE: (id: number) => `/lessons/${id}`,
     DELETE: (id: number) => `/lessons/${id}`,
     REORDER: "/lessons/reorder",
+    DISCUSSIONS: (id: number) => `/lessons/${id}/discussions`,
+    NOTES: (id: number) => `/lessons/${id}/notes`,
+    BOOKMARK: (id: number) => `/lessons/${id}/bookmark`,
+    ATTACHMENTS: (id: number) => `/lessons/${id}/attachments`,
+    ATTACHMENT: (id: number, attachmentId: number) =>
+      `/lessons/${id}/attachments/${attachmentId}`,
+    ATTACHMENT_DOWNLOAD: (id: number, attachmentId: number) =>
+      `/lessons/${id}/attachments/${attachmentId}/download`,
+  },
+  NOTES: {
+    DETAIL: (id: number) => `/notes/${id}`,
+    COURSE: (courseId: number) => `/courses/${courseId}/notes`,
+    EXPORT: (courseId: number) => `/courses/${courseId}/notes/export`,
+    BOOKMARKS: "/bookmarks",
+  },
+  DISCUSSIONS: {
+    DETAIL: (id: number) => `/discussions/${id}`,
+    UPVOTE: (id: number) => `/discussions/${id}/upvote`,
+    ACCEPT: (id: number) => `/discussions/${id}/accept`,
+    HIDE: (id: number) => `/discussions/${id}/hide`,
   },
   PROGRESS: {
     COURSE: (courseId: number) => `/progress/courses/${courseId}`,
@@ -18,8 +18,10 @@ export interface PaginatedData<T> {
   pagination: {
     page: number;
     limit: number;
-    total: number;
+    total: number; // 0 when following a cursor
     total_pages: number;
+    next_cursor?: string; // Pass as `cursor` to fetch the next page
+    has_more?: boolean;
   };
 }
 
@@ -107,14 +109,250 @@ export interface Course {
   difficulty: "beginner" | "intermediate" | "advanced";
   instructor_id: number;
   price: number;
-  is_published: boolean;
+  access_duration_days?: number; // 0 = lifetime access
+  max_enrollments?: number; // Seat cap; 0 = unlimited
+  is_published: boolean; // Mirrors status === "published"
+  status?: CourseStatus;
   enrolled_count: number;
+  average_rating?: number; // 0 until the first review
+  review_count?: number;
   lesson_count: number;
   is_enrolled: boolean;
+  tags?: Tag[];
+  created_at: string;
+  updated_at: string;
+  prerequisites?: CoursePrerequisite[]; // Detail view only
+  access_expires_at?: string; // Detail view: viewer's enrollment end
+  access_expired?: boolean; // Detail view: enrollment lapsed, renew to continue
+  waitlist_position?: number; // Detail view: viewer's place on a full course's waitlist
+  snippets?: SearchSnippet[]; // Search results only
+  canonical_slug?: string; // Set when fetched by an old slug: redirect to it
+}
+
+export type CourseStatus =
+  | "draft"
+  | "submitted"
+  | "changes_requested"
+  | "published"
+  | "archived";
+
+export interface CourseStatusEvent {
+  id: number;
+  course_id: number;
+  from_status: CourseStatus;
+  to_status: CourseStatus;
+  actor_id: number;
+  comment: string;
+  created_at: string;
+  actor?: { id: number; name: string };
+}
+
+export interface ReviewCourseRequest {
+  decision: "approve" | "request_changes";
+  comment?: string;
+}
+
+export interface Notification {
+  id: number;
+  user_id: number;
+  type: string;
+  title: string;
+  message: string;
+  link?: string;
+  read_at?: string | null;
+  created_at: string;
+}
+
+export interface NotificationListResponse {
+  notifications: Notification[];
+  unread_count: number;
+  pagination: { page: number; limit: number; total: number; total_pages: number };
+}
+
+// Course announcements (enrolled students and the course team)
+export interface Announcement {
+  id: number;
+  course_id: number;
+  author_id: number;
+  author_name: string;
+  title: string;
+  body: string; // MDX
+  is_pinned: boolean;
+  is_read: boolean;
   created_at: string;
   updated_at: string;
 }
 
+export interface AnnouncementListResponse {
+  announcements: Announcement[]; // Pinned first, then newest
+  unread_count: number;
+  pagination: { page: number; limit: number; total: number; total_pages: number };
+}
+
+export interface CreateAnnouncementRequest {
+  title: string;
+  body: string;
+  is_pinned?: boolean;
+}
+
+export type UpdateAnnouncementRequest = Partial<CreateAnnouncementRequest>;
+
+// Lesson discussions (enrolled students and the course team)
+export type CommentType = "comment" | "question";
+
+export interface LessonComment {
+  id: number;
+  lesson_id: number;
+  parent_id: number | null; // null = thread
+  user_id: number;
+  author_name: string;
+  author_is_staff: boolean; // Instructor or collaborator badge
+  type: CommentType;
+  body: string;
+  accepted_answer_id?: number; // Questions only
+  is_accepted_answer: boolean;
+  upvote_count: number;
+  has_upvoted: boolean;
+  reply_count: number;
+  is_hidden: boolean; // Only moderators see hidden comments
+  edited_at: string | null;
+  can_edit: boolean; // Author, within 30 minutes
+  can_delete: boolean; // Author within 24 hours, or moderator
+  created_at: string;
+  replies?: LessonComment[]; // Threads only: accepted answer first, then oldest
+}
+
+export interface CommentListResponse {
+  threads: LessonComment[];
+  pagination: { page: number; limit: number; total: number; total_pages: number };
+}
+
+export interface CommentListParams {
+  page?: number;
+  limit?: number;
+  sort?: "newest" | "oldest" | "top";
+  type?: CommentType;
+  unanswered?: boolean;
+}
+
+export interface CreateCommentRequest {
+  body: string;
+  type?: CommentType; // Threads only
+  parent_id?: number;
+}
+
+export interface UpdateCommentRequest {
+  body?: string;
+  type?: CommentType;
+}
+
+// Private lesson notes and bookmarks (only ever the current user's)
+export interface LessonNote {
+  id: number;
+  course_id: number;
+  lesson_id: number;
+  body: string; // Markdown
+  heading?: string; // Anchor: heading text in the lesson content
+  video_timestamp?: number; // Anchor: seconds into the lesson video
+  created_at: string;
+  updated_at: string;
+}
+
+export interface LessonNotes {
+  lesson_id: number;
+  lesson_title: string;
+  is_bookmarked: boolean;
+  notes: LessonNote[]; // Oldest first
+}
+
+export interface CourseNotes {
+  course_id: number;
+  course_title: string;
+  lessons: LessonNotes[]; // Course order; lessons without notes only when bookmarked
+  total_notes: number;
+}
+
+// Used for creating and replacing a note (omitted anchors are cleared)
+export interface NoteRequest {
+  body: string;
+  heading?: string;
+  video_timestamp?: number;
+}
+
+export interface LessonBookmark {
+  lesson_id: number;
+  lesson_title: string;
+  course_id: number;
+  course_title: string;
+  course_slug: string;
+  created_at: string;
+}
+
+// Where a search matched; text is HTML-escaped with matches wrapped in <mark>
+export interface SearchSnippet {
+  field: "title" | "description" | "lesson";
+  lesson_id?: number;
+  lesson_title?: string;
+  text: string;
+}
+
+export interface Tag {
+  id: number;
+  name: string;
+  slug: string;
+}
+
+export interface TagWithCount extends Tag {
+  course_count: number; // Published courses
+}
+
+// GET /tags/:slug
+export interface TagPage {
+  tag: Tag;
+  courses: Course[];
+  pagination: { page: number; limit: number; total: number; total_pages: number };
+  related_tags: TagWithCount[];
+}
+
+export interface CoursePrerequisite {
+  id: number;
+  title: string;
+  slug: string;
+  difficulty: "beginner" | "intermediate" | "advanced";
+  is_completed?: boolean; // Only for logged-in users
+}
+
+export type CollaboratorRole = "owner" | "co_instructor" | "editor" | "ta";
+
+export interface CourseCollaborator {
+  user_id: number;
+  name: string;
+  email: string;
+  role: CollaboratorRole;
+  revenue_share: number; // Percent of the instructor share; owner keeps the remainder
+  added_at: string;
+}
+
+export interface AddCollaboratorRequest {
+  email: string;
+  role: Exclude<CollaboratorRole, "owner">;
+  revenue_share?: number; // Co-instructors only
+}
+
+// Result of POST /courses/import (multipart field "archive")
+export interface CourseImportResult {
+  course_id: number;
+  title: string;
+  slug: string;
+  lessons_imported: number;
+  lesson_errors: {
+    file: string;
+    title: string;
+    errors: string[];
+  }[]; // Skipped lessons
+  warnings: string[];
+}
+
 export interface CourseListQuery {
   page?: number;
   limit?: number;
@@ -125,8 +363,39 @@ export interface CourseListQuery {
   sortOrder?: string;
   minPrice?: number;
   maxPrice?: number;
+  minRating?: number; // 0-5
+  priceBucket?: "free" | "under_100k" | "100k_500k" | "over_500k";
   instructorId?: number;
   published?: boolean;
+  tags?: string[]; // Tag slugs, sent comma-separated
+  tagMatch?: "any" | "all";
+  cursor?: string; // next_cursor of the previous page; replaces page
+}
+
+// GET /courses/facets (same filters as /courses); each facet ignores its own filter
+export interface FacetCount {
+  value: string; // category, difficulty or price_bucket query value
+  label: string;
+  count: number;
+}
+
+export interface CourseFacets {
+  total: number;
+  categories: FacetCount[];
+  difficulties: FacetCount[];
+  price_buckets: FacetCount[]; // free, under_100k, 100k_500k, over_500k
+  instructors: { instructor_id: number; name: string; count: number }[];
+}
+
+export interface Category {
+  id: number;
+  name: string;
+  slug: string;
+  icon: string;
+  parent_id: number | null;
+  sort_order: number;
+  course_count: number; // published courses, including sub-categories
+  children?: Category[];
 }
 
 export interface CourseListResponse {
@@ -140,16 +409,106 @@ export interface CourseListResponse {
 export interface Lesson {
   id: number;
   course_id: number;
+  section_id?: number | null;
   title: string;
   slug: string;
+  type: LessonType;
   content: string;
   order_index: number;
   duration: number;
   is_published: boolean;
+  is_preview: boolean; // free sample, full content without enrollment
+  video?: VideoPayload;
+  quiz?: { quiz_id: number };
+  assignment?: { brief: string; max_score: number };
+  attachments?: LessonAttachment[]; // detail view only, with the content
+  published_revision_id?: number | null;
+  draft?: LessonRevision; // instructor only, unpublished edits
+  release?: LessonRelease; // drip rule, detail view only
+  is_locked?: boolean; // not released yet for the student (no content)
+  available_at?: string; // release time for time-based rules
+  unlock_after_lesson_id?: number; // lesson to complete first
   created_at: string;
   updated_at: string;
 }
 
+export type LessonType = "text" | "video" | "quiz" | "assignment";
+
+// Downloadable lesson resource; fetch the file through useDownloadAttachment
+export interface LessonAttachment {
+  id: number;
+  lesson_id: number;
+  title: string;
+  file_name: string;
+  file_size: number; // bytes
+  mime_type: string;
+  created_at: string;
+}
+
+// Short-lived signed link to an attachment file
+export interface AttachmentDownload {
+  url: string;
+  file_name: string;
+  url_expires_at: string;
+}
+
+export interface LessonRelease {
+  rule: "immediate" | "fixed_date" | "days_after_enrollment" | "after_lesson";
+  at?: string;
+  after_days?: number;
+  after_lesson_id?: number;
+}
+
+export interface CaptionTrack {
+  language: string;
+  label: string;
+  url: string;
+}
+
+export interface VideoPayload {
+  url: string;
+  duration_seconds: number;
+  captions?: CaptionTrack[];
+}
+
+export interface LessonRevision {
+  id: number;
+  lesson_id: number;
+  version: number;
+  title: string;
+  content?: string;
+  duration: number;
+  type?: LessonType; // absent on revisions saved before typed payloads were versioned
+  author_id: number;
+  restored_from_id?: number;
+  status: "draft" | "published" | "superseded";
+  published_at: string | null;
+  created_at: string;
+  video?: VideoPayload;
+  quiz?: { quiz_id: number };
+  assignment?: { brief: string; max_score: number };
+}
+
+export interface Section {
+  id: number;
+  course_id: number;
+  title: string;
+  description: string;
+  order_index: number;
+  lesson_count: number;
+  completed_count: number;
+  lessons: Lesson[];
+  created_at: string;
+}
+
+export interface CourseLessons {
+  sections: Section[];
+  unsectioned_lessons: Lesson[];
+  lessons: Lesson[];
+  total_lessons: number;
+  completed_lessons: number;
+}
+
 export interface CreateLessonRequest {
   title: string;
   slug: string;
@@ -183,12 +542,14 @@ export interface UserProgress {
   enrolled_at?: string;
   status?: string;
   thumbnail_url?: string;
+  unread_announcements?: number;
 }
 
 export interface UserProgressSummary {
   total_enrolled: number;
   total_completed: number;
   total_in_progress: number;
+  unread_announcements?: number; // Across all enrolled courses
   courses: UserProgress[];
 }
 
@@ -203,9 +564,29 @@ export interface Enrollment {
   course_id: number;
   progress: number;
   enrolled_at: string;
+  expires_at?: string | null; // null = lifetime access
   updated_at: string;
 }
 
+// POST /courses/:id/enroll on a full course answers 202 with the waitlist place
+export interface EnrollResponse {
+  message: string;
+  waitlisted?: boolean;
+  waitlist_position?: number;
+}
+
+export interface WaitlistStatus {
+  course_id: number;
+  position: number;
+}
+
+// PATCH /courses/:id/enrollments/:userId (admin): set exactly one field
+export interface ExtendEnrollmentRequest {
+  days?: number;
+  expires_at?: string;
+  lifetime?: boolean;
+}
+
 // Session Types
 export interface Session {
   id: number;
@@ -383,6 +764,7 @@ export interface Payment {
   transaction_time: string;
   settlement_time?: string;
   payment_url?: string;
+  needs_refund: boolean; // Settled while the course was full
   created_at: string;
   updated_at: string;
 }
@@ -397,6 +779,7 @@ export interface PaymentWithDetails {
   transaction_time: string;
   settlement_time?: string;
   payment_url?: string; // For repayment
+  needs_refund: boolean; // Settled while the course was full
 
   // User details
   user_id: number;
@@ -429,6 +812,7 @@ export interface PaymentResponse {
   transaction_time: string;
   settlement_time?: string;
   payment_url?: string;
+  needs_refund: boolean; // Settled while the course was full
   created_at: string;
   updated_at: string;
 }
@@ -29,8 +29,10 @@ export interface Breadcrumb {
 export interface PaginationMeta {
   page: number;
   limit: number;
-  total: number;
+  total: number; // 0 when following a cursor
   total_pages: number;
+  next_cursor?: string; // Pass as `cursor` to fetch the next page
+  has_more?: boolean;
 }
 
 /**